@@ -56,21 +56,31 @@ import (
 const idRegex = `/{id:[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[1-5][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12}}`
 
 const (
-	auditsPath          = "/audits"
-	stewardPath         = "/steward"
-	usersPath           = "/" + domain.TypeUser
-	claimsPath          = "/" + domain.TypeClaim
-	claimFilesPath      = "/" + domain.TypeClaimFile
-	claimItemsPath      = "/" + domain.TypeClaimItem
-	filesPath           = "/" + domain.TypeFile
-	itemsPath           = "/" + domain.TypeItem
-	ledgerReportPath    = "/" + domain.TypeLedgerReport
-	policiesPath        = "/" + domain.TypePolicy
-	policyDependentPath = "/" + domain.TypePolicyDependent
-	entityCodesPath     = "/" + domain.TypeEntityCode
-	policyMemberPath    = "/" + domain.TypePolicyMember
-	repairsPath         = "/repairs"
-	strikesPath         = "/" + domain.TypeStrike
+	auditsPath              = "/audits"
+	certificatesPath        = "/" + domain.TypeCertificate
+	stewardPath             = "/steward"
+	usersPath               = "/" + domain.TypeUser
+	claimsPath              = "/" + domain.TypeClaim
+	claimFilesPath          = "/" + domain.TypeClaimFile
+	claimItemsPath          = "/" + domain.TypeClaimItem
+	claimReserveReportsPath = "/" + domain.TypeClaimReserveReport
+	coverageLimitsPath      = "/" + domain.TypeCoverageLimit
+	filesPath               = "/" + domain.TypeFile
+	itemsPath               = "/" + domain.TypeItem
+	jobsPath                = "/jobs"
+	fiscalPeriodsPath       = "/" + domain.TypeFiscalPeriod
+	ledgerAdjustmentsPath   = "/" + domain.TypeLedgerAdjustment
+	ledgerEntriesPath       = "/" + domain.TypeLedgerEntry
+	ledgerReportPath        = "/" + domain.TypeLedgerReport
+	policiesPath            = "/" + domain.TypePolicy
+	policyApproverPath      = "/" + domain.TypePolicyApprover
+	policyDependentPath     = "/" + domain.TypePolicyDependent
+	entityCodesPath         = "/" + domain.TypeEntityCode
+	policyInvitePath        = "/" + domain.TypePolicyInvite
+	policyMemberPath        = "/" + domain.TypePolicyMember
+	repairsPath             = "/repairs"
+	strikesPath             = "/" + domain.TypeStrike
+	strikeRulesPath         = "/" + domain.TypeStrikeRule
 )
 
 var app *buffalo.App
@@ -143,8 +153,10 @@ func App() *buffalo.App {
 		// users
 		usersGroup := app.Group(usersPath)
 		usersGroup.GET("/", usersList)
-		usersGroup.Middleware.Skip(AuthZ, usersMe, usersMeUpdate, usersMeFilesAttach, usersMeFilesDelete)
+		usersGroup.Middleware.Skip(AuthZ, usersMe, usersMeUpdate, usersMeFilesAttach, usersMeFilesDelete,
+			usersMeApprovals)
 		usersGroup.GET("/me", usersMe)
+		usersGroup.GET("/me/"+api.ResourceApprovals, usersMeApprovals)
 		usersGroup.PUT("/me", usersMeUpdate)
 		usersGroup.POST("/me/files", usersMeFilesAttach)
 		usersGroup.DELETE("/me/files", usersMeFilesDelete)
@@ -166,18 +178,58 @@ func App() *buffalo.App {
 		// AuthZ is implemented in the handlers
 		ledgerReportGroup.Middleware.Skip(AuthZ, ledgerAnnualRenewalStatus, ledgerAnnualRenewalProcess)
 		ledgerReportGroup.Middleware.Skip(AuthZ, ledgerMonthlyRenewalStatus, ledgerMonthlyRenewalProcess)
+		ledgerReportGroup.Middleware.Skip(AuthZ, ledgerAnnualRenewalPreview, ledgerMonthlyRenewalPreview)
+		ledgerReportGroup.Middleware.Skip(AuthZ, ledgerReportPreview)
 		ledgerReportGroup.GET("/", ledgerReportList)
 		ledgerReportGroup.GET(idRegex, ledgerReportView)
 		ledgerReportGroup.POST("/", ledgerReportCreate)
 		ledgerReportGroup.PUT(idRegex, ledgerReportReconcile)
+		ledgerReportGroup.POST(idRegex+"/"+api.ResourceUnreconcile, ledgerReportUnreconcile)
+		ledgerReportGroup.POST(idRegex+"/"+api.ResourceJournal, ledgerReportReconcileJournal)
 		ledgerReportGroup.GET("/annual", ledgerAnnualRenewalStatus)
 		ledgerReportGroup.POST("/annual", ledgerAnnualRenewalProcess)
+		ledgerReportGroup.GET("/annual/"+api.ResourcePreview, ledgerAnnualRenewalPreview)
 		ledgerReportGroup.GET("/monthly", ledgerMonthlyRenewalStatus)
 		ledgerReportGroup.POST("/monthly", ledgerMonthlyRenewalProcess)
+		ledgerReportGroup.GET("/monthly/"+api.ResourcePreview, ledgerMonthlyRenewalPreview)
+		ledgerReportGroup.POST("/"+api.ResourcePreview, ledgerReportPreview)
+
+		// claim reserve reports
+		claimReserveReportsGroup := app.Group(claimReserveReportsPath)
+		claimReserveReportsGroup.Middleware.Skip(AuthZ, claimReserveReportsList, claimReserveReportsCurrent)
+		claimReserveReportsGroup.GET("/", claimReserveReportsList)
+		claimReserveReportsGroup.GET("/"+api.ResourceCurrent, claimReserveReportsCurrent)
+
+		// fiscal periods
+		fiscalPeriodsGroup := app.Group(fiscalPeriodsPath)
+		fiscalPeriodsGroup.Middleware.Skip(AuthZ, fiscalPeriodsReport, fiscalPeriodsClose)
+		fiscalPeriodsGroup.GET("/", fiscalPeriodsList)
+		fiscalPeriodsGroup.GET("/"+api.ResourceReport, fiscalPeriodsReport)
+		fiscalPeriodsGroup.POST("/"+api.ResourceClose, fiscalPeriodsClose)
+
+		// manual ledger adjustments
+		ledgerAdjustmentsGroup := app.Group(ledgerAdjustmentsPath)
+		ledgerAdjustmentsGroup.GET("/", ledgerAdjustmentsList)
+		ledgerAdjustmentsGroup.GET(idRegex, ledgerAdjustmentsView)
+		ledgerAdjustmentsGroup.POST("/", ledgerAdjustmentsCreate)
+		ledgerAdjustmentsGroup.POST(idRegex+"/"+api.ResourceApprove, ledgerAdjustmentsApprove)
+		ledgerAdjustmentsGroup.POST(idRegex+"/"+api.ResourceReject, ledgerAdjustmentsReject)
+
+		// ledger entries
+		ledgerEntriesGroup := app.Group(ledgerEntriesPath)
+		ledgerEntriesGroup.GET(idRegex, ledgerEntriesView)
+		ledgerEntriesGroup.POST(idRegex+"/"+api.ResourceReverse, ledgerEntriesReverse)
+
+		// certificates
+		certificatesGroup := app.Group(certificatesPath)
+		certificatesGroup.Middleware.Skip(AuthN, certificatesVerify)
+		certificatesGroup.Middleware.Skip(AuthZ, certificatesVerify)
+		certificatesGroup.GET("/{"+certificateCodeParam+"}", certificatesVerify)
 
 		stewardGroup := app.Group(stewardPath)
-		stewardGroup.Middleware.Skip(AuthZ, stewardListRecentObjects) // AuthZ is implemented in the handler
+		stewardGroup.Middleware.Skip(AuthZ, stewardListRecentObjects, stewardPortfolioAnalytics) // AuthZ is implemented in the handlers
 		stewardGroup.GET("/"+api.ResourceRecent, stewardListRecentObjects)
+		stewardGroup.GET("/"+api.ResourceAnalytics, stewardPortfolioAnalytics)
 
 		// claims
 		claimsGroup := app.Group(claimsPath)
@@ -212,10 +264,18 @@ func App() *buffalo.App {
 		depsGroup.PUT(idRegex, dependentsUpdate)
 		depsGroup.DELETE(idRegex, dependentsDelete)
 
+		// coverage limits
+		coverageLimitsGroup := app.Group(coverageLimitsPath)
+		coverageLimitsGroup.GET("/", coverageLimitsList)
+		coverageLimitsGroup.POST("/", coverageLimitsCreate)
+		coverageLimitsGroup.POST(idRegex+"/"+api.ResourceApprove, coverageLimitsApprove)
+		coverageLimitsGroup.DELETE(idRegex, coverageLimitsDelete)
+
 		// entity codes
 		entityCodesGroup := app.Group(entityCodesPath)
-		entityCodesGroup.Middleware.Skip(AuthZ, entityCodesList)
+		entityCodesGroup.Middleware.Skip(AuthZ, entityCodesList, entityCodesChargeback)
 		entityCodesGroup.GET("", entityCodesList)
+		entityCodesGroup.GET("/"+api.ResourceChargeback, entityCodesChargeback)
 		entityCodesGroup.PUT(idRegex, entityCodesUpdate)
 		entityCodesGroup.GET(idRegex, entityCodesView)
 		entityCodesGroup.POST("", entityCodesCreate)
@@ -226,6 +286,9 @@ func App() *buffalo.App {
 		itemsGroup.POST(idRegex+"/"+api.ResourceRevision, itemsRevision)
 		itemsGroup.POST(idRegex+"/"+api.ResourceApprove, itemsApprove)
 		itemsGroup.POST(idRegex+"/"+api.ResourceDeny, itemsDeny)
+		itemsGroup.POST(idRegex+"/"+api.ResourceBudgetApprove, itemsBudgetApprove)
+		itemsGroup.POST(idRegex+"/"+api.ResourceBudgetReject, itemsBudgetReject)
+		itemsGroup.POST(idRegex+"/"+api.ResourceCertificate, itemsCertificateCreate)
 		itemsGroup.PUT(idRegex, itemsUpdate)
 		itemsGroup.DELETE(idRegex, itemsRemove)
 
@@ -240,19 +303,46 @@ func App() *buffalo.App {
 		policiesGroup.PUT(idRegex, policiesUpdate)
 		policiesGroup.POST(idRegex+"/dependents", dependentsCreate)
 		policiesGroup.GET(idRegex+itemsPath, itemsList)
+		policiesGroup.GET(idRegex+itemsPath+"/export", itemsExport)
 		policiesGroup.POST(idRegex+itemsPath, itemsCreate)
 		policiesGroup.GET(idRegex+claimsPath, policiesClaimsList)
 		policiesGroup.POST(idRegex+claimsPath, claimsCreate)
-		policiesGroup.GET(idRegex+"/members", policiesListMembers)
-		policiesGroup.POST(idRegex+"/members", policiesInviteMember)
+		policiesGroup.GET(idRegex+"/"+api.ResourceMembers, policiesListMembers)
+		policiesGroup.POST(idRegex+"/"+api.ResourceMembers, policiesInviteMember)
+		policiesGroup.PUT(idRegex+"/"+api.ResourceMembers, policiesMembersUpdateRole)
+		policiesGroup.GET(idRegex+"/"+api.ResourceInvites, policiesListInvites)
+		policiesGroup.GET(idRegex+"/"+api.ResourceApprovers, policiesListApprovers)
+		policiesGroup.POST(idRegex+"/"+api.ResourceApprovers, policiesAddApprover)
 		policiesGroup.POST(idRegex+"/ledger-reports", policiesLedgerReportCreate)
 		policiesGroup.GET(idRegex+"/ledger-reports", policiesLedgerTableView)
 		policiesGroup.POST(idRegex+"/"+api.ResourceStrikes, policiesStrikeCreate)
+		policiesGroup.POST(idRegex+"/"+api.ResourceCertificate, policiesCertificateCreate)
+		policiesGroup.GET(idRegex+"/"+api.ResourceStatements, policiesListStatements)
+		policiesGroup.POST(idRegex+"/"+api.ResourceClose, policiesClose)
+		policiesGroup.POST(idRegex+"/"+api.ResourceMerge, policiesMerge)
+		policiesGroup.POST(idRegex+"/"+api.ResourceSplit, policiesSplit)
+
+		// policy-approvers
+		policyApproversGroup := app.Group(policyApproverPath)
+		policyApproversGroup.DELETE(idRegex, policyApproversDelete)
+
+		// policy-invites
+		policyInvitesGroup := app.Group(policyInvitePath)
+		policyInvitesGroup.POST(idRegex+"/"+api.ResourceResend, policyInvitesResend)
+		policyInvitesGroup.POST(idRegex+"/"+api.ResourceExtend, policyInvitesExtend)
+		policyInvitesGroup.DELETE(idRegex, policyInvitesRevoke)
 
 		// policy-members
 		policyMembersGroup := app.Group(policyMemberPath)
 		policyMembersGroup.DELETE(idRegex, policiesMembersDelete)
 
+		// jobs
+		jobsGroup := app.Group(jobsPath)
+		jobsGroup.Middleware.Skip(AuthZ, jobsList, jobsRunsList, jobsRun) // AuthZ is implemented in the handlers
+		jobsGroup.GET("/", jobsList)
+		jobsGroup.GET("/runs", jobsRunsList)
+		jobsGroup.POST("/{"+jobTypeParam+"}/run", jobsRun)
+
 		// repairs
 		repairsGroup := app.Group(repairsPath)
 		repairsGroup.Middleware.Skip(AuthZ, repairsRun) // AuthZ is implemented in the handler
@@ -260,9 +350,19 @@ func App() *buffalo.App {
 
 		// strikes
 		strikesGroup := app.Group(strikesPath)
+		strikesGroup.GET("/", strikesList)
 		strikesGroup.PUT(idRegex, strikesUpdate)
+		strikesGroup.POST(idRegex+"/"+api.ResourceApprove, strikesApprove)
+		strikesGroup.POST(idRegex+"/"+api.ResourceReject, strikesReject)
 		strikesGroup.DELETE(idRegex, strikesDelete)
 
+		// strike rules
+		strikeRulesGroup := app.Group(strikeRulesPath)
+		strikeRulesGroup.GET("/", strikeRulesList)
+		strikeRulesGroup.POST("/", strikeRulesCreate)
+		strikeRulesGroup.PUT(idRegex, strikeRulesUpdate)
+		strikeRulesGroup.DELETE(idRegex, strikeRulesDelete)
+
 		// robots
 		app.GET("/robots.txt", robots)
 		app.Middleware.Skip(AuthZ, robots)
@@ -16,17 +16,24 @@ import (
 func AuthZ(next buffalo.Handler) buffalo.Handler {
 	return func(c buffalo.Context) error {
 		authableResources := map[string]models.Authable{
-			domain.TypeClaim:           &models.Claim{},
-			domain.TypeClaimFile:       &models.ClaimFile{},
-			domain.TypeClaimItem:       &models.ClaimItem{},
-			domain.TypeEntityCode:      &models.EntityCode{},
-			domain.TypeItem:            &models.Item{},
-			domain.TypeLedgerReport:    &models.LedgerReport{},
-			domain.TypePolicy:          &models.Policy{},
-			domain.TypePolicyDependent: &models.PolicyDependent{},
-			domain.TypePolicyMember:    &models.PolicyUser{},
-			domain.TypeStrike:          &models.Strike{},
-			domain.TypeUser:            &models.User{},
+			domain.TypeClaim:            &models.Claim{},
+			domain.TypeClaimFile:        &models.ClaimFile{},
+			domain.TypeClaimItem:        &models.ClaimItem{},
+			domain.TypeCoverageLimit:    &models.CoverageLimit{},
+			domain.TypeEntityCode:       &models.EntityCode{},
+			domain.TypeFiscalPeriod:     &models.FiscalPeriod{},
+			domain.TypeItem:             &models.Item{},
+			domain.TypeLedgerAdjustment: &models.LedgerAdjustment{},
+			domain.TypeLedgerEntry:      &models.LedgerEntry{},
+			domain.TypeLedgerReport:     &models.LedgerReport{},
+			domain.TypePolicy:           &models.Policy{},
+			domain.TypePolicyApprover:   &models.PolicyApprover{},
+			domain.TypePolicyDependent:  &models.PolicyDependent{},
+			domain.TypePolicyInvite:     &models.PolicyUserInvite{},
+			domain.TypePolicyMember:     &models.PolicyUser{},
+			domain.TypeStrike:           &models.Strike{},
+			domain.TypeStrikeRule:       &models.StrikeRule{},
+			domain.TypeUser:             &models.User{},
 		}
 
 		actor, ok := c.Value(domain.ContextKeyCurrentUser).(models.User)
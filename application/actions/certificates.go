@@ -0,0 +1,94 @@
+package actions
+
+import (
+	"strings"
+
+	"github.com/gobuffalo/buffalo"
+
+	"github.com/silinternational/cover-api/models"
+)
+
+const certificateCodeParam = "code"
+
+// swagger:operation POST /items/{id}/certificate Certificates ItemsCertificateCreate
+// ItemsCertificateCreate
+//
+// Create a proof-of-coverage certificate for an item with approved coverage
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: item ID
+//	responses:
+//	  '200':
+//	    description: the new Certificate, including a link to the PDF
+//	    schema:
+//	      "$ref": "#/definitions/Certificate"
+func itemsCertificateCreate(c buffalo.Context) error {
+	item := getReferencedItemFromCtx(c)
+
+	cert, err := models.NewItemCertificate(c, *item)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, cert.ConvertToAPI(models.Tx(c)))
+}
+
+// swagger:operation POST /policies/{id}/certificate Certificates PoliciesCertificateCreate
+// PoliciesCertificateCreate
+//
+// Create a proof-of-coverage certificate listing all the items on a policy with approved coverage
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: policy ID
+//	responses:
+//	  '200':
+//	    description: the new Certificate, including a link to the PDF
+//	    schema:
+//	      "$ref": "#/definitions/Certificate"
+func policiesCertificateCreate(c buffalo.Context) error {
+	policy := getReferencedPolicyFromCtx(c)
+
+	cert, err := models.NewPolicyCertificate(c, *policy)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, cert.ConvertToAPI(models.Tx(c)))
+}
+
+// swagger:operation GET /certificates/{code} Certificates CertificatesVerify
+// CertificatesVerify
+//
+// Check the verification code printed on a proof-of-coverage certificate. No authentication is required.
+// ---
+//
+//	parameters:
+//	  - name: code
+//	    in: path
+//	    required: true
+//	    description: certificate verification code
+//	responses:
+//	  '200':
+//	    description: the current coverage of the items listed on the certificate
+//	    schema:
+//	      "$ref": "#/definitions/CertificateVerification"
+func certificatesVerify(c buffalo.Context) error {
+	tx := models.Tx(c)
+
+	code := strings.ToUpper(strings.TrimSpace(c.Param(certificateCodeParam)))
+
+	cert, err := models.FindCertificateByVerificationCode(tx, code)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, cert.Verify(tx))
+}
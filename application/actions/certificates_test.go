@@ -0,0 +1,116 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (as *ActionSuite) Test_ItemsCertificateCreate() {
+	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{NumberOfPolicies: 2, ItemsPerPolicy: 2})
+	approvedItem := models.UpdateItemStatus(as.DB, f.Items[0], api.ItemCoverageStatusApproved, "")
+	draftItem := f.Items[1]
+
+	member := f.Policies[0].Members[0]
+	otherUser := f.Policies[1].Members[0]
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		item       models.Item
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "unauthenticated",
+			actor:      models.User{},
+			item:       approvedItem,
+			wantStatus: http.StatusUnauthorized,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "not a policy member",
+			actor:      otherUser,
+			item:       approvedItem,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "item not approved",
+			actor:      member,
+			item:       draftItem,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "good",
+			actor:      member,
+			item:       approvedItem,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"item_id":"` + approvedItem.ID.String(),
+				`"policy_id":"` + approvedItem.PolicyID.String(),
+				fmt.Sprintf(`"coverage_amount":%d`, approvedItem.CoverageAmount),
+				`"content_type":"application/pdf"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			req := as.JSON("%s/%s/%s", itemsPath, tt.item.ID.String(), api.ResourceCertificate)
+			res := req.Post(nil)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
+
+func (as *ActionSuite) Test_CertificatesVerify() {
+	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{})
+	item := models.UpdateItemStatus(as.DB, f.Items[0], api.ItemCoverageStatusApproved, "")
+
+	cert, err := models.NewItemCertificate(models.CreateTestContext(f.Users[0]), item)
+	as.NoError(err)
+
+	tests := []struct {
+		name       string
+		code       string
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "unknown code",
+			code:       "NOTACODE",
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorCertificateNotFound.String()},
+		},
+		{
+			name:       "good",
+			code:       cert.VerificationCode,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"verification_code":"` + cert.VerificationCode,
+				`"is_current":true`,
+				`"serial_number":"` + item.SerialNumber,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON("%s/%s", certificatesPath, tt.code)
+			res := req.Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package actions
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gobuffalo/buffalo"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/models"
+)
+
+// swagger:operation GET /claim-reserve-reports ClaimReserveReports ClaimReserveReportsList
+// ClaimReserveReportsList
+//
+// list the month-end claim reserve reports, most recent first
+// ---
+//
+//	responses:
+//	  '200':
+//	    description: all claim reserve reports, each with a link to its CSV file
+//	    schema:
+//	      "$ref": "#/definitions/ClaimReserveReports"
+func claimReserveReportsList(c buffalo.Context) error {
+	actor := models.CurrentUser(c)
+	if !actor.IsAdmin() {
+		err := fmt.Errorf("user not allowed to view claim reserve reports")
+		return reportError(c, api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden))
+	}
+
+	tx := models.Tx(c)
+
+	var reports models.ClaimReserveReports
+	if err := reports.All(tx); err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, reports.ConvertToAPI(tx))
+}
+
+// swagger:operation GET /claim-reserve-reports/current ClaimReserveReports ClaimReserveReportsCurrent
+// ClaimReserveReportsCurrent
+//
+// estimate the outstanding liability of the claims that are under review or approved but not yet paid
+// ---
+//
+//	responses:
+//	  '200':
+//	    description: the current claim reserves
+//	    schema:
+//	      "$ref": "#/definitions/ClaimReserves"
+func claimReserveReportsCurrent(c buffalo.Context) error {
+	actor := models.CurrentUser(c)
+	if !actor.IsAdmin() {
+		err := fmt.Errorf("user not allowed to view claim reserves")
+		return reportError(c, api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden))
+	}
+
+	reserves, err := models.ClaimReserves(models.Tx(c), time.Now().UTC())
+	if err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, reserves)
+}
@@ -0,0 +1,85 @@
+package actions
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (as *ActionSuite) Test_ClaimReserveReportsCurrent() {
+	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{ClaimsPerPolicy: 1, ClaimItemsPerClaim: 1})
+	claim := models.UpdateClaimStatus(as.DB, f.Claims[0], api.ClaimStatusApproved, "")
+	steward := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "not an admin",
+			actor:      f.Users[0],
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "steward",
+			actor:      steward,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"claim_count":1`,
+				`"reference_number":"` + claim.ReferenceNumber,
+				`"status":"` + string(api.ClaimStatusApproved),
+				`"age":"0-30 days"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON("%s/%s", claimReserveReportsPath, api.ResourceCurrent).Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
+
+func (as *ActionSuite) Test_ClaimReserveReportsList() {
+	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{})
+	steward := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "not an admin",
+			actor:      f.Users[0],
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "steward",
+			actor:      steward,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{`[]`},
+		},
+	}
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON("%s", claimReserveReportsPath).Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package actions
+
+import (
+	"net/http"
+
+	"github.com/gobuffalo/buffalo"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+// swagger:operation GET /coverage-limits CoverageLimits CoverageLimitsList
+// CoverageLimitsList
+//
+// list the coverage limit overrides that have not expired
+// ---
+//
+//	responses:
+//	  '200':
+//	    description: list of Coverage Limits
+//	    schema:
+//	      type: array
+//	      items:
+//	        "$ref": "#/definitions/CoverageLimit"
+func coverageLimitsList(c buffalo.Context) error {
+	var limits models.CoverageLimits
+	if err := limits.FindActive(models.Tx(c)); err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, limits.ConvertToAPI())
+}
+
+// swagger:operation POST /coverage-limits CoverageLimits CoverageLimitsCreate
+// CoverageLimitsCreate
+//
+// create a coverage limit override for a policy or an entity code. If the limit is above the approval
+// threshold, it is not in force until it is approved by a Signator.
+// ---
+//
+//	parameters:
+//	  - name: coverage limit input
+//	    in: body
+//	    description: coverage limit input object
+//	    required: true
+//	    schema:
+//	      "$ref": "#/definitions/CoverageLimitInput"
+//	responses:
+//	  '200':
+//	    description: the new Coverage Limit
+//	    schema:
+//	      "$ref": "#/definitions/CoverageLimit"
+func coverageLimitsCreate(c buffalo.Context) error {
+	var input api.CoverageLimitInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	limit, err := models.NewCoverageLimit(c, input)
+	if err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, limit.ConvertToAPI())
+}
+
+// swagger:operation POST /coverage-limits/{id}/approve CoverageLimits CoverageLimitsApprove
+// CoverageLimitsApprove
+//
+// approve a coverage limit override that is above the approval threshold. Only a Signator other than
+// the creator of the limit may approve it.
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: coverage limit ID
+//	responses:
+//	  '200':
+//	    description: the approved Coverage Limit
+//	    schema:
+//	      "$ref": "#/definitions/CoverageLimit"
+func coverageLimitsApprove(c buffalo.Context) error {
+	limit := getReferencedCoverageLimitFromCtx(c)
+	if err := limit.Approve(c); err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, limit.ConvertToAPI())
+}
+
+// swagger:operation DELETE /coverage-limits/{id} CoverageLimits CoverageLimitsDelete
+// CoverageLimitsDelete
+//
+// delete a coverage limit override
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: coverage limit ID
+//	responses:
+//	  '204':
+//	    description: OK but no content in response
+func coverageLimitsDelete(c buffalo.Context) error {
+	limit := getReferencedCoverageLimitFromCtx(c)
+	if err := limit.Destroy(models.Tx(c)); err != nil {
+		return reportError(c, err)
+	}
+	return c.Render(http.StatusNoContent, nil)
+}
+
+// getReferencedCoverageLimitFromCtx pulls the models.CoverageLimit resource from context that was put there
+// by the AuthZ middleware
+func getReferencedCoverageLimitFromCtx(c buffalo.Context) *models.CoverageLimit {
+	limit, ok := c.Value(domain.TypeCoverageLimit).(*models.CoverageLimit)
+	if !ok {
+		panic("coverage limit not found in context")
+	}
+	return limit
+}
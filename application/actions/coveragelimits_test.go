@@ -0,0 +1,113 @@
+package actions
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (as *ActionSuite) Test_CoverageLimitsCreate() {
+	f := models.CreatePolicyFixtures(as.DB, models.FixturesConfig{})
+	policy := f.Policies[0]
+	member := policy.Members[0]
+	steward := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	input := api.CoverageLimitInput{
+		PolicyID:       &policy.ID,
+		MaxCoverage:    5000,
+		ExpirationDate: time.Now().UTC().AddDate(0, 6, 0).Format(domain.DateFormat),
+		Reason:         "temporary assignment",
+	}
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "member",
+			actor:      member,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "steward",
+			actor:      steward,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"policy_id":"` + policy.ID.String(),
+				`"max_coverage":5000`,
+				`"expiration_date":"` + input.ExpirationDate,
+				`"is_approved":true`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON(coverageLimitsPath).Post(input)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
+
+func (as *ActionSuite) Test_CoverageLimitsApprove() {
+	f := models.CreatePolicyFixtures(as.DB, models.FixturesConfig{})
+	admins := models.CreateAdminUsers(as.DB)
+	steward := admins[models.AppRoleSteward]
+	signator := admins[models.AppRoleSignator]
+
+	limit := models.CoverageLimit{
+		PolicyID:       nulls.NewUUID(f.Policies[0].ID),
+		MaxCoverage:    domain.Env.CoverageLimitApprovalThreshold + 1,
+		ExpirationDate: time.Now().UTC().AddDate(0, 6, 0),
+		CreatedByID:    steward.ID,
+	}
+	as.NoError(limit.Create(as.DB))
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "steward",
+			actor:      steward,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "signator",
+			actor:      signator,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"id":"` + limit.ID.String(),
+				`"is_approved":true`,
+				`"approved_by_id":"` + signator.ID.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON("%s/%s/%s", coverageLimitsPath, limit.ID.String(), api.ResourceApprove).Post(nil)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
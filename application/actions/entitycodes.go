@@ -1,6 +1,9 @@
 package actions
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/gobuffalo/buffalo"
 
 	"github.com/silinternational/cover-api/api"
@@ -123,6 +126,64 @@ func entityCodesCreate(c buffalo.Context) error {
 	return renderEntityCode(c, e)
 }
 
+// swagger:operation GET /entity-codes/chargeback EntityCodes EntityCodesChargeback
+// EntityCodesChargeback
+//
+// Report the premiums, refunds and claims of team policies for a period, grouped by entity code (rolled up to
+// the parent entity), cost center, policy and item. The report can be limited to an entity code and its children.
+// ---
+//
+//	parameters:
+//	  - name: start
+//	    in: query
+//	    required: true
+//	    description: first date of the period, e.g. "2023-10-01"
+//	  - name: end
+//	    in: query
+//	    required: true
+//	    description: last date of the period, e.g. "2023-10-31"
+//	  - name: entity_code
+//	    in: query
+//	    required: false
+//	    description: include only this entity code and its child entity codes
+//	  - name: format
+//	    in: query
+//	    required: false
+//	    description: file format, either `csv` (default) or `pdf`
+//	responses:
+//	  '200':
+//	    description: the chargeback report File
+//	    schema:
+//	      "$ref": "#/definitions/File"
+func entityCodesChargeback(c buffalo.Context) error {
+	actor := models.CurrentUser(c)
+	if !actor.IsAdmin() {
+		err := fmt.Errorf("user not allowed to view chargeback reports")
+		return reportError(c, api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden))
+	}
+
+	start, err := time.Parse(domain.DateFormat, c.Param("start"))
+	if err != nil {
+		return reportError(c, api.NewAppError(err, api.ErrorInvalidDate, api.CategoryUser))
+	}
+	end, err := time.Parse(domain.DateFormat, c.Param("end"))
+	if err != nil {
+		return reportError(c, api.NewAppError(err, api.ErrorInvalidDate, api.CategoryUser))
+	}
+
+	format := c.Param(exportFormatParam)
+	if format == "" {
+		format = api.ExportFormatCSV
+	}
+
+	file, err := models.NewChargebackReport(c, start, end, c.Param("entity_code"), format)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, file.ConvertToAPI(models.Tx(c)))
+}
+
 // getReferencedEntityCodeFromCtx pulls the models.EntityCode resource from context that was put there
 // by the AuthZ middleware
 func getReferencedEntityCodeFromCtx(c buffalo.Context) *models.EntityCode {
@@ -2,8 +2,11 @@ package actions
 
 import (
 	"net/http"
+	"testing"
+	"time"
 
 	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
 	"github.com/silinternational/cover-api/models"
 )
 
@@ -259,3 +262,64 @@ func (as *ActionSuite) Test_entityCodesCreate() {
 		}
 	}
 }
+
+func (as *ActionSuite) Test_entityCodesChargeback() {
+	user := models.CreateUserFixtures(as.DB, 1).Users[0]
+	admin := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	today := time.Now().UTC().Format(domain.DateFormat)
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		query      string
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "not an admin",
+			actor:      user,
+			query:      "start=" + today + "&end=" + today,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "invalid date",
+			actor:      admin,
+			query:      "start=" + today + "&end=today",
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorInvalidDate.String()},
+		},
+		{
+			name:       "csv",
+			actor:      admin,
+			query:      "start=" + today + "&end=" + today,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{`"content_type":"` + domain.ContentCSV, "chargeback"},
+		},
+		{
+			name:       "unknown entity code",
+			actor:      admin,
+			query:      "start=" + today + "&end=" + today + "&entity_code=nope",
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorNoRows.String()},
+		},
+		{
+			name:       "pdf",
+			actor:      admin,
+			query:      "start=" + today + "&end=" + today + "&format=pdf",
+			wantStatus: http.StatusOK,
+			wantInBody: []string{`"content_type":"` + domain.ContentPDF},
+		},
+	}
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON("%s/%s?%s", entityCodesPath, api.ResourceChargeback, tt.query).Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package actions
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gobuffalo/buffalo"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+// swagger:operation GET /fiscal-periods FiscalPeriods FiscalPeriodsList
+// FiscalPeriodsList
+//
+// list the fiscal periods that have been closed or otherwise recorded, most recent first. A period without a record
+// is open.
+// ---
+//
+//	responses:
+//	  '200':
+//	    description: list of Fiscal Periods
+//	    schema:
+//	      type: array
+//	      items:
+//	        "$ref": "#/definitions/FiscalPeriod"
+func fiscalPeriodsList(c buffalo.Context) error {
+	var periods models.FiscalPeriods
+	if err := periods.All(models.Tx(c)); err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, periods.ConvertToAPI())
+}
+
+// swagger:operation GET /fiscal-periods/report FiscalPeriods FiscalPeriodsReport
+// FiscalPeriodsReport
+//
+// summarize the ledger entries submitted in a fiscal period, e.g. to review a period before it is closed
+// ---
+//
+//	parameters:
+//	- name: date
+//	  in: query
+//	  required: true
+//	  description: any date in the fiscal period, e.g. "2023-10-31"
+//	responses:
+//	  '200':
+//	    description: the Fiscal Period Report
+//	    schema:
+//	      "$ref": "#/definitions/FiscalPeriodReport"
+func fiscalPeriodsReport(c buffalo.Context) error {
+	actor := models.CurrentUser(c)
+	if !actor.IsAdmin() {
+		err := fmt.Errorf("user not allowed to view fiscal period reports")
+		return reportError(c, api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden))
+	}
+
+	date, err := time.Parse(domain.DateFormat, c.Param("date"))
+	if err != nil {
+		return reportError(c, api.NewAppError(err, api.ErrorInvalidDate, api.CategoryUser))
+	}
+
+	tx := models.Tx(c)
+
+	var period models.FiscalPeriod
+	if err := period.FindByDate(tx, date); err != nil {
+		return reportError(c, err)
+	}
+
+	report, err := period.Report(tx)
+	if err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, report)
+}
+
+// swagger:operation POST /fiscal-periods/close FiscalPeriods FiscalPeriodsClose
+// FiscalPeriodsClose
+//
+// close a fiscal period after its journal has been posted to the accounting system. Ledger entries dated in a
+// closed period are moved to the next open period. Only a signator can close a period, and only after it has ended.
+// ---
+//
+//	parameters:
+//	  - name: fiscal period close input
+//	    in: body
+//	    description: fiscal period close input object
+//	    required: true
+//	    schema:
+//	      "$ref": "#/definitions/FiscalPeriodCloseInput"
+//	responses:
+//	  '200':
+//	    description: the period-close report
+//	    schema:
+//	      "$ref": "#/definitions/FiscalPeriodReport"
+func fiscalPeriodsClose(c buffalo.Context) error {
+	actor := models.CurrentUser(c)
+	if actor.AppRole != models.AppRoleSignator {
+		err := fmt.Errorf("user not allowed to close fiscal periods")
+		return reportError(c, api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden))
+	}
+
+	var input api.FiscalPeriodCloseInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	date, err := time.Parse(domain.DateFormat, input.Date)
+	if err != nil {
+		return reportError(c, api.NewAppError(err, api.ErrorInvalidDate, api.CategoryUser))
+	}
+
+	period, err := models.CloseFiscalPeriod(c, date, input.Note)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	report, err := period.Report(models.Tx(c))
+	if err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, report)
+}
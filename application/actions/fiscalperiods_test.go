@@ -0,0 +1,75 @@
+package actions
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (as *ActionSuite) Test_FiscalPeriodsClose() {
+	admins := models.CreateAdminUsers(as.DB)
+	stewardUser := admins[models.AppRoleSteward]
+	signatorUser := admins[models.AppRoleSignator]
+
+	now := time.Now().UTC()
+	lastMonth := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		date       string
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "steward",
+			actor:      stewardUser,
+			date:       lastMonth.Format(domain.DateFormat),
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "invalid date",
+			actor:      signatorUser,
+			date:       "last month",
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorInvalidDate.String()},
+		},
+		{
+			name:       "current period",
+			actor:      signatorUser,
+			date:       now.Format(domain.DateFormat),
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorFiscalPeriodClose.String()},
+		},
+		{
+			name:       "last month",
+			actor:      signatorUser,
+			date:       lastMonth.Format(domain.DateFormat),
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"start_date":"` + lastMonth.Format(domain.DateFormat),
+				`"status":"` + string(api.FiscalPeriodStatusClosed),
+				`"closed_by_id":"` + signatorUser.ID.String(),
+				`"close_note":"posted"`,
+				`"entry_count":0`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON("%s/%s", fiscalPeriodsPath, api.ResourceClose).
+				Post(api.FiscalPeriodCloseInput{Date: tt.date, Note: "posted"})
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
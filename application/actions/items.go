@@ -39,6 +39,43 @@ func itemsList(c buffalo.Context) error {
 	return renderOk(c, policy.Items.ConvertToAPI(tx))
 }
 
+// swagger:operation GET /policies/{id}/items/export PolicyItems PolicyItemsExport
+// PolicyItemsExport
+//
+// Export an inventory of all the items on a Policy, including coverage status, accountable person and premium
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: policy ID
+//	  - name: format
+//	    in: query
+//	    required: false
+//	    description: file format, either `csv` (default) or `pdf`
+//	responses:
+//	  '200':
+//	    description: the inventory File
+//	    schema:
+//	      "$ref": "#/definitions/File"
+func itemsExport(c buffalo.Context) error {
+	tx := models.Tx(c)
+	policy := getReferencedPolicyFromCtx(c)
+
+	format := c.Param(exportFormatParam)
+	if format == "" {
+		format = api.ExportFormatCSV
+	}
+
+	file, err := policy.NewItemsExport(c, format)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, file.ConvertToAPI(tx))
+}
+
 // swagger:operation POST /policies/{id}/items PolicyItems PolicyItemsCreate
 // PolicyItemsCreate
 //
@@ -283,6 +320,73 @@ func itemsDeny(c buffalo.Context) error {
 	return c.Render(http.StatusOK, r.JSON(output))
 }
 
+// swagger:operation POST /items/{id}/budget-approve PolicyItems PolicyItemsBudgetApprove
+// PolicyItemsBudgetApprove
+//
+// a budget approver of a team policy accepts the cost of new coverage, sending the item on for steward review
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: item ID
+//	responses:
+//	  '200':
+//	    description: Policy Item
+//	    schema:
+//	      "$ref": "#/definitions/Item"
+func itemsBudgetApprove(c buffalo.Context) error {
+	tx := models.Tx(c)
+	item := getReferencedItemFromCtx(c)
+
+	if err := item.BudgetApprove(c); err != nil {
+		return reportError(c, err)
+	}
+
+	output := item.ConvertToAPI(tx)
+	return c.Render(http.StatusOK, r.JSON(output))
+}
+
+// swagger:operation POST /items/{id}/budget-reject PolicyItems PolicyItemsBudgetReject
+// PolicyItemsBudgetReject
+//
+// a budget approver of a team policy rejects the cost of new coverage, returning the item to its members for revision
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: item ID
+//	  - name: item budget rejection input
+//	    in: body
+//	    description: item status input object
+//	    required: true
+//	    schema:
+//	      "$ref": "#/definitions/ItemStatusInput"
+//	responses:
+//	  '200':
+//	    description: Policy Item
+//	    schema:
+//	      "$ref": "#/definitions/Item"
+func itemsBudgetReject(c buffalo.Context) error {
+	tx := models.Tx(c)
+	item := getReferencedItemFromCtx(c)
+
+	var input api.ItemStatusInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	if err := item.BudgetReject(c, input.StatusReason); err != nil {
+		return reportError(c, err)
+	}
+
+	output := item.ConvertToAPI(tx)
+	return c.Render(http.StatusOK, r.JSON(output))
+}
+
 // swagger:operation DELETE /items/{id} PolicyItems PolicyItemsRemove
 // PolicyItemsRemove
 //
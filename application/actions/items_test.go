@@ -1008,3 +1008,57 @@ func (as *ActionSuite) Test_NewItemFromApiInput() {
 		})
 	}
 }
+
+func (as *ActionSuite) Test_ItemsExport() {
+	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{NumberOfPolicies: 2, ItemsPerPolicy: 2})
+	policy := f.Policies[0]
+	member := policy.Members[0]
+	otherUser := f.Policies[1].Members[0]
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		format     string
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "not a policy member",
+			actor:      otherUser,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "bad format",
+			actor:      member,
+			format:     "doc",
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorInvalidExportFormat.String()},
+		},
+		{
+			name:       "default format",
+			actor:      member,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{`"content_type":"` + domain.ContentCSV},
+		},
+		{
+			name:       "pdf",
+			actor:      member,
+			format:     api.ExportFormatPDF,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{`"content_type":"` + domain.ContentPDF},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			req := as.JSON("%s/%s%s/export?format=%s", policiesPath, policy.ID.String(), itemsPath, tt.format)
+			res := req.Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
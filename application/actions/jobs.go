@@ -0,0 +1,117 @@
+package actions
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gobuffalo/buffalo"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/job"
+	"github.com/silinternational/cover-api/models"
+)
+
+const jobTypeParam = "job_type"
+
+// swagger:operation GET /jobs Jobs JobsList
+// ---
+//
+//	summary: JobsList
+//	description: |-
+//	  List the schedules of the background jobs, including the last and next run times. Admin only.
+//	responses:
+//	  '200':
+//	    description: the job schedules
+//	    schema:
+//	      "$ref": "#/definitions/JobSchedules"
+func jobsList(c buffalo.Context) error {
+	actor := models.CurrentUser(c)
+	if !actor.IsAdmin() {
+		err := fmt.Errorf("actor not allowed to perform that action on this resource")
+		return reportError(c, api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden))
+	}
+
+	var schedules models.JobSchedules
+	if err := schedules.All(models.Tx(c)); err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, schedules.ConvertToAPI())
+}
+
+// swagger:operation GET /jobs/runs Jobs JobsRunsList
+// ---
+//
+//	summary: JobsRunsList
+//	description: |-
+//	  List the most recent runs of the background jobs, newest first. Admin only.
+//	parameters:
+//	  - name: job_type
+//	    in: query
+//	    required: false
+//	    description: list only the runs of this job type
+//	  - name: limit
+//	    in: query
+//	    required: false
+//	    description: maximum number of runs to list, default and maximum is 500
+//	responses:
+//	  '200':
+//	    description: the job runs
+//	    schema:
+//	      "$ref": "#/definitions/JobRuns"
+func jobsRunsList(c buffalo.Context) error {
+	actor := models.CurrentUser(c)
+	if !actor.IsAdmin() {
+		err := fmt.Errorf("actor not allowed to perform that action on this resource")
+		return reportError(c, api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden))
+	}
+
+	limit := 0
+	if l := c.Param("limit"); l != "" {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil {
+			err = fmt.Errorf("invalid limit %q: %w", l, err)
+			return reportError(c, api.NewAppError(err, api.ErrorInvalidRequestBody, api.CategoryUser))
+		}
+	}
+
+	tx := models.Tx(c)
+	var runs models.JobRuns
+	if err := runs.Recent(tx, c.Param(jobTypeParam), limit); err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, runs.ConvertToAPI(tx))
+}
+
+// swagger:operation POST /jobs/{job_type}/run Jobs JobsRun
+// ---
+//
+//	summary: JobsRun
+//	description: |-
+//	  Run a background job now, even if its schedule is not enabled. The job is run in the background, so the
+//	  returned run is queued. Fails if the job is already running. Admin only.
+//	parameters:
+//	  - name: job_type
+//	    in: path
+//	    required: true
+//	    description: the job type, as listed by `GET /jobs`
+//	responses:
+//	  '200':
+//	    description: the queued job run
+//	    schema:
+//	      "$ref": "#/definitions/JobRun"
+func jobsRun(c buffalo.Context) error {
+	actor := models.CurrentUser(c)
+	if !actor.IsAdmin() {
+		err := fmt.Errorf("actor not allowed to perform that action on this resource")
+		return reportError(c, api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden))
+	}
+
+	run, err := job.Trigger(c, c.Param(jobTypeParam))
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, run.ConvertToAPI(models.Tx(c)))
+}
@@ -0,0 +1,147 @@
+package actions
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (as *ActionSuite) Test_jobsList() {
+	user := models.CreateUserFixtures(as.DB, 1).Users[0]
+	admin := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	schedule := models.JobSchedule{JobType: "test_job", Schedule: "0 1 * * *", Enabled: true}
+	as.NoError(schedule.Create(as.DB))
+	run := models.JobRun{JobScheduleID: schedule.ID, Trigger: api.JobRunTriggerSchedule, Result: api.JobRunResultQueued}
+	as.NoError(run.Create(as.DB))
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		path       string
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "unauthenticated",
+			actor:      models.User{},
+			path:       jobsPath,
+			wantStatus: http.StatusUnauthorized,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "normal users can't list jobs",
+			actor:      user,
+			path:       jobsPath,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "normal users can't list runs",
+			actor:      user,
+			path:       jobsPath + "/runs",
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "list jobs",
+			actor:      admin,
+			path:       jobsPath,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{`"job_type":"test_job"`, `"schedule":"0 1 * * *"`, `"is_running":false`},
+		},
+		{
+			name:       "list runs",
+			actor:      admin,
+			path:       jobsPath + "/runs?job_type=test_job&limit=10",
+			wantStatus: http.StatusOK,
+			wantInBody: []string{`"id":"` + run.ID.String(), `"job_type":"test_job"`, `"result":"Queued"`},
+		},
+		{
+			name:       "invalid limit",
+			actor:      admin,
+			path:       jobsPath + "/runs?limit=ten",
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorInvalidRequestBody.String()},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON(tt.path).Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
+
+func (as *ActionSuite) Test_jobsRun() {
+	user := models.CreateUserFixtures(as.DB, 1).Users[0]
+	admin := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	schedule := models.JobSchedule{JobType: "test_job", Schedule: "0 1 * * *"}
+	as.NoError(schedule.Create(as.DB))
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		jobType    string
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "normal users can't do this",
+			actor:      user,
+			jobType:    schedule.JobType,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "unknown job",
+			actor:      admin,
+			jobType:    "nope",
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorNoRows.String()},
+		},
+		{
+			name:       "admins can run a job that is not enabled",
+			actor:      admin,
+			jobType:    schedule.JobType,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"job_type":"test_job"`,
+				`"trigger":"Manual"`,
+				`"triggered_by_id":"` + admin.ID.String(),
+				`"result":"Queued"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON(jobsPath + "/" + tt.jobType + "/run").Post(nil)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+
+			if res.Code != http.StatusOK {
+				return
+			}
+
+			var run api.JobRun
+			as.NoError(json.Unmarshal([]byte(body), &run))
+			var runs models.JobRuns
+			as.NoError(runs.Recent(as.DB, schedule.JobType, 0))
+			as.Len(runs, 1)
+			as.Equal(run.ID, runs[0].ID)
+		})
+	}
+}
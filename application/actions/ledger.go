@@ -1,6 +1,7 @@
 package actions
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -67,7 +68,14 @@ func ledgerReportView(c buffalo.Context) error {
 // LedgerReportCreate
 //
 // Create and return a report on the ledger entries as specified by the input object. The returned object
-// contains metadata and a File object pointing to a CSV file suitable for use with Sage Accounting.
+// contains metadata and a File object pointing to a file in the requested format. By default, this is a CSV file
+// suitable for use with Sage Accounting, and a second report with a NetSuite CSV file is also created.
+//
+// ### Report formats:
+// + `sage` - Sage Accounting CSV
+// + `netsuite` - NetSuite CSV
+// + `quickbooks` - QuickBooks IIF general journal
+// + `json` - JSON journal, with the transactions in balanced blocks
 //
 // ### Report types:
 // + `monthly` - Return all ledger entries not yet reconciled, up to the beginning of the given day (0:00 UTC).
@@ -87,42 +95,96 @@ func ledgerReportView(c buffalo.Context) error {
 //	    schema:
 //	      "$ref": "#/definitions/LedgerReport"
 func ledgerReportCreate(c buffalo.Context) error {
-	var input api.LedgerReportCreateInput
-	if err := StrictBind(c, &input); err != nil {
+	report, input, err := newLedgerReportFromInput(c)
+	if err != nil {
 		return reportError(c, err)
 	}
 
-	date, err := time.Parse(domain.DateFormat, input.Date)
-	if err != nil {
-		return reportError(c, api.NewAppError(err, api.ErrorInvalidDate, api.CategoryUser))
+	tx := models.Tx(c)
+
+	if err = report.Create(tx); err != nil {
+		return reportError(c, err)
+	}
+
+	// The default Sage report is accompanied by a NetSuite report
+	if input.Format == "" {
+		netsuite, err := report.LedgerEntries.NewReport(c, fin.ReportFormatNetSuite, input.Type, report.Date)
+		if err != nil {
+			return reportError(c, err)
+		}
+		if err = netsuite.Create(tx); err != nil {
+			return reportError(c, err)
+		}
+	}
+
+	return renderOk(c, report.ConvertToAPI(tx))
+}
+
+// swagger:operation POST /ledger-reports/preview LedgerReport LedgerReportPreview
+// LedgerReportPreview
+//
+// Render a report on the ledger entries as specified by the input object, without creating it. The rendered report
+// is returned in the response. The input is the same as for LedgerReportCreate, but no companion NetSuite report
+// is rendered.
+// ---
+//
+//	parameters:
+//	  - name: input
+//	    in: body
+//	    description: LedgerReportCreateInput object
+//	    required: true
+//	    schema:
+//	      "$ref": "#/definitions/LedgerReportCreateInput"
+//	responses:
+//	  '200':
+//	    description: the rendered report
+//	    schema:
+//	      "$ref": "#/definitions/LedgerReportPreview"
+func ledgerReportPreview(c buffalo.Context) error {
+	actor := models.CurrentUser(c)
+	if !actor.IsAdmin() {
+		err := fmt.Errorf("user not allowed to preview ledger reports")
+		return reportError(c, api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden))
 	}
 
-	// Create Sage report
-	report, err := models.NewLedgerReport(c, fin.ReportFormatSage, input.Type, date)
+	report, input, err := newLedgerReportFromInput(c)
 	if err != nil {
 		return reportError(c, err)
 	}
 
-	tx := models.Tx(c)
+	return renderOk(c, report.ConvertToPreview(ledgerReportFormat(input)))
+}
 
-	if err = report.Create(tx); err != nil {
-		return reportError(c, err)
+// newLedgerReportFromInput builds the report requested by the LedgerReportCreateInput in the request body. The
+// report is not stored.
+func newLedgerReportFromInput(c buffalo.Context) (models.LedgerReport, api.LedgerReportCreateInput, error) {
+	var input api.LedgerReportCreateInput
+	if err := StrictBind(c, &input); err != nil {
+		return models.LedgerReport{}, input, err
 	}
 
-	// Create NetSuite report
-	netsuite := report.LedgerEntries.NewReport(c, fin.ReportFormatNetSuite, input.Type, report.Date)
-	if err = netsuite.Create(tx); err != nil {
-		return reportError(c, err)
+	date, err := time.Parse(domain.DateFormat, input.Date)
+	if err != nil {
+		return models.LedgerReport{}, input, api.NewAppError(err, api.ErrorInvalidDate, api.CategoryUser)
 	}
 
-	return renderOk(c, report.ConvertToAPI(tx))
+	report, err := models.NewLedgerReport(c, ledgerReportFormat(input), input.Type, date)
+	return report, input, err
+}
+
+// ledgerReportFormat returns the requested report format, or the default format, Sage, if none is given
+func ledgerReportFormat(input api.LedgerReportCreateInput) string {
+	if input.Format == "" {
+		return fin.ReportFormatSage
+	}
+	return input.Format
 }
 
 // swagger:operation PUT /ledger-reports/{id} LedgerReport LedgerReportReconcile
 // LedgerReportReconcile
 //
 // Mark ledger entries in the report reconciled as of today. Call this only after all transactions in the report
-// have been fully loaded into the accounting record.
+// have been fully loaded into the accounting record. A report that does not balance cannot be reconciled.
 // ---
 //
 //	parameters:
@@ -146,6 +208,95 @@ func ledgerReportReconcile(c buffalo.Context) error {
 	return renderOk(c, ledgerReport.ConvertToAPI(tx))
 }
 
+// swagger:operation POST /ledger-reports/{id}/unreconcile LedgerReport LedgerReportUnreconcile
+// LedgerReportUnreconcile
+//
+// Undo the reconciliation of a report. The ledger entries in the report are no longer marked reconciled, so they are
+// included in the next monthly report, and the report is voided. This is only possible while the fiscal period in
+// which the report was reconciled is still open. Only a signator can undo a reconciliation.
+// ---
+//
+//	parameters:
+//	- name: id
+//	  in: path
+//	  required: true
+//	  description: specifies the ID of the report to unreconcile
+//	- name: input
+//	  in: body
+//	  description: LedgerReportUnreconcileInput object
+//	  required: true
+//	  schema:
+//	    "$ref": "#/definitions/LedgerReportUnreconcileInput"
+//	responses:
+//	  '200':
+//	    description: the voided LedgerReport
+//	    schema:
+//	      "$ref": "#/definitions/LedgerReport"
+func ledgerReportUnreconcile(c buffalo.Context) error {
+	tx := models.Tx(c)
+
+	var input api.LedgerReportUnreconcileInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	ledgerReport := getReferencedLedgerReportFromCtx(c)
+	if err := ledgerReport.Unreconcile(c, input.Reason); err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, ledgerReport.ConvertToAPI(tx))
+}
+
+// swagger:operation POST /ledger-reports/{id}/journal LedgerReport LedgerReportReconcileJournal
+// LedgerReportReconcileJournal
+//
+// Reconcile a report with the journal exported from the accounting system. The lines of the journal (CSV) are
+// matched to the entries of the report by reference and amount, and only the matched entries are marked reconciled.
+// The references are those of the format the report was created in. A report that does not balance cannot be
+// reconciled. The journal must have a header row with a reference column (`Reference` or `TRANSREF`) and either an amount column
+// (`Amount` or `TRANSAMT`) or `Debit` and `Credit` columns. The lines that could not be matched, the lines that
+// match the reference but not the amount of an entry, and the entries not found in the journal are returned for
+// follow-up. Only an admin can reconcile a journal.
+// ---
+//
+//	consumes:
+//	  - multipart/form-data
+//	parameters:
+//	- name: id
+//	  in: path
+//	  required: true
+//	  description: specifies the ID of the report to reconcile
+//	- name: file
+//	  in: formData
+//	  type: file
+//	  description: journal CSV file
+//	responses:
+//	  '200':
+//	    description: the result of the reconciliation
+//	    schema:
+//	      "$ref": "#/definitions/LedgerJournalReconciliation"
+func ledgerReportReconcileJournal(c buffalo.Context) error {
+	f, err := c.File(fileFieldName)
+	if err != nil {
+		err := fmt.Errorf("error getting uploaded file from context ... %v", err)
+		return reportError(c, api.NewAppError(err, api.ErrorReceivingFile, api.CategoryInternal))
+	}
+
+	if f.Size > int64(domain.MaxFileSize) {
+		err := fmt.Errorf("file upload size (%v) greater than max (%v)", f.Size, domain.MaxFileSize)
+		return reportError(c, api.NewAppError(err, api.ErrorStoreFileTooLarge, api.CategoryUser))
+	}
+
+	ledgerReport := getReferencedLedgerReportFromCtx(c)
+	result, err := ledgerReport.ReconcileJournal(c, f)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, result)
+}
+
 // swagger:operation POST /ledger-reports/annual Ledger LedgerAnnualProcess
 // LedgerAnnualProcess
 //
@@ -263,6 +414,83 @@ func ledgerMonthlyRenewalStatus(c buffalo.Context) error {
 	return renderOk(c, status)
 }
 
+// swagger:operation GET /ledger-reports/annual/preview Ledger LedgerAnnualRenewalPreview
+// LedgerAnnualRenewalPreview
+//
+// List the ledger entries that the annual billing process would create, without creating them.
+// ---
+//
+//	parameters:
+//	  - name: format
+//	    in: query
+//	    required: false
+//	    description: omit for a RenewalPreview, or `csv` for a File containing the preview
+//	responses:
+//	  '200':
+//	    description: the preview of the annual billing process
+//	    schema:
+//	      "$ref": "#/definitions/RenewalPreview"
+func ledgerAnnualRenewalPreview(c buffalo.Context) error {
+	endOfYear := domain.EndOfYear(time.Now().UTC().Year())
+	return renderRenewalPreview(c, endOfYear, domain.BillingPeriodAnnual, "annual")
+}
+
+// swagger:operation GET /ledger-reports/monthly/preview Ledger LedgerMonthlyRenewalPreview
+// LedgerMonthlyRenewalPreview
+//
+// List the ledger entries that the monthly billing process would create, without creating them.
+// ---
+//
+//	parameters:
+//	  - name: format
+//	    in: query
+//	    required: false
+//	    description: omit for a RenewalPreview, or `csv` for a File containing the preview
+//	responses:
+//	  '200':
+//	    description: the preview of the monthly billing process
+//	    schema:
+//	      "$ref": "#/definitions/RenewalPreview"
+func ledgerMonthlyRenewalPreview(c buffalo.Context) error {
+	return renderRenewalPreview(c, time.Now().UTC(), domain.BillingPeriodMonthly, "monthly")
+}
+
+func renderRenewalPreview(c buffalo.Context, date time.Time, billingPeriod int, label string) error {
+	actor := models.CurrentUser(c)
+	if !actor.IsAdmin() {
+		err := fmt.Errorf("user not allowed to access %s batch data", label)
+		return reportError(c, api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden))
+	}
+
+	format := c.Param(exportFormatParam)
+	if format != "" && format != api.ExportFormatCSV {
+		err := errors.New("invalid export format: " + format)
+		return reportError(c, api.NewAppError(err, api.ErrorInvalidExportFormat, api.CategoryUser))
+	}
+
+	tx := models.Tx(c)
+
+	var policies models.Policies
+	if err := policies.AllActive(tx); err != nil {
+		return reportError(c, err)
+	}
+
+	preview, err := policies.PreviewRenewals(tx, date, billingPeriod)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	if format == "" {
+		return renderOk(c, preview)
+	}
+
+	file, err := models.NewRenewalPreviewFile(c, preview, label)
+	if err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, file.ConvertToAPI(tx))
+}
+
 func getReferencedLedgerReportFromCtx(c buffalo.Context) *models.LedgerReport {
 	lr, ok := c.Value(domain.TypeLedgerReport).(*models.LedgerReport)
 	if !ok {
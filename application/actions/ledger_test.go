@@ -91,7 +91,7 @@ func (as *ActionSuite) Test_LedgerReportView() {
 	as.NoError(lr.Create(as.DB))
 
 	policyReport, err := models.NewPolicyLedgerReport(models.CreateTestContext(normalUser),
-		policy, models.ReportTypeAnnual, 0, now.Year())
+		policy, models.ReportTypeAnnual, 0, now.Year(), "")
 	as.NoError(err)
 	as.NoError(policyReport.Create(as.DB))
 
@@ -309,6 +309,133 @@ func (as *ActionSuite) Test_LedgerReportReconcile() {
 	}
 }
 
+func (as *ActionSuite) Test_LedgerReportPreview() {
+	f := as.createFixturesForLedger()
+	normalUser := f.Users[0]
+	stewardUser := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		format     string
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "insufficient privileges",
+			actor:      normalUser,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "invalid format",
+			actor:      stewardUser,
+			format:     "not-a-real-format",
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorInvalidReportFormat.String()},
+		},
+		{
+			name:       "default format",
+			actor:      stewardUser,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"format":"` + fin.ReportFormatSage,
+				`"content_type":"` + domain.ContentCSV,
+				`"transaction_count":1`,
+			},
+		},
+		{
+			name:       "json format",
+			actor:      stewardUser,
+			format:     fin.ReportFormatJSON,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{`"format":"` + fin.ReportFormatJSON, `"content_type":"` + domain.ContentJson},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON("%s/%s", ledgerReportPath, api.ResourcePreview).Post(api.LedgerReportCreateInput{
+				Type:   models.ReportTypeMonthly,
+				Date:   time.Now().UTC().Format(domain.DateFormat),
+				Format: tt.format,
+			})
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+
+	count, err := as.DB.Count(&models.LedgerReports{})
+	as.NoError(err)
+	as.Equal(0, count, "preview should not create a report")
+}
+
+func (as *ActionSuite) Test_LedgerReportUnreconcile() {
+	f := as.createFixturesForLedger()
+	admins := models.CreateAdminUsers(as.DB)
+	stewardUser := admins[models.AppRoleSteward]
+	signatorUser := admins[models.AppRoleSignator]
+
+	ctx := models.CreateTestContext(stewardUser)
+	lr, err := models.NewLedgerReport(ctx, fin.ReportFormatSage, models.ReportTypeMonthly, time.Now())
+	as.NoError(err)
+	as.NoError(lr.Create(as.DB))
+	as.NoError(lr.Reconcile(ctx))
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		reason     string
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "steward",
+			actor:      stewardUser,
+			reason:     "import failed",
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "no reason",
+			actor:      signatorUser,
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorLedgerReportUnreconcile.String()},
+		},
+		{
+			name:       "signator",
+			actor:      signatorUser,
+			reason:     "import failed",
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"id":"` + lr.ID.String(),
+				`"is_cleared":false`,
+				`"voided_by_id":"` + signatorUser.ID.String(),
+				`"void_reason":"import failed"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON("%s/%s/%s", ledgerReportPath, lr.ID, api.ResourceUnreconcile).
+				Post(api.LedgerReportUnreconcileInput{Reason: tt.reason})
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+
+	var entry models.LedgerEntry
+	as.NoError(as.DB.Where("item_id = ?", f.Items[1].ID).First(&entry))
+	as.False(entry.DateEntered.Valid, "ledger entry DateEntered was not cleared")
+}
+
 func (as *ActionSuite) Test_LedgerAnnualProcess() {
 	year := time.Now().UTC().Year()
 
@@ -426,6 +553,74 @@ func (as *ActionSuite) Test_LedgerAnnualRenewalStatus() {
 	}
 }
 
+func (as *ActionSuite) Test_LedgerAnnualRenewalPreview() {
+	year := time.Now().UTC().Year()
+
+	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{ItemsPerPolicy: 2})
+
+	f.Items[0].PaidThroughDate = domain.EndOfYear(year - 1)
+	models.UpdateItemStatus(as.DB, f.Items[0], api.ItemCoverageStatusApproved, "")
+
+	normalUser := f.Users[0]
+	stewardUser := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		format     string
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "insufficient privileges",
+			actor:      normalUser,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "invalid format",
+			actor:      stewardUser,
+			format:     api.ExportFormatPDF,
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorInvalidExportFormat.String()},
+		},
+		{
+			name:       "preview",
+			actor:      stewardUser,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"item_count":1`,
+				`"entry_count":1`,
+				`"item_id":"` + f.Items[0].ID.String(),
+				`"type":"` + string(models.LedgerEntryTypeCoverageRenewal),
+				`"anomalies":[]`,
+			},
+		},
+		{
+			name:       "csv",
+			actor:      stewardUser,
+			format:     api.ExportFormatCSV,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{`"content_type":"` + domain.ContentCSV, "annual_renewal_preview"},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON("%s/annual/%s?format=%s", ledgerReportPath, api.ResourcePreview, tt.format).Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+
+	var entries models.LedgerEntries
+	as.NoError(as.DB.All(&entries))
+	as.Len(entries, 0, "preview should not create ledger entries")
+}
+
 func (as *ActionSuite) createFixturesForLedger() models.Fixtures {
 	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{ItemsPerPolicy: 3})
 
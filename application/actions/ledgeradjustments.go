@@ -0,0 +1,161 @@
+package actions
+
+import (
+	"github.com/gobuffalo/buffalo"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+// swagger:operation GET /ledger-adjustments LedgerAdjustments LedgerAdjustmentsList
+// LedgerAdjustmentsList
+//
+// list manual ledger adjustments, most recent first
+// ---
+//
+//	parameters:
+//	- name: status
+//	  in: query
+//	  required: false
+//	  description: only include adjustments with this status, one of Proposed, Approved, Rejected
+//	responses:
+//	  '200':
+//	    description: list of Ledger Adjustments
+//	    schema:
+//	      type: array
+//	      items:
+//	        "$ref": "#/definitions/LedgerAdjustment"
+func ledgerAdjustmentsList(c buffalo.Context) error {
+	tx := models.Tx(c)
+
+	var adjustments models.LedgerAdjustments
+	if err := adjustments.FindAll(tx, api.LedgerAdjustmentStatus(c.Param("status"))); err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, adjustments.ConvertToAPI(tx))
+}
+
+// swagger:operation GET /ledger-adjustments/{id} LedgerAdjustments LedgerAdjustmentsView
+// LedgerAdjustmentsView
+//
+// view a manual ledger adjustment
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: ledger adjustment ID
+//	responses:
+//	  '200':
+//	    description: the Ledger Adjustment
+//	    schema:
+//	      "$ref": "#/definitions/LedgerAdjustment"
+func ledgerAdjustmentsView(c buffalo.Context) error {
+	adjustment := getReferencedLedgerAdjustmentFromCtx(c)
+	return renderOk(c, adjustment.ConvertToAPI(models.Tx(c)))
+}
+
+// swagger:operation POST /ledger-adjustments LedgerAdjustments LedgerAdjustmentsCreate
+// LedgerAdjustmentsCreate
+//
+// propose a manual ledger adjustment. It enters the ledger only after a signator other than the proposer
+// approves it.
+// ---
+//
+//	parameters:
+//	  - name: ledger adjustment input
+//	    in: body
+//	    description: ledger adjustment input object
+//	    required: true
+//	    schema:
+//	      "$ref": "#/definitions/LedgerAdjustmentInput"
+//	responses:
+//	  '200':
+//	    description: the proposed Ledger Adjustment
+//	    schema:
+//	      "$ref": "#/definitions/LedgerAdjustment"
+func ledgerAdjustmentsCreate(c buffalo.Context) error {
+	var input api.LedgerAdjustmentInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	adjustment, err := models.NewLedgerAdjustment(c, input)
+	if err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, adjustment.ConvertToAPI(models.Tx(c)))
+}
+
+// swagger:operation POST /ledger-adjustments/{id}/approve LedgerAdjustments LedgerAdjustmentsApprove
+// LedgerAdjustmentsApprove
+//
+// approve a proposed ledger adjustment, adding it to the ledger. It is included in the next monthly ledger report.
+// Only a signator other than the proposer can approve an adjustment.
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: ledger adjustment ID
+//	responses:
+//	  '200':
+//	    description: the approved Ledger Adjustment
+//	    schema:
+//	      "$ref": "#/definitions/LedgerAdjustment"
+func ledgerAdjustmentsApprove(c buffalo.Context) error {
+	adjustment := getReferencedLedgerAdjustmentFromCtx(c)
+	if err := adjustment.Approve(c); err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, adjustment.ConvertToAPI(models.Tx(c)))
+}
+
+// swagger:operation POST /ledger-adjustments/{id}/reject LedgerAdjustments LedgerAdjustmentsReject
+// LedgerAdjustmentsReject
+//
+// reject a proposed ledger adjustment. Only a signator other than the proposer can reject an adjustment.
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: ledger adjustment ID
+//	  - name: ledger adjustment reject input
+//	    in: body
+//	    description: ledger adjustment reject input object
+//	    required: true
+//	    schema:
+//	      "$ref": "#/definitions/LedgerAdjustmentRejectInput"
+//	responses:
+//	  '200':
+//	    description: the rejected Ledger Adjustment
+//	    schema:
+//	      "$ref": "#/definitions/LedgerAdjustment"
+func ledgerAdjustmentsReject(c buffalo.Context) error {
+	adjustment := getReferencedLedgerAdjustmentFromCtx(c)
+
+	var input api.LedgerAdjustmentRejectInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	if err := adjustment.Reject(c, input.Note); err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, adjustment.ConvertToAPI(models.Tx(c)))
+}
+
+// getReferencedLedgerAdjustmentFromCtx pulls the models.LedgerAdjustment resource from context that was put there
+// by the AuthZ middleware
+func getReferencedLedgerAdjustmentFromCtx(c buffalo.Context) *models.LedgerAdjustment {
+	adjustment, ok := c.Value(domain.TypeLedgerAdjustment).(*models.LedgerAdjustment)
+	if !ok {
+		panic("ledger adjustment not found in context")
+	}
+	return adjustment
+}
@@ -0,0 +1,127 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (as *ActionSuite) Test_LedgerAdjustmentsCreate() {
+	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{NumberOfPolicies: 1, ItemsPerPolicy: 1})
+	policy := f.Policies[0]
+	normalUser := f.Users[0]
+	stewardUser := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	input := api.LedgerAdjustmentInput{
+		PolicyID: policy.ID,
+		Type:     api.LedgerEntryType(models.LedgerEntryTypePolicyAdjustment),
+		Amount:   1000,
+		Reason:   "goodwill credit",
+	}
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		input      api.LedgerAdjustmentInput
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "insufficient privileges",
+			actor:      normalUser,
+			input:      input,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "no reason",
+			actor:      stewardUser,
+			input:      api.LedgerAdjustmentInput{PolicyID: policy.ID, Type: input.Type, Amount: input.Amount},
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorLedgerAdjustmentInvalid.String()},
+		},
+		{
+			name:       "good",
+			actor:      stewardUser,
+			input:      input,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"policy_id":"` + policy.ID.String(),
+				`"amount":1000`,
+				`"reason":"goodwill credit"`,
+				`"status":"` + string(api.LedgerAdjustmentStatusProposed),
+				`"proposed_by_id":"` + stewardUser.ID.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON("%s", ledgerAdjustmentsPath).Post(tt.input)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
+
+func (as *ActionSuite) Test_LedgerAdjustmentsApprove() {
+	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{NumberOfPolicies: 1, ItemsPerPolicy: 1})
+	policy := f.Policies[0]
+	admins := models.CreateAdminUsers(as.DB)
+	stewardUser := admins[models.AppRoleSteward]
+	signatorUser := admins[models.AppRoleSignator]
+
+	adjustment, err := models.NewLedgerAdjustment(models.CreateTestContext(stewardUser), api.LedgerAdjustmentInput{
+		PolicyID: policy.ID,
+		Type:     api.LedgerEntryType(models.LedgerEntryTypePolicyAdjustment),
+		Amount:   -1000,
+		Reason:   "mis-billed premium",
+	})
+	as.NoError(err)
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "steward",
+			actor:      stewardUser,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "signator",
+			actor:      signatorUser,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"status":"` + string(api.LedgerAdjustmentStatusApproved),
+				`"reviewed_by_id":"` + signatorUser.ID.String(),
+			},
+		},
+		{
+			name:       "already approved",
+			actor:      signatorUser,
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorLedgerAdjustmentStatus.String()},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON(fmt.Sprintf("%s/%s/%s", ledgerAdjustmentsPath, adjustment.ID, api.ResourceApprove)).Post(nil)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
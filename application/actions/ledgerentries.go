@@ -0,0 +1,90 @@
+package actions
+
+import (
+	"github.com/gobuffalo/buffalo"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+// swagger:operation GET /ledger-entries/{id} LedgerEntries LedgerEntriesView
+// LedgerEntriesView
+//
+// view a ledger entry
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: ledger entry ID
+//	responses:
+//	  '200':
+//	    description: the Ledger Entry
+//	    schema:
+//	      "$ref": "#/definitions/LedgerEntry"
+func ledgerEntriesView(c buffalo.Context) error {
+	entry := getReferencedLedgerEntryFromCtx(c)
+	return renderOk(c, entry.ConvertToAPI(models.Tx(c)))
+}
+
+// swagger:operation POST /ledger-entries/{id}/reverse LedgerEntries LedgerEntriesReverse
+// LedgerEntriesReverse
+//
+// reverse a ledger entry. Ledger entries are not edited, so a correction is made by adding an entry that cancels
+// the original and, optionally, a replacement entry. Both are included in the next monthly ledger report. Only a
+// signator can reverse an entry.
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: ledger entry ID
+//	  - name: ledger entry reverse input
+//	    in: body
+//	    description: ledger entry reverse input object
+//	    required: true
+//	    schema:
+//	      "$ref": "#/definitions/LedgerEntryReverseInput"
+//	responses:
+//	  '200':
+//	    description: the reversal and replacement Ledger Entries
+//	    schema:
+//	      "$ref": "#/definitions/LedgerEntryCorrection"
+func ledgerEntriesReverse(c buffalo.Context) error {
+	entry := getReferencedLedgerEntryFromCtx(c)
+
+	var input api.LedgerEntryReverseInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	tx := models.Tx(c)
+
+	if input.ReplacementAmount == nil {
+		reversal, err := entry.Reverse(c, input.Reason)
+		if err != nil {
+			return reportError(c, err)
+		}
+		return renderOk(c, api.LedgerEntryCorrection{Reversal: reversal.ConvertToAPI(tx)})
+	}
+
+	reversal, replacement, err := entry.Correct(c, input.Reason, *input.ReplacementAmount)
+	if err != nil {
+		return reportError(c, err)
+	}
+	apiReplacement := replacement.ConvertToAPI(tx)
+	return renderOk(c, api.LedgerEntryCorrection{Reversal: reversal.ConvertToAPI(tx), Replacement: &apiReplacement})
+}
+
+// getReferencedLedgerEntryFromCtx pulls the models.LedgerEntry resource from context that was put there
+// by the AuthZ middleware
+func getReferencedLedgerEntryFromCtx(c buffalo.Context) *models.LedgerEntry {
+	entry, ok := c.Value(domain.TypeLedgerEntry).(*models.LedgerEntry)
+	if !ok {
+		panic("ledger entry not found in context")
+	}
+	return entry
+}
@@ -0,0 +1,91 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (as *ActionSuite) Test_LedgerEntriesReverse() {
+	f := models.CreateLedgerFixtures(as.DB, models.FixturesConfig{NumberOfPolicies: 1, ItemsPerPolicy: 2})
+	admins := models.CreateAdminUsers(as.DB)
+	stewardUser := admins[models.AppRoleSteward]
+	signatorUser := admins[models.AppRoleSignator]
+
+	reversed := f.LedgerEntries[0]
+	corrected := f.LedgerEntries[1]
+	replacementAmount := corrected.Amount + 100
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		entry      models.LedgerEntry
+		input      api.LedgerEntryReverseInput
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "steward",
+			actor:      stewardUser,
+			entry:      reversed,
+			input:      api.LedgerEntryReverseInput{Reason: "duplicate"},
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "no reason",
+			actor:      signatorUser,
+			entry:      reversed,
+			input:      api.LedgerEntryReverseInput{},
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorLedgerEntryCorrection.String()},
+		},
+		{
+			name:       "reverse",
+			actor:      signatorUser,
+			entry:      reversed,
+			input:      api.LedgerEntryReverseInput{Reason: "duplicate"},
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"reversal_of_id":"` + reversed.ID.String(),
+				fmt.Sprintf(`"amount":%d`, -reversed.Amount),
+				`"correction_reason":"duplicate"`,
+				`"replacement":null`,
+			},
+		},
+		{
+			name:       "already reversed",
+			actor:      signatorUser,
+			entry:      reversed,
+			input:      api.LedgerEntryReverseInput{Reason: "duplicate"},
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorLedgerEntryNotReversible.String()},
+		},
+		{
+			name:       "replace",
+			actor:      signatorUser,
+			entry:      corrected,
+			input:      api.LedgerEntryReverseInput{Reason: "wrong amount", ReplacementAmount: &replacementAmount},
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"reversal_of_id":"` + corrected.ID.String(),
+				`"replacement_of_id":"` + corrected.ID.String(),
+				fmt.Sprintf(`"amount":%d`, replacementAmount),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON("%s/%s/%s", ledgerEntriesPath, tt.entry.ID, api.ResourceReverse).Post(tt.input)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
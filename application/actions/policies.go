@@ -20,6 +20,9 @@ const (
 
 	// http param for year
 	YearParam = "year"
+
+	// http param for export file format
+	exportFormatParam = "format"
 )
 
 // swagger:operation GET /policies Policies PoliciesList
@@ -212,7 +215,7 @@ func policiesUpdate(c buffalo.Context) error {
 // PolicyLedgerReportCreate
 //
 // Create and return a report on the ledger entries of a policy as specified by the input object.
-// The returned object contains metadata and a File object pointing to a CSV file.
+// The returned object contains metadata and a File object pointing to a CSV or XLSX file.
 // If no ledger entries are found with a `date_entered` value that matches the requested
 // Type, Year and (if applicable) Month, then a 204 is returned.
 // ---
@@ -244,7 +247,7 @@ func policiesLedgerReportCreate(c buffalo.Context) error {
 		return reportError(c, err)
 	}
 
-	report, err := models.NewPolicyLedgerReport(c, *policy, input.Type, input.Month, input.Year)
+	report, err := models.NewPolicyLedgerReport(c, *policy, input.Type, input.Month, input.Year, input.Format)
 	if err != nil {
 		return reportError(c, err)
 	}
@@ -372,6 +375,137 @@ func policiesStrikeCreate(c buffalo.Context) error {
 	return renderOk(c, strikes.ConvertToAPI(tx))
 }
 
+// swagger:operation POST /policies/{id}/close Policies PoliciesClose
+// PoliciesClose
+//
+// Close a policy. Coverage of all its items ends on the given date, credits are created for any unused
+// annual premiums, and no further items or claims may be added. All open claims must be resolved first.
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: policy ID
+//	  - name: input
+//	    in: body
+//	    description: PolicyCloseInput object
+//	    required: true
+//	    schema:
+//	      "$ref": "#/definitions/PolicyCloseInput"
+//	responses:
+//	  '200':
+//	    description: the closed Policy
+//	    schema:
+//	      "$ref": "#/definitions/Policy"
+func policiesClose(c buffalo.Context) error {
+	policy := getReferencedPolicyFromCtx(c)
+
+	var input api.PolicyCloseInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	date := time.Now().UTC()
+	if input.Date != "" {
+		var err error
+		if date, err = time.Parse(domain.DateFormat, input.Date); err != nil {
+			return reportError(c, api.NewAppError(err, api.ErrorInvalidDate, api.CategoryUser))
+		}
+	}
+
+	if err := policy.Close(c, date, input.Reason); err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, policy.ConvertToAPI(models.Tx(c), true))
+}
+
+// swagger:operation POST /policies/{id}/merge Policies PoliciesMerge
+// PoliciesMerge
+//
+// Merge another household policy into this one. The members, dependents, items, claims, invites and strikes of the
+// other policy are moved to this policy, and the other policy is closed. Any unused annual premium on the moved
+// items is transferred between the two policies. The merged policy keeps the chosen household ID. Only available to
+// admins.
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: ID of the policy that remains after the merge
+//	  - name: input
+//	    in: body
+//	    description: PolicyMergeInput object
+//	    required: true
+//	    schema:
+//	      "$ref": "#/definitions/PolicyMergeInput"
+//	responses:
+//	  '200':
+//	    description: the merged Policy
+//	    schema:
+//	      "$ref": "#/definitions/Policy"
+func policiesMerge(c buffalo.Context) error {
+	tx := models.Tx(c)
+	policy := getReferencedPolicyFromCtx(c)
+
+	var input api.PolicyMergeInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	var other models.Policy
+	if err := other.FindByID(tx, input.PolicyID); err != nil {
+		return reportError(c, api.NewAppError(err, api.ErrorPolicyNotFound, api.CategoryNotFound))
+	}
+
+	if err := policy.Merge(c, &other, input.HouseholdID); err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, policy.ConvertToAPI(tx, true))
+}
+
+// swagger:operation POST /policies/{id}/split Policies PoliciesSplit
+// PoliciesSplit
+//
+// Split a household policy by moving some of its members, dependents and items to a new household policy. Any
+// unused annual premium on the moved items is transferred to the new policy. Only available to admins.
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: policy ID
+//	  - name: input
+//	    in: body
+//	    description: PolicySplitInput object
+//	    required: true
+//	    schema:
+//	      "$ref": "#/definitions/PolicySplitInput"
+//	responses:
+//	  '200':
+//	    description: the new Policy
+//	    schema:
+//	      "$ref": "#/definitions/Policy"
+func policiesSplit(c buffalo.Context) error {
+	policy := getReferencedPolicyFromCtx(c)
+
+	var input api.PolicySplitInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	newPolicy, err := policy.Split(c, input)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, newPolicy.ConvertToAPI(models.Tx(c), true))
+}
+
 // swagger:operation POST /policies/{id}/imports Policies PoliciesImport
 // PoliciesImport
 //
@@ -434,6 +434,10 @@ func (as *ActionSuite) Test_PoliciesListMembers() {
 	err := appAdmin.Update(as.DB)
 	as.NoError(err, "failed to make first policy user an app admin")
 
+	memberUser := fixtures.Policies[1].Members[1]
+	memberPolUser := fixtures.Policies[1].GetPolicyUsers(as.DB, false)[1]
+	as.NoError(memberPolUser.UpdateRole(models.CreateTestContext(normalUser), api.PolicyUserRoleMember))
+
 	tests := []struct {
 		name          string
 		actor         models.User
@@ -479,6 +483,15 @@ func (as *ActionSuite) Test_PoliciesListMembers() {
 			wantInBody:    normalUser.ID.String(),
 			notWantInBody: appAdmin.ID.String(),
 		},
+		{
+			name:          "user with the member role",
+			actor:         memberUser,
+			policyID:      fixtures.Policies[1].ID.String(),
+			wantCount:     fixConfig.UsersPerPolicy,
+			wantStatus:    http.StatusOK,
+			wantInBody:    normalUser.ID.String(),
+			notWantInBody: appAdmin.ID.String(),
+		},
 		{
 			name:          "normal user, other user's policy",
 			actor:         normalUser,
@@ -869,3 +882,111 @@ func (as *ActionSuite) Test_PolicyStrikesCreate() {
 		})
 	}
 }
+
+func (as *ActionSuite) Test_PoliciesClose() {
+	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{NumberOfPolicies: 2})
+	member := f.Policies[0].Members[0]
+	otherUser := f.Policies[1].Members[0]
+
+	today := time.Now().UTC().Format(domain.DateFormat)
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		input      api.PolicyCloseInput
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "unauthenticated",
+			actor:      models.User{},
+			wantStatus: http.StatusUnauthorized,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "not a policy member",
+			actor:      otherUser,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "bad date",
+			actor:      member,
+			input:      api.PolicyCloseInput{Date: "not a date"},
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorInvalidDate.String()},
+		},
+		{
+			name:       "good",
+			actor:      member,
+			input:      api.PolicyCloseInput{Date: today, Reason: "leaving"},
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"id":"` + f.Policies[0].ID.String(),
+				`"closed_date":"` + today,
+				`"closed_reason":"leaving"`,
+			},
+		},
+		{
+			name:       "already closed",
+			actor:      member,
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorPolicyClosed.String()},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			req := as.JSON("%s/%s/%s", policiesPath, f.Policies[0].ID.String(), api.ResourceClose)
+			res := req.Post(tt.input)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
+
+func (as *ActionSuite) Test_PoliciesMerge() {
+	f := models.CreatePolicyFixtures(as.DB, models.FixturesConfig{NumberOfPolicies: 2})
+	survivor := f.Policies[0]
+	other := f.Policies[1]
+	member := survivor.Members[0]
+	steward := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "policy member",
+			actor:      member,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "steward",
+			actor:      steward,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"id":"` + survivor.ID.String(),
+				`"id":"` + other.Members[0].ID.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			req := as.JSON("%s/%s/%s", policiesPath, survivor.ID.String(), api.ResourceMerge)
+			res := req.Post(api.PolicyMergeInput{PolicyID: other.ID})
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
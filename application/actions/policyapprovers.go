@@ -0,0 +1,132 @@
+package actions
+
+import (
+	"net/http"
+
+	"github.com/gobuffalo/buffalo"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+// swagger:operation GET /policies/{id}/approvers PolicyApprovers PolicyApproversList
+// PolicyApproversList
+//
+// list the budget approvers of a team policy
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: policy ID
+//	responses:
+//	  '200':
+//	    description: all policy approvers
+//	    schema:
+//	      "$ref": "#/definitions/PolicyApprovers"
+func policiesListApprovers(c buffalo.Context) error {
+	tx := models.Tx(c)
+	policy := getReferencedPolicyFromCtx(c)
+
+	approvers := policy.GetApprovers(tx)
+	return renderOk(c, approvers.ConvertToAPI(tx))
+}
+
+// swagger:operation POST /policies/{id}/approvers PolicyApprovers PolicyApproversAdd
+// PolicyApproversAdd
+//
+// Add a budget approver to a team policy. New coverage on the policy will need their approval before steward
+// review. Only an admin may add an approver.
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: policy ID
+//	  - name: approver input
+//	    in: body
+//	    description: approver input object
+//	    required: true
+//	    schema:
+//	      "$ref": "#/definitions/PolicyApproverInput"
+//	responses:
+//	  '200':
+//	    description: the new approver
+//	    schema:
+//	      "$ref": "#/definitions/PolicyApprover"
+func policiesAddApprover(c buffalo.Context) error {
+	tx := models.Tx(c)
+	policy := getReferencedPolicyFromCtx(c)
+
+	var input api.PolicyApproverInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	approver, err := policy.AddApprover(c, input)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, approver.ConvertToAPI(tx))
+}
+
+// swagger:operation DELETE /policy-approvers/{id} PolicyApprovers PolicyApproversDelete
+// PolicyApproversDelete
+//
+// Remove a budget approver from a team policy. Only an admin may remove an approver.
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: policy approver ID
+//	responses:
+//	  '204':
+//	    description: OK but no content in response
+func policyApproversDelete(c buffalo.Context) error {
+	approver := getReferencedPolicyApproverFromCtx(c)
+
+	if err := approver.Delete(c); err != nil {
+		return reportError(c, err)
+	}
+
+	return c.Render(http.StatusNoContent, nil)
+}
+
+// swagger:operation GET /users/me/approvals Users UsersMeApprovals
+// UsersMeApprovals
+//
+// list the items awaiting budget approval on the team policies the current user approves
+// ---
+//
+//	responses:
+//	  '200':
+//	    description: items awaiting budget approval
+//	    schema:
+//	      "$ref": "#/definitions/Items"
+func usersMeApprovals(c buffalo.Context) error {
+	tx := models.Tx(c)
+	user := models.CurrentUser(c)
+
+	var items models.Items
+	if err := items.FindPendingBudgetForApprover(tx, user.ID); err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, items.ConvertToAPI(tx))
+}
+
+// getReferencedPolicyApproverFromCtx pulls the models.PolicyApprover resource from context that was put there
+// by the AuthZ middleware
+func getReferencedPolicyApproverFromCtx(c buffalo.Context) *models.PolicyApprover {
+	approver, ok := c.Value(domain.TypePolicyApprover).(*models.PolicyApprover)
+	if !ok {
+		panic("policy approver not found in context")
+	}
+	return approver
+}
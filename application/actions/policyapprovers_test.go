@@ -0,0 +1,115 @@
+package actions
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (as *ActionSuite) Test_PoliciesAddApprover() {
+	f := models.CreateTeamPolicyFixtures(as.DB, models.FixturesConfig{})
+	policy := f.Policies[0]
+	member := f.Users[0]
+	approver := models.CreateUserFixtures(as.DB, 1).Users[0]
+	steward := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "policy member",
+			actor:      member,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "steward",
+			actor:      steward,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"policy_id":"` + policy.ID.String(),
+				`"user_id":"` + approver.ID.String(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON("%s/%s/%s", policiesPath, policy.ID.String(), api.ResourceApprovers).
+				Post(api.PolicyApproverInput{Email: approver.Email})
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
+
+func (as *ActionSuite) Test_ItemsBudgetApprove() {
+	f := models.CreateTeamPolicyFixtures(as.DB, models.FixturesConfig{})
+	policy := f.Policies[0]
+	member := f.Users[0]
+	approver := models.CreateUserFixtures(as.DB, 1).Users[0]
+	steward := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	_, err := policy.AddApprover(models.CreateTestContext(steward), api.PolicyApproverInput{Email: approver.Email})
+	as.NoError(err)
+
+	category := models.CreateCategoryFixtures(as.DB, 1).ItemCategories[0]
+	item := models.Item{
+		Name:              "team laptop",
+		CategoryID:        category.ID,
+		RiskCategoryID:    models.RiskCategoryStationaryID(),
+		Country:           "Thailand",
+		PolicyID:          policy.ID,
+		CoverageAmount:    category.AutoApproveMax + 100,
+		CoverageStartDate: time.Now().UTC(),
+	}
+	models.MustCreate(as.DB, &item)
+	as.NoError(item.SubmitForApproval(models.CreateTestContext(member)))
+	as.Equal(api.ItemCoverageStatusPendingBudget, item.CoverageStatus)
+
+	as.SetAccessToken(approver)
+	res := as.JSON("%s/me/%s", usersPath, api.ResourceApprovals).Get()
+	as.Equal(http.StatusOK, res.Code, "incorrect status code listing approvals, body: %s", res.Body.String())
+	as.verifyResponseData([]string{`"id":"` + item.ID.String()}, res.Body.String(), "")
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "policy member",
+			actor:      member,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "approver",
+			actor:      approver,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"id":"` + item.ID.String(),
+				`"coverage_status":"` + string(api.ItemCoverageStatusPending),
+			},
+		},
+	}
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON("%s/%s/%s", itemsPath, item.ID.String(), api.ResourceBudgetApprove).Post(nil)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
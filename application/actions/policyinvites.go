@@ -0,0 +1,114 @@
+package actions
+
+import (
+	"net/http"
+
+	"github.com/gobuffalo/buffalo"
+
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+// swagger:operation GET /policies/{id}/invites PolicyInvites PolicyInvitesList
+// PolicyInvitesList
+//
+// list the pending invites of a Policy, including expired invites that have not been cleaned up
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: policy ID
+//	responses:
+//	  '200':
+//	    description: all policy invites
+//	    schema:
+//	      type: array
+//	      items:
+//	        "$ref": "#/definitions/PolicyUserInvite"
+func policiesListInvites(c buffalo.Context) error {
+	policy := getReferencedPolicyFromCtx(c)
+	policy.LoadInvites(models.Tx(c), true)
+	return renderOk(c, policy.Invites.ConvertToAPI())
+}
+
+// swagger:operation POST /policy-invites/{id}/resend PolicyInvites PolicyInvitesResend
+// PolicyInvitesResend
+//
+// send the invite email again. An expired invite must be extended instead.
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: policy invite ID
+//	responses:
+//	  '200':
+//	    description: the policy invite
+//	    schema:
+//	      "$ref": "#/definitions/PolicyUserInvite"
+func policyInvitesResend(c buffalo.Context) error {
+	invite := getReferencedPolicyInviteFromCtx(c)
+	if err := invite.Resend(c); err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, invite.ConvertToAPI())
+}
+
+// swagger:operation POST /policy-invites/{id}/extend PolicyInvites PolicyInvitesExtend
+// PolicyInvitesExtend
+//
+// reset the expiration of the invite to InviteLifetimeDays from now, and send the invite email again
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: policy invite ID
+//	responses:
+//	  '200':
+//	    description: the policy invite
+//	    schema:
+//	      "$ref": "#/definitions/PolicyUserInvite"
+func policyInvitesExtend(c buffalo.Context) error {
+	invite := getReferencedPolicyInviteFromCtx(c)
+	if err := invite.Extend(c); err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, invite.ConvertToAPI())
+}
+
+// swagger:operation DELETE /policy-invites/{id} PolicyInvites PolicyInvitesRevoke
+// PolicyInvitesRevoke
+//
+// revoke an invite and notify the invitee
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: policy invite ID
+//	responses:
+//	  '204':
+//	    description: OK but no content in response
+func policyInvitesRevoke(c buffalo.Context) error {
+	invite := getReferencedPolicyInviteFromCtx(c)
+	if err := invite.Revoke(c); err != nil {
+		return reportError(c, err)
+	}
+	return c.Render(http.StatusNoContent, nil)
+}
+
+// getReferencedPolicyInviteFromCtx pulls the models.PolicyUserInvite resource from context that was put there
+// by the AuthZ middleware
+func getReferencedPolicyInviteFromCtx(c buffalo.Context) *models.PolicyUserInvite {
+	invite, ok := c.Value(domain.TypePolicyInvite).(*models.PolicyUserInvite)
+	if !ok {
+		panic("policy invite not found in context")
+	}
+	return invite
+}
@@ -0,0 +1,98 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (as *ActionSuite) Test_PoliciesListInvites() {
+	f := models.CreatePolicyFixtures(as.DB, models.FixturesConfig{NumberOfPolicies: 2, InvitesPerPolicy: 2})
+	policy := f.Policies[0]
+	member := policy.Members[0]
+	otherUser := f.Policies[1].Members[0]
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "not a member",
+			actor:      otherUser,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "member",
+			actor:      member,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"email":"` + f.PolicyUserInvites[0].Email,
+				`"email":"` + f.PolicyUserInvites[1].Email,
+				`"is_expired":false`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON("%s/%s/%s", policiesPath, policy.ID.String(), api.ResourceInvites).Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
+
+func (as *ActionSuite) Test_PolicyInvitesRevoke() {
+	f := models.CreatePolicyFixtures(as.DB, models.FixturesConfig{UsersPerPolicy: 2, InvitesPerPolicy: 1})
+	policy := f.Policies[0]
+	owner := policy.Members[0]
+	member := policy.Members[1]
+	invite := f.PolicyUserInvites[0]
+
+	polUsers := policy.GetPolicyUsers(as.DB, false)
+	as.NoError(polUsers[1].UpdateRole(models.CreateTestContext(owner), api.PolicyUserRoleMember))
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "not an owner",
+			actor:      member,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "owner",
+			actor:      owner,
+			wantStatus: http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON("%s/%s", policyInvitePath, invite.ID.String()).Delete()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+
+			if tt.wantStatus != http.StatusNoContent {
+				return
+			}
+			count, err := as.DB.Where("id = ?", invite.ID).Count(&models.PolicyUserInvite{})
+			as.NoError(err)
+			as.Equal(0, count, fmt.Sprintf("invite %s was not deleted", invite.ID))
+		})
+	}
+}
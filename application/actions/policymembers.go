@@ -1,6 +1,7 @@
 package actions
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gobuffalo/buffalo"
@@ -32,9 +33,9 @@ func policiesListMembers(c buffalo.Context) error {
 	tx := models.Tx(c)
 	policy := getReferencedPolicyFromCtx(c)
 
-	polUserIDs := policy.GetPolicyUserIDs(tx, false)
+	polUsers := policy.GetPolicyUsers(tx, false)
 
-	return renderOk(c, policy.Members.ConvertToPolicyMembers(polUserIDs))
+	return renderOk(c, policy.Members.ConvertToPolicyMembers(polUsers))
 }
 
 // swagger:operation POST /policies/{id}/members PolicyMembers PolicyMembersInvite
@@ -92,6 +93,53 @@ func policiesInviteMember(c buffalo.Context) error {
 	return c.Render(http.StatusNoContent, nil)
 }
 
+// swagger:operation PUT /policies/{id}/members PolicyMembers PolicyMembersUpdateRole
+// PolicyMembersUpdateRole
+//
+// change the role of a member of a Policy. Only an owner of the policy may change roles, and a policy must
+// always have at least one owner.
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: policy ID
+//	  - name: policy member role input
+//	    in: body
+//	    description: policy member role input object
+//	    required: true
+//	    schema:
+//	      "$ref": "#/definitions/PolicyMemberRoleInput"
+//	responses:
+//	  '200':
+//	    description: all policy members
+//	    schema:
+//	      type: array
+//	      items:
+//	        "$ref": "#/definitions/PolicyMember"
+func policiesMembersUpdateRole(c buffalo.Context) error {
+	tx := models.Tx(c)
+	policy := getReferencedPolicyFromCtx(c)
+
+	var input api.PolicyMemberRoleInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	var polUser models.PolicyUser
+	if err := polUser.FindByID(tx, input.PolicyUserID); err != nil || polUser.PolicyID != policy.ID {
+		err := fmt.Errorf("policy user %s not found on policy %s", input.PolicyUserID, policy.ID)
+		return reportError(c, api.NewAppError(err, api.ErrorResourceNotFound, api.CategoryNotFound))
+	}
+
+	if err := polUser.UpdateRole(c, input.Role); err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, policy.Members.ConvertToPolicyMembers(policy.GetPolicyUsers(tx, true)))
+}
+
 // swagger:operation DELETE /policy-members/{id} PolicyMembers PolicyMembersDelete
 // PolicyMembersDelete
 //
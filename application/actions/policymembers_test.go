@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/silinternational/cover-api/api"
 	"github.com/silinternational/cover-api/domain"
 	"github.com/silinternational/cover-api/models"
 )
@@ -60,3 +61,60 @@ func (as *ActionSuite) TestPolicyMember_Delete() {
 		})
 	}
 }
+
+func (as *ActionSuite) Test_PoliciesMembersUpdateRole() {
+	f := models.CreatePolicyFixtures(as.DB, models.FixturesConfig{UsersPerPolicy: 3})
+	policy := f.Policies[0]
+	owner := policy.Members[0]
+	polUsers := policy.GetPolicyUsers(as.DB, false)
+
+	member := policy.Members[1]
+	memberPolUser := polUsers[1]
+	as.NoError(memberPolUser.UpdateRole(models.CreateTestContext(owner), api.PolicyUserRoleMember))
+
+	viewerPolUser := polUsers[2]
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		input      api.PolicyMemberRoleInput
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "not an owner",
+			actor:      member,
+			input:      api.PolicyMemberRoleInput{PolicyUserID: viewerPolUser.ID, Role: api.PolicyUserRoleViewer},
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "not on the policy",
+			actor:      owner,
+			input:      api.PolicyMemberRoleInput{PolicyUserID: domain.GetUUID(), Role: api.PolicyUserRoleViewer},
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorResourceNotFound.String()},
+		},
+		{
+			name:       "good",
+			actor:      owner,
+			input:      api.PolicyMemberRoleInput{PolicyUserID: viewerPolUser.ID, Role: api.PolicyUserRoleViewer},
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"policy_user_id":"` + viewerPolUser.ID.String(),
+				`"role":"` + string(api.PolicyUserRoleViewer),
+				`"role":"` + string(api.PolicyUserRoleMember),
+			},
+		},
+	}
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON("%s/%s/%s", policiesPath, policy.ID.String(), api.ResourceMembers).Put(tt.input)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
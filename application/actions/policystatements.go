@@ -0,0 +1,35 @@
+package actions
+
+import (
+	"github.com/gobuffalo/buffalo"
+
+	"github.com/silinternational/cover-api/models"
+)
+
+// swagger:operation GET /policies/{id}/statements PolicyStatements PolicyStatementsList
+// PolicyStatementsList
+//
+// list the archive of monthly statements of a Policy, most recent first
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: policy ID
+//	responses:
+//	  '200':
+//	    description: all policy statements, each with a link to its PDF
+//	    schema:
+//	      "$ref": "#/definitions/PolicyStatements"
+func policiesListStatements(c buffalo.Context) error {
+	tx := models.Tx(c)
+	policy := getReferencedPolicyFromCtx(c)
+
+	var statements models.PolicyStatements
+	if err := statements.FindByPolicyID(tx, policy.ID); err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, statements.ConvertToAPI(tx))
+}
@@ -0,0 +1,57 @@
+package actions
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (as *ActionSuite) Test_PoliciesListStatements() {
+	f := models.CreateLedgerFixtures(as.DB, models.FixturesConfig{NumberOfPolicies: 2})
+	policy := f.Policies[0]
+	member := policy.Members[0]
+	otherUser := f.Policies[1].Members[0]
+
+	now := time.Now().UTC()
+	lastMonth := now.AddDate(0, 0, -now.Day())
+	statement, err := models.NewPolicyStatement(models.CreateTestContext(member), policy,
+		int(lastMonth.Month()), lastMonth.Year())
+	as.NoError(err)
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "not a member",
+			actor:      otherUser,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "member",
+			actor:      member,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"id":"` + statement.ID.String(),
+				`"policy_id":"` + policy.ID.String(),
+				`"content_type":"application/pdf"`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON("%s/%s/%s", policiesPath, policy.ID.String(), api.ResourceStatements).Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
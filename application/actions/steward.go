@@ -2,10 +2,12 @@ package actions
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gobuffalo/buffalo"
 
 	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
 	"github.com/silinternational/cover-api/models"
 )
 
@@ -46,3 +48,65 @@ func stewardListRecentObjects(c buffalo.Context) error {
 
 	return renderOk(c, recent)
 }
+
+// swagger:operation GET /steward/analytics Steward PortfolioAnalytics
+// PortfolioAnalytics
+//
+// gets the earned premium, claims paid, loss ratio, claim frequency and average severity of a date range, in total
+// and by a chosen dimension, with a time series
+// ---
+//
+//	parameters:
+//	  - name: start
+//	    in: query
+//	    required: true
+//	    description: first date of the range, e.g. "2023-01-01"
+//	  - name: end
+//	    in: query
+//	    required: true
+//	    description: last date of the range, e.g. "2023-12-31"
+//	  - name: group_by
+//	    in: query
+//	    required: false
+//	    description: one of `risk_category` (default), `item_category`, `country`, `policy_type` or `year`
+//	  - name: interval
+//	    in: query
+//	    required: false
+//	    description: time series interval, either `month` (default) or `year`
+//	responses:
+//	  '200':
+//	    description: the portfolio analytics
+//	    schema:
+//	      "$ref": "#/definitions/PortfolioAnalytics"
+func stewardPortfolioAnalytics(c buffalo.Context) error {
+	actor := models.CurrentUser(c)
+	if !actor.IsAdmin() {
+		err := fmt.Errorf("actor not allowed to perform that action on this resource")
+		return reportError(c, api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden))
+	}
+
+	start, err := time.Parse(domain.DateFormat, c.Param("start"))
+	if err != nil {
+		return reportError(c, api.NewAppError(err, api.ErrorInvalidDate, api.CategoryUser))
+	}
+	end, err := time.Parse(domain.DateFormat, c.Param("end"))
+	if err != nil {
+		return reportError(c, api.NewAppError(err, api.ErrorInvalidDate, api.CategoryUser))
+	}
+
+	groupBy := api.AnalyticsGroupBy(c.Param("group_by"))
+	if groupBy == "" {
+		groupBy = api.AnalyticsGroupByRiskCategory
+	}
+	interval := api.AnalyticsInterval(c.Param("interval"))
+	if interval == "" {
+		interval = api.AnalyticsIntervalMonth
+	}
+
+	analytics, err := models.PortfolioAnalytics(models.Tx(c), start, end, groupBy, interval)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, analytics)
+}
@@ -2,7 +2,9 @@ package actions
 
 import (
 	"net/http"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/silinternational/cover-api/api"
 	"github.com/silinternational/cover-api/domain"
@@ -83,3 +85,75 @@ func (as *ActionSuite) Test_StewardListRecent() {
 		})
 	}
 }
+
+func (as *ActionSuite) Test_StewardPortfolioAnalytics() {
+	f := models.CreateLedgerFixtures(as.DB, models.FixturesConfig{NumberOfPolicies: 1, ItemsPerPolicy: 1})
+	steward := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+	normalUser := f.Users[0]
+
+	today := time.Now().UTC().Format(domain.DateFormat)
+	period := "start=" + today + "&end=" + today
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		query      string
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "not an admin",
+			actor:      normalUser,
+			query:      period,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "missing dates",
+			actor:      steward,
+			query:      "group_by=country",
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorInvalidDate.String()},
+		},
+		{
+			name:       "invalid group_by",
+			actor:      steward,
+			query:      period + "&group_by=color",
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorInvalidAnalyticsParam.String()},
+		},
+		{
+			name:       "defaults",
+			actor:      steward,
+			query:      period,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"group_by":"` + string(api.AnalyticsGroupByRiskCategory),
+				`"interval":"` + string(api.AnalyticsIntervalMonth),
+				`"earned_premium":`,
+				`"written_premium":` + strconv.Itoa(int(-f.LedgerEntries[0].Amount)),
+				`"item_count":1`,
+			},
+		},
+		{
+			name:       "by year",
+			actor:      steward,
+			query:      period + "&group_by=year&interval=year",
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"name":"` + strconv.Itoa(time.Now().UTC().Year()),
+				`"period":"` + strconv.Itoa(time.Now().UTC().Year()),
+			},
+		},
+	}
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON("%s/%s?%s", stewardPath, api.ResourceAnalytics, tt.query).Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package actions
+
+import (
+	"net/http"
+
+	"github.com/gobuffalo/buffalo"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+// swagger:operation GET /strike-rules StrikeRules StrikeRulesList
+// StrikeRulesList
+//
+// list the rules that create or propose strikes when claims are approved
+// ---
+//
+//	responses:
+//	  '200':
+//	    description: list of Strike Rules
+//	    schema:
+//	      type: array
+//	      items:
+//	        "$ref": "#/definitions/StrikeRule"
+func strikeRulesList(c buffalo.Context) error {
+	var rules models.StrikeRules
+	if err := rules.FindAll(models.Tx(c)); err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, rules.ConvertToAPI())
+}
+
+// swagger:operation POST /strike-rules StrikeRules StrikeRulesCreate
+// StrikeRulesCreate
+//
+// create a strike rule
+// ---
+//
+//	parameters:
+//	  - name: strike rule input
+//	    in: body
+//	    description: strike rule input object
+//	    required: true
+//	    schema:
+//	      "$ref": "#/definitions/StrikeRuleInput"
+//	responses:
+//	  '200':
+//	    description: the new Strike Rule
+//	    schema:
+//	      "$ref": "#/definitions/StrikeRule"
+func strikeRulesCreate(c buffalo.Context) error {
+	var input api.StrikeRuleInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	rule, err := models.NewStrikeRule(c, input)
+	if err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, rule.ConvertToAPI())
+}
+
+// swagger:operation PUT /strike-rules/{id} StrikeRules StrikeRulesUpdate
+// StrikeRulesUpdate
+//
+// update a strike rule. Strikes it has already created are not changed.
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: strike rule ID
+//	  - name: strike rule input
+//	    in: body
+//	    description: strike rule input object
+//	    required: true
+//	    schema:
+//	      "$ref": "#/definitions/StrikeRuleInput"
+//	responses:
+//	  '200':
+//	    description: updated Strike Rule
+//	    schema:
+//	      "$ref": "#/definitions/StrikeRule"
+func strikeRulesUpdate(c buffalo.Context) error {
+	rule := getReferencedStrikeRuleFromCtx(c)
+
+	var input api.StrikeRuleInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	if err := rule.UpdateFromInput(c, input); err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, rule.ConvertToAPI())
+}
+
+// swagger:operation DELETE /strike-rules/{id} StrikeRules StrikeRulesDelete
+// StrikeRulesDelete
+//
+// delete a strike rule. Strikes it has already created are kept.
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: strike rule ID
+//	responses:
+//	  '204':
+//	    description: OK but no content in response
+func strikeRulesDelete(c buffalo.Context) error {
+	rule := getReferencedStrikeRuleFromCtx(c)
+	if err := rule.Destroy(models.Tx(c)); err != nil {
+		return reportError(c, err)
+	}
+	return c.Render(http.StatusNoContent, nil)
+}
+
+// getReferencedStrikeRuleFromCtx pulls the models.StrikeRule resource from context that was put there
+// by the AuthZ middleware
+func getReferencedStrikeRuleFromCtx(c buffalo.Context) *models.StrikeRule {
+	rule, ok := c.Value(domain.TypeStrikeRule).(*models.StrikeRule)
+	if !ok {
+		panic("strike rule not found in context")
+	}
+	return rule
+}
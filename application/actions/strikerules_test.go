@@ -0,0 +1,67 @@
+package actions
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (as *ActionSuite) Test_StrikeRulesCreate() {
+	normalUser := models.CreateUserFixtures(as.DB, 1).Users[0]
+	stewardUser := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	input := api.StrikeRuleInput{
+		Name:                "Unattended theft",
+		IncidentType:        api.ClaimIncidentTypeTheft,
+		DescriptionContains: "unattended",
+		IsActive:            true,
+	}
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		input      api.StrikeRuleInput
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "insufficient privileges",
+			actor:      normalUser,
+			input:      input,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "no conditions",
+			actor:      stewardUser,
+			input:      api.StrikeRuleInput{Name: "everything"},
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorStrikeRuleInvalid.String()},
+		},
+		{
+			name:       "good",
+			actor:      stewardUser,
+			input:      input,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"name":"` + input.Name,
+				`"incident_type":"` + string(input.IncidentType),
+				`"description_contains":"unattended"`,
+				`"is_active":true`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON("%s", strikeRulesPath).Post(tt.input)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
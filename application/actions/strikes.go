@@ -10,6 +10,27 @@ import (
 	"github.com/silinternational/cover-api/models"
 )
 
+// swagger:operation GET /strikes Strikes StrikesList
+// StrikesList
+//
+// list the strikes that were proposed by a strike rule and are waiting for review
+// ---
+//
+//	responses:
+//	  '200':
+//	    description: list of proposed Strikes
+//	    schema:
+//	      type: array
+//	      items:
+//	        "$ref": "#/definitions/Strike"
+func strikesList(c buffalo.Context) error {
+	var strikes models.Strikes
+	if err := strikes.FindProposed(models.Tx(c)); err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, strikes.ConvertToAPI(models.Tx(c)))
+}
+
 // swagger:operation PUT /strikes/{id} Strikes StrikesUpdate
 // StrikesUpdate
 //
@@ -74,6 +95,54 @@ func strikesDelete(c buffalo.Context) error {
 	return c.Render(http.StatusNoContent, nil)
 }
 
+// swagger:operation POST /strikes/{id}/approve Strikes StrikesApprove
+// StrikesApprove
+//
+// approve a proposed strike, putting it in force and notifying the policy members
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: strike ID
+//	responses:
+//	  '200':
+//	    description: approved Strike
+//	    schema:
+//	      "$ref": "#/definitions/Strike"
+func strikesApprove(c buffalo.Context) error {
+	strike := getReferencedStrikeFromCtx(c)
+	if err := strike.Approve(c); err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, strike.ConvertToAPI())
+}
+
+// swagger:operation POST /strikes/{id}/reject Strikes StrikesReject
+// StrikesReject
+//
+// reject a proposed strike, so that it is never put in force
+// ---
+//
+//	parameters:
+//	  - name: id
+//	    in: path
+//	    required: true
+//	    description: strike ID
+//	responses:
+//	  '200':
+//	    description: rejected Strike
+//	    schema:
+//	      "$ref": "#/definitions/Strike"
+func strikesReject(c buffalo.Context) error {
+	strike := getReferencedStrikeFromCtx(c)
+	if err := strike.Reject(c); err != nil {
+		return reportError(c, err)
+	}
+	return renderOk(c, strike.ConvertToAPI())
+}
+
 // getReferencedStrikeFromCtx pulls the models.Strike resource from context that was put there
 // by the AuthZ middleware
 func getReferencedStrikeFromCtx(c buffalo.Context) *models.Strike {
@@ -125,3 +125,58 @@ func (as *ActionSuite) Test_StrikesDelete() {
 		})
 	}
 }
+
+func (as *ActionSuite) Test_StrikesApprove() {
+	f := models.CreatePolicyFixtures(as.DB, models.FixturesConfig{})
+	policy := f.Policies[0]
+	normalUser := f.Users[0]
+	stewardUser := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	strike := models.Strike{Description: "proposed", PolicyID: policy.ID, Status: api.StrikeStatusProposed}
+	as.NoError(strike.Create(as.DB))
+
+	as.SetAccessToken(stewardUser)
+	res := as.JSON("%s", strikesPath).Get()
+	as.Equal(http.StatusOK, res.Code, "incorrect status code listing strikes, body: %s", res.Body.String())
+	as.Contains(res.Body.String(), `"id":"`+strike.ID.String())
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "insufficient privileges",
+			actor:      normalUser,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "good",
+			actor:      stewardUser,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"id":"` + strike.ID.String(),
+				`"status":"` + string(api.StrikeStatusActive),
+			},
+		},
+		{
+			name:       "already approved",
+			actor:      stewardUser,
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorStrikeStatus.String()},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			as.SetAccessToken(tt.actor)
+			res := as.JSON("%s/%s/%s", strikesPath, strike.ID.String(), api.ResourceApprove).Post(nil)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
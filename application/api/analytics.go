@@ -0,0 +1,108 @@
+package api
+
+// AnalyticsGroupBy
+//
+// may be one of: risk_category, item_category, country, policy_type, year
+//
+// swagger:model
+type AnalyticsGroupBy string
+
+const (
+	AnalyticsGroupByRiskCategory = AnalyticsGroupBy("risk_category")
+	AnalyticsGroupByItemCategory = AnalyticsGroupBy("item_category")
+	AnalyticsGroupByCountry      = AnalyticsGroupBy("country")
+	AnalyticsGroupByPolicyType   = AnalyticsGroupBy("policy_type")
+	AnalyticsGroupByYear         = AnalyticsGroupBy("year")
+)
+
+// AnalyticsInterval
+//
+// may be one of: month, year
+//
+// swagger:model
+type AnalyticsInterval string
+
+const (
+	AnalyticsIntervalMonth = AnalyticsInterval("month")
+	AnalyticsIntervalYear  = AnalyticsInterval("year")
+)
+
+// PortfolioAnalytics summarizes the premiums and claim payouts in the ledger for a date range
+//
+// swagger:model
+type PortfolioAnalytics struct {
+	// first day of the range
+	//
+	// swagger:strfmt date
+	Start string `json:"start"`
+
+	// last day of the range
+	//
+	// swagger:strfmt date
+	End string `json:"end"`
+
+	GroupBy AnalyticsGroupBy `json:"group_by"`
+
+	Interval AnalyticsInterval `json:"interval"`
+
+	// metrics for the whole range
+	Totals PortfolioMetrics `json:"totals"`
+
+	// metrics for each interval of the range
+	Series []PortfolioSeriesPoint `json:"series"`
+
+	// metrics for each group, sorted by group name
+	Groups []PortfolioGroup `json:"groups"`
+}
+
+// PortfolioGroup holds the metrics of one value of the group_by dimension, e.g. one risk category
+//
+// swagger:model
+type PortfolioGroup struct {
+	// name of the group, e.g. "Mobile"
+	Name string `json:"name"`
+
+	// metrics for the whole range
+	Totals PortfolioMetrics `json:"totals"`
+
+	// metrics for each interval of the range
+	Series []PortfolioSeriesPoint `json:"series"`
+}
+
+// swagger:model
+type PortfolioSeriesPoint struct {
+	// the interval, e.g. "2023-10" for a month or "2023" for a year
+	Period string `json:"period"`
+
+	PortfolioMetrics
+}
+
+// PortfolioMetrics are derived from the ledger entries of a period
+//
+// swagger:model
+type PortfolioMetrics struct {
+	// the part of the premiums, net of refunds, adjustments and discounts, that pays for coverage in the period. Each
+	// premium is earned evenly over the coverage period it pays for.
+	EarnedPremium Currency `json:"earned_premium"`
+
+	// the premiums charged in the period, net of refunds, adjustments and discounts
+	WrittenPremium Currency `json:"written_premium"`
+
+	// claim payouts in the period, net of claim adjustments
+	ClaimsPaid Currency `json:"claims_paid"`
+
+	// claims paid divided by earned premium, or zero if there is no earned premium
+	LossRatio float64 `json:"loss_ratio"`
+
+	// number of items with premium earned in the period
+	ItemCount int `json:"item_count"`
+
+	// number of claims with a payout entry
+	ClaimCount int `json:"claim_count"`
+
+	// number of claims divided by number of items, or zero if there are no items
+	ClaimFrequency float64 `json:"claim_frequency"`
+
+	// claims paid divided by number of claims, or zero if there are no claims
+	AverageSeverity Currency `json:"average_severity"`
+}
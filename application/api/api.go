@@ -14,14 +14,43 @@ import (
 )
 
 const (
-	ResourceSubmit     = "submit"
-	ResourceRevision   = "revision"
-	ResourcePreapprove = "preapprove"
-	ResourceReceipt    = "receipt"
-	ResourceApprove    = "approve"
-	ResourceDeny       = "deny"
-	ResourceRecent     = "recent"
-	ResourceStrikes    = "strikes"
+	ResourceSubmit        = "submit"
+	ResourceRevision      = "revision"
+	ResourcePreapprove    = "preapprove"
+	ResourceReceipt       = "receipt"
+	ResourceApprove       = "approve"
+	ResourceDeny          = "deny"
+	ResourceRecent        = "recent"
+	ResourceStrikes       = "strikes"
+	ResourceCertificate   = "certificate"
+	ResourceClose         = "close"
+	ResourceMerge         = "merge"
+	ResourceSplit         = "split"
+	ResourceMembers       = "members"
+	ResourceInvites       = "invites"
+	ResourceResend        = "resend"
+	ResourceExtend        = "extend"
+	ResourceStatements    = "statements"
+	ResourceApprovers     = "approvers"
+	ResourceApprovals     = "approvals"
+	ResourceBudgetApprove = "budget-approve"
+	ResourceBudgetReject  = "budget-reject"
+	ResourceReject        = "reject"
+	ResourceReverse       = "reverse"
+	ResourcePreview       = "preview"
+	ResourceReport        = "report"
+	ResourceUnreconcile   = "unreconcile"
+	ResourceChargeback    = "chargeback"
+	ResourceAnalytics     = "analytics"
+	ResourceCurrent       = "current"
+	ResourceJournal       = "journal"
+)
+
+// File formats available for exported reports
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatPDF  = "pdf"
+	ExportFormatXLSX = "xlsx"
 )
 
 // swagger:model
@@ -191,6 +220,14 @@ func (c Currency) String() string {
 	return fmt.Sprintf("%0.2f", float32(c)/domain.CurrencyFactor)
 }
 
+// Dollars formats the amount with a dollar sign, placing the minus sign of a negative amount in front of it
+func (c Currency) Dollars() string {
+	if c < 0 {
+		return "-$" + (-c).String()
+	}
+	return "$" + c.String()
+}
+
 // swagger:model
 type RecentObjects struct {
 	Items  RecentItems
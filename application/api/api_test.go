@@ -159,3 +159,32 @@ func (ts *TestSuite) TestCurrency_String() {
 		})
 	}
 }
+
+func (ts *TestSuite) TestCurrency_Dollars() {
+	tests := []struct {
+		name string
+		c    Currency
+		want string
+	}{
+		{
+			name: "0",
+			c:    0,
+			want: "$0.00",
+		},
+		{
+			name: "10536",
+			c:    10536,
+			want: "$105.36",
+		},
+		{
+			name: "-10",
+			c:    -10,
+			want: "-$0.10",
+		},
+	}
+	for _, tt := range tests {
+		ts.T().Run(tt.name, func(t *testing.T) {
+			ts.Equal(tt.want, tt.c.Dollars())
+		})
+	}
+}
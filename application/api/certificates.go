@@ -0,0 +1,91 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// Certificate is a proof-of-coverage document for a single item or for all of the covered items on a policy
+// swagger:model
+type Certificate struct {
+	// unique ID
+	//
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	// policy ID
+	//
+	// swagger:strfmt uuid4
+	PolicyID uuid.UUID `json:"policy_id"`
+
+	// item ID, null if the certificate covers the whole policy
+	//
+	// swagger:strfmt uuid4
+	ItemID *uuid.UUID `json:"item_id"`
+
+	// code printed on the certificate that can be checked using the public verification endpoint
+	VerificationCode string `json:"verification_code"`
+
+	// total coverage amount shown on the certificate
+	CoverageAmount Currency `json:"coverage_amount"`
+
+	// the PDF certificate
+	File File `json:"file"`
+
+	// The time the certificate was issued
+	//
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CertificateVerification is the public view of a certificate, used to confirm its authenticity
+// swagger:model
+type CertificateVerification struct {
+	// code printed on the certificate
+	VerificationCode string `json:"verification_code"`
+
+	// true if all the items on the certificate are still covered
+	IsCurrent bool `json:"is_current"`
+
+	// policy name
+	PolicyName string `json:"policy_name"`
+
+	// total coverage amount shown on the certificate
+	CoverageAmount Currency `json:"coverage_amount"`
+
+	// The time the certificate was issued
+	//
+	// swagger:strfmt date-time
+	IssuedAt time.Time `json:"issued_at"`
+
+	// items listed on the certificate
+	Items []CertificateItem `json:"items"`
+}
+
+// swagger:model
+type CertificateItem struct {
+	// item name
+	Name string `json:"name"`
+
+	// item make and model
+	MakeModel string `json:"make_model"`
+
+	// item serial number
+	SerialNumber string `json:"serial_number"`
+
+	// current coverage amount
+	CoverageAmount Currency `json:"coverage_amount"`
+
+	// current coverage status
+	CoverageStatus ItemCoverageStatus `json:"coverage_status"`
+
+	// date coverage started, format: YYYY-MM-DD
+	CoverageStartDate string `json:"coverage_start_date"`
+
+	// date coverage ends, format: YYYY-MM-DD, null if coverage is ongoing
+	CoverageEndDate *string `json:"coverage_end_date"`
+
+	// date through which the premium has been paid, format: YYYY-MM-DD
+	PaidThroughDate string `json:"paid_through_date"`
+}
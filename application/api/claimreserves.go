@@ -0,0 +1,102 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// ClaimReserves estimates the outstanding liability of the claims that are under review or approved but not yet
+// paid
+//
+// swagger:model
+type ClaimReserves struct {
+	// date of the estimate
+	//
+	// swagger:strfmt date
+	AsOf string `json:"as_of"`
+
+	// number of open claims
+	ClaimCount int `json:"claim_count"`
+
+	// total estimated liability of the open claims
+	TotalReserve Currency `json:"total_reserve"`
+
+	// estimated liability of each claim item, oldest claim first
+	Lines []ClaimReserve `json:"lines"`
+
+	// totals by risk category of the claimed item
+	RiskCategories []ClaimReserveTotal `json:"risk_categories"`
+
+	// totals by time since the claim was submitted
+	Ages []ClaimReserveTotal `json:"ages"`
+}
+
+// ClaimReserve is the estimated liability of one item of an open claim
+//
+// swagger:model
+type ClaimReserve struct {
+	// swagger:strfmt uuid4
+	ClaimID uuid.UUID `json:"claim_id"`
+
+	// swagger:strfmt uuid4
+	ClaimItemID uuid.UUID `json:"claim_item_id"`
+
+	ReferenceNumber string `json:"reference_number"`
+
+	Status ClaimStatus `json:"status"`
+
+	ItemName string `json:"item_name"`
+
+	RiskCategory string `json:"risk_category"`
+
+	// date the claim was submitted for review
+	//
+	// swagger:strfmt date
+	SubmittedDate string `json:"submitted_date"`
+
+	// number of days since the claim was submitted
+	AgeDays int `json:"age_days"`
+
+	// age group, e.g. "31-60 days"
+	Age string `json:"age"`
+
+	// coverage amount of the item
+	CoverageAmount Currency `json:"coverage_amount"`
+
+	// payout of an approved claim, otherwise the payout estimated from the repair or replacement estimate, or from
+	// the coverage amount if there is no estimate, less the deductible
+	Reserve Currency `json:"reserve"`
+}
+
+// swagger:model
+type ClaimReserveTotal struct {
+	Name       string   `json:"name"`
+	ClaimCount int      `json:"claim_count"`
+	Reserve    Currency `json:"reserve"`
+}
+
+// swagger:model
+type ClaimReserveReports []ClaimReserveReport
+
+// ClaimReserveReport is the month-end snapshot of the claim reserves, with its CSV file
+//
+// swagger:model
+type ClaimReserveReport struct {
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	// last day of the month
+	//
+	// swagger:strfmt date
+	ReportDate string `json:"report_date"`
+
+	ClaimCount int `json:"claim_count"`
+
+	TotalReserve Currency `json:"total_reserve"`
+
+	File File `json:"file"`
+
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// swagger:model
+type CoverageLimits []CoverageLimit
+
+// CoverageLimit is an override of the maximum total coverage of a policy, or of all the policies with an entity code
+//
+// swagger:model
+type CoverageLimit struct {
+	// unique ID
+	//
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	// ID of the policy the limit applies to, null if the limit applies to an entity code
+	//
+	// swagger:strfmt uuid4
+	PolicyID *uuid.UUID `json:"policy_id"`
+
+	// ID of the entity code the limit applies to, null if the limit applies to a policy
+	//
+	// swagger:strfmt uuid4
+	EntityCodeID *uuid.UUID `json:"entity_code_id"`
+
+	// maximum total coverage, in cents
+	MaxCoverage Currency `json:"max_coverage"`
+
+	// date (yyyy-mm-dd) of the last day on which the limit applies
+	ExpirationDate string `json:"expiration_date"`
+
+	// reason for the override
+	Reason string `json:"reason"`
+
+	// true if the limit is in force, i.e. it does not need approval or has been approved
+	IsApproved bool `json:"is_approved"`
+
+	// swagger:strfmt uuid4
+	CreatedByID uuid.UUID `json:"created_by_id"`
+
+	// ID of the Signator who approved the limit
+	//
+	// swagger:strfmt uuid4
+	ApprovedByID *uuid.UUID `json:"approved_by_id"`
+
+	// The time the limit was approved
+	//
+	// swagger:strfmt date-time
+	ApprovedAt *time.Time `json:"approved_at"`
+
+	// The time the limit was created
+	//
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CoverageLimitInput represents payload for creating a coverage limit override. Exactly one of PolicyID and
+// EntityCodeID must be given.
+//
+// swagger:model
+type CoverageLimitInput struct {
+	// ID of the policy the limit applies to
+	//
+	// swagger:strfmt uuid4
+	PolicyID *uuid.UUID `json:"policy_id"`
+
+	// ID of the entity code the limit applies to
+	//
+	// swagger:strfmt uuid4
+	EntityCodeID *uuid.UUID `json:"entity_code_id"`
+
+	// maximum total coverage, in cents. Limits above the approval threshold must be approved by a Signator.
+	MaxCoverage Currency `json:"max_coverage"`
+
+	// date (yyyy-mm-dd) of the last day on which the limit applies
+	ExpirationDate string `json:"expiration_date"`
+
+	// reason for the override
+	Reason string `json:"reason"`
+}
@@ -17,6 +17,7 @@ const (
 	ErrorFailedToSubmitJob        = ErrorKey("ErrorFailedToSubmitJob")
 	ErrorFailedToConvertToAPIType = ErrorKey("ErrorFailedToConvertToAPIType")
 	ErrorForeignKeyViolation      = ErrorKey("ErrorForeignKeyViolation")
+	ErrorInvalidExportFormat      = ErrorKey("ErrorInvalidExportFormat")
 	ErrorInvalidRequestBody       = ErrorKey("ErrorInvalidRequestBody")
 	ErrorMissingSessionKey        = ErrorKey("ErrorMissingSessionKey")
 	ErrorMustBeAValidUUID         = ErrorKey("ErrorMustBeAValidUUID")
@@ -41,6 +42,9 @@ const (
 	ErrorConflict              = ErrorKey("ErrorConflict")
 	ErrorUnprocessableEntity   = ErrorKey("ErrorUnprocessableEntity")
 
+	// Analytics
+	ErrorInvalidAnalyticsParam = ErrorKey("ErrorInvalidAnalyticsParam")
+
 	// Audit
 
 	ErrorUnrecognizedAuditType = ErrorKey("ErrorUnrecognizedAuditType")
@@ -72,11 +76,19 @@ const (
 	ErrorUnableToReadFile        = ErrorKey("ErrorUnableToReadFile")
 	ErrorUnableToStoreFile       = ErrorKey("ErrorUnableToStoreFile")
 
+	// Certificate
+	ErrorCertificateNoCoverage = ErrorKey("ErrorCertificateNoCoverage")
+	ErrorCertificateNotFound   = ErrorKey("ErrorCertificateNotFound")
+
 	// Claim
 	ErrorClaimFromContext      = ErrorKey("ErrorClaimFromContext")
 	ErrorClaimStatus           = ErrorKey("ErrorClaimStatus")
 	ErrorClaimMissingClaimItem = ErrorKey("ErrorClaimMissingClaimItem")
 
+	// CoverageLimit
+	ErrorCoverageLimitInvalid  = ErrorKey("ErrorCoverageLimitInvalid")
+	ErrorCoverageLimitApproval = ErrorKey("ErrorCoverageLimitApproval")
+
 	// Item
 	ErrorItemFromContext                  = ErrorKey("ErrorItemFromContext")
 	ErrorItemNullAccountablePerson        = ErrorKey("ErrorItemNullAccountablePerson")
@@ -85,12 +97,28 @@ const (
 	ErrorInvalidCategory                  = ErrorKey("ErrorInvalidCategory")
 	ErrorItemHasActiveClaim               = ErrorKey("ErrorItemHasActiveClaim")
 
+	// Job
+	ErrorJobRunning = ErrorKey("ErrorJobRunning")
+
 	// Ledger
-	ErrorCreateRenewalEntry = ErrorKey("ErrorCreateRenewalEntry")
-	ErrorInvalidDate        = ErrorKey("ErrorInvalidDate")
-	ErrorInvalidReportType  = ErrorKey("ErrorInvalidReportType")
-	ErrorNoLedgerEntries    = ErrorKey("ErrorNoLedgerEntries")
-	ErrorReconcileError     = ErrorKey("ErrorReconcileError")
+	ErrorCreateRenewalEntry       = ErrorKey("ErrorCreateRenewalEntry")
+	ErrorFiscalPeriodClose        = ErrorKey("ErrorFiscalPeriodClose")
+	ErrorFiscalPeriodClosed       = ErrorKey("ErrorFiscalPeriodClosed")
+	ErrorInvalidDate              = ErrorKey("ErrorInvalidDate")
+	ErrorInvalidReportFormat      = ErrorKey("ErrorInvalidReportFormat")
+	ErrorInvalidReportType        = ErrorKey("ErrorInvalidReportType")
+	ErrorLedgerAdjustmentInvalid  = ErrorKey("ErrorLedgerAdjustmentInvalid")
+	ErrorLedgerAdjustmentReviewer = ErrorKey("ErrorLedgerAdjustmentReviewer")
+	ErrorLedgerAdjustmentStatus   = ErrorKey("ErrorLedgerAdjustmentStatus")
+	ErrorLedgerEntryCorrection    = ErrorKey("ErrorLedgerEntryCorrection")
+	ErrorLedgerEntryNotReversible = ErrorKey("ErrorLedgerEntryNotReversible")
+	ErrorLedgerJournalInvalid     = ErrorKey("ErrorLedgerJournalInvalid")
+	ErrorLedgerReportPeriodClosed = ErrorKey("ErrorLedgerReportPeriodClosed")
+	ErrorLedgerReportUnbalanced   = ErrorKey("ErrorLedgerReportUnbalanced")
+	ErrorLedgerReportUnreconcile  = ErrorKey("ErrorLedgerReportUnreconcile")
+	ErrorLedgerReportVoided       = ErrorKey("ErrorLedgerReportVoided")
+	ErrorNoLedgerEntries          = ErrorKey("ErrorNoLedgerEntries")
+	ErrorReconcileError           = ErrorKey("ErrorReconcileError")
 
 	// Policy
 	ErrorPolicyFromContext                    = ErrorKey("ErrorPolicyFromContext")
@@ -102,13 +130,25 @@ const (
 	ErrorPolicyUserInviteCode                 = ErrorKey("ErrorPolicyUserInviteCode")
 	ErrorPolicyUserInviteDifferentHouseholdID = ErrorKey("ErrorPolicyUserInviteDifferentHouseholdID")
 	ErrorPolicyUserIsTheLast                  = ErrorKey("ErrorPolicyUserIsTheLast")
+	ErrorPolicyUserIsTheLastOwner             = ErrorKey("ErrorPolicyUserIsTheLastOwner")
+	ErrorPolicyUserRole                       = ErrorKey("ErrorPolicyUserRole")
 	ErrorPolicyHasNoHouseholdID               = ErrorKey("ErrorPolicyHasNoHouseholdID")
+	ErrorPolicyClosed                         = ErrorKey("ErrorPolicyClosed")
+	ErrorPolicyHasOpenClaims                  = ErrorKey("ErrorPolicyHasOpenClaims")
+	ErrorPolicyMergeInvalid                   = ErrorKey("ErrorPolicyMergeInvalid")
+	ErrorPolicySplitInvalid                   = ErrorKey("ErrorPolicySplitInvalid")
+	ErrorPolicyApproverTeamOnly               = ErrorKey("ErrorPolicyApproverTeamOnly")
+	ErrorPolicyApproverExists                 = ErrorKey("ErrorPolicyApproverExists")
 
 	// PolicyDependent
 	ErrorPolicyDependentCreate        = ErrorKey("ErrorPolicyDependentCreate")
 	ErrorPolicyDependentDelete        = ErrorKey("ErrorPolicyDependentDelete")
 	ErrorPolicyDependentDuplicateName = ErrorKey("ErrorPolicyDependentDuplicateName")
 
+	// Strike
+	ErrorStrikeStatus      = ErrorKey("ErrorStrikeStatus")
+	ErrorStrikeRuleInvalid = ErrorKey("ErrorStrikeRuleInvalid")
+
 	// ClaimItem
 	ErrorClaimItemCreateInvalidInput     = ErrorKey("ErrorClaimItemCreateInvalidInput")
 	ErrorClaimItemNotRepairable          = ErrorKey("ClaimItemNotRepairable")
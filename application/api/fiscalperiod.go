@@ -0,0 +1,115 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// FiscalPeriodStatus
+//
+// may be one of: Open, Closed
+//
+// swagger:model
+type FiscalPeriodStatus string
+
+const (
+	FiscalPeriodStatusOpen   = FiscalPeriodStatus("Open")
+	FiscalPeriodStatusClosed = FiscalPeriodStatus("Closed")
+)
+
+// swagger:model
+type FiscalPeriods []FiscalPeriod
+
+// FiscalPeriod is one month of the fiscal year. Ledger entries cannot be added to or reconciled in a closed period.
+//
+// swagger:model
+type FiscalPeriod struct {
+	// unique ID
+	//
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	// fiscal year
+	Year int `json:"year"`
+
+	// fiscal period, 1 to 12
+	Period int `json:"period"`
+
+	// first day of the period
+	//
+	// swagger:strfmt date
+	StartDate string `json:"start_date"`
+
+	// last day of the period
+	//
+	// swagger:strfmt date
+	EndDate string `json:"end_date"`
+
+	Status FiscalPeriodStatus `json:"status"`
+
+	// swagger:strfmt date-time
+	ClosedAt *time.Time `json:"closed_at"`
+
+	// swagger:strfmt uuid4
+	ClosedByID *uuid.UUID `json:"closed_by_id"`
+
+	// note given when the period was closed
+	CloseNote string `json:"close_note"`
+
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+
+	// swagger:strfmt date-time
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// swagger:model
+type FiscalPeriodCloseInput struct {
+	// any date in the period to close, e.g. "2023-10-31"
+	//
+	// swagger:strfmt date
+	Date string `json:"date"`
+
+	// note for the closing, e.g. "October journal posted"
+	Note string `json:"note"`
+}
+
+// FiscalPeriodReport summarizes the ledger entries submitted in a fiscal period
+//
+// swagger:model
+type FiscalPeriodReport struct {
+	FiscalPeriod FiscalPeriod `json:"fiscal_period"`
+
+	// number of entries submitted in the period
+	EntryCount int `json:"entry_count"`
+
+	// number of entries submitted in the period that have been reconciled
+	EnteredCount int `json:"entered_count"`
+
+	// number of entries submitted in the period that have not been reconciled. These are reconciled in a later
+	// period.
+	NotEnteredCount int `json:"not_entered_count"`
+
+	// number of entries with a submission or reconciliation date that was moved out of a closed period
+	RedirectedCount int `json:"redirected_count"`
+
+	// total of the charges, as a negative amount
+	Charges Currency `json:"charges"`
+
+	// total of the reimbursements and reductions
+	Credits Currency `json:"credits"`
+
+	// total of all entries
+	Net Currency `json:"net"`
+
+	// totals by ledger entry type
+	Types []FiscalPeriodTypeTotal `json:"types"`
+}
+
+// swagger:model
+type FiscalPeriodTypeTotal struct {
+	Type   LedgerEntryType `json:"type"`
+	Count  int             `json:"count"`
+	Amount Currency        `json:"amount"`
+}
@@ -8,18 +8,19 @@ import (
 
 // ItemCoverageStatus
 //
-// may be one of: Draft, Pending, Revision, Approved, Denied, Inactive
+// may be one of: Draft, PendingBudget, Pending, Revision, Approved, Denied, Inactive
 //
 // swagger:model
 type ItemCoverageStatus string
 
 const (
-	ItemCoverageStatusDraft    = ItemCoverageStatus("Draft")
-	ItemCoverageStatusPending  = ItemCoverageStatus("Pending")
-	ItemCoverageStatusRevision = ItemCoverageStatus("Revision")
-	ItemCoverageStatusApproved = ItemCoverageStatus("Approved")
-	ItemCoverageStatusDenied   = ItemCoverageStatus("Denied")
-	ItemCoverageStatusInactive = ItemCoverageStatus("Inactive")
+	ItemCoverageStatusDraft         = ItemCoverageStatus("Draft")
+	ItemCoverageStatusPendingBudget = ItemCoverageStatus("PendingBudget")
+	ItemCoverageStatusPending       = ItemCoverageStatus("Pending")
+	ItemCoverageStatusRevision      = ItemCoverageStatus("Revision")
+	ItemCoverageStatusApproved      = ItemCoverageStatus("Approved")
+	ItemCoverageStatusDenied        = ItemCoverageStatus("Denied")
+	ItemCoverageStatusInactive      = ItemCoverageStatus("Inactive")
 )
 
 // swagger:model
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// JobRunTrigger
+//
+// may be one of: Schedule, Manual, Submit
+//
+// swagger:model
+type JobRunTrigger string
+
+const (
+	// JobRunTriggerSchedule is a run started by the scheduler
+	JobRunTriggerSchedule = JobRunTrigger("Schedule")
+
+	// JobRunTriggerManual is a run requested by an admin
+	JobRunTriggerManual = JobRunTrigger("Manual")
+
+	// JobRunTriggerSubmit is a run submitted by the application, e.g. a renewal requested through the ledger API
+	JobRunTriggerSubmit = JobRunTrigger("Submit")
+)
+
+// JobRunResult
+//
+// may be one of: Queued, Running, Success, Failure
+//
+// swagger:model
+type JobRunResult string
+
+const (
+	JobRunResultQueued  = JobRunResult("Queued")
+	JobRunResultRunning = JobRunResult("Running")
+	JobRunResultSuccess = JobRunResult("Success")
+	JobRunResultFailure = JobRunResult("Failure")
+)
+
+// swagger:model
+type JobSchedules []JobSchedule
+
+// JobSchedule is the schedule of a background job
+//
+// swagger:model
+type JobSchedule struct {
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	JobType string `json:"job_type"`
+
+	// standard cron expression with five fields (minute, hour, day of month, month, day of week), in UTC
+	Schedule string `json:"schedule"`
+
+	// if false, the job is not run by the scheduler, but it can be run manually
+	Enabled bool `json:"enabled"`
+
+	// swagger:strfmt date-time
+	LastRunAt *time.Time `json:"last_run_at"`
+
+	// swagger:strfmt date-time
+	NextRunAt *time.Time `json:"next_run_at"`
+
+	// true if an instance of the app is running the job
+	IsRunning bool `json:"is_running"`
+
+	// the instance of the app that is running the job, if any
+	RunningOn string `json:"running_on"`
+}
+
+// swagger:model
+type JobRuns []JobRun
+
+// JobRun is the record of one run of a background job
+//
+// swagger:model
+type JobRun struct {
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	JobType string `json:"job_type"`
+
+	Trigger JobRunTrigger `json:"trigger"`
+
+	// the admin who requested a manual run
+	//
+	// swagger:strfmt uuid4
+	TriggeredByID *uuid.UUID `json:"triggered_by_id"`
+
+	// the instance of the app that ran the job
+	Instance string `json:"instance"`
+
+	// swagger:strfmt date-time
+	StartedAt *time.Time `json:"started_at"`
+
+	// swagger:strfmt date-time
+	FinishedAt *time.Time `json:"finished_at"`
+
+	DurationMS int `json:"duration_ms"`
+
+	Result JobRunResult `json:"result"`
+
+	// error message of a failed run
+	Error string `json:"error"`
+
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
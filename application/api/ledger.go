@@ -20,8 +20,27 @@ type LedgerReport struct {
 	Date             time.Time `json:"date"`
 	TransactionCount int       `json:"transaction_count"`
 	IsCleared        bool      `json:"is_cleared"`
-	CreatedAt        time.Time `json:"created_at"`
-	UpdatedAt        time.Time `json:"updated_at"`
+
+	// false if some block of the rendered report does not balance, leaves out entries, or has entries that are
+	// missing an account. Such a report cannot be reconciled.
+	IsBalanced bool `json:"is_balanced"`
+
+	// describes the blocks that do not balance and the entries that cause it
+	Imbalance string `json:"imbalance"`
+
+	// date the reconciliation of the report was undone. A voided report cannot be reconciled.
+	//
+	// swagger:strfmt date-time
+	VoidedAt *time.Time `json:"voided_at"`
+
+	// swagger:strfmt uuid4
+	VoidedByID *uuid.UUID `json:"voided_by_id"`
+
+	// reason given for undoing the reconciliation
+	VoidReason string `json:"void_reason"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // swagger:model
@@ -34,6 +53,46 @@ type LedgerReportCreateInput struct {
 
 	// Report date, e.g. return the ledger entries prior to the given date. Details vary by the report type.
 	Date string `json:"date"`
+
+	// Report file format. If not given, a Sage CSV file is created, along with a NetSuite CSV file in a second report.
+	// + `sage` - Sage Accounting CSV
+	// + `netsuite` - NetSuite CSV
+	// + `quickbooks` - QuickBooks IIF general journal
+	// + `json` - JSON journal, with the transactions in balanced blocks
+	//
+	// Append `-xlsx` to any format, e.g. `sage-xlsx`, for an Excel workbook with the same accounts and references,
+	// typed amount and date cells, and a summary sheet of the totals of each block.
+	Format string `json:"format"`
+}
+
+// swagger:model
+type LedgerReportPreview struct {
+	Type   string    `json:"type"`
+	Format string    `json:"format"`
+	Date   time.Time `json:"date"`
+
+	// name the file would have if the report were created
+	FileName string `json:"file_name"`
+
+	ContentType string `json:"content_type"`
+
+	// the rendered report, base64-encoded if it is an XLSX workbook
+	Content string `json:"content"`
+
+	TransactionCount int `json:"transaction_count"`
+
+	// false if some block of the rendered report does not balance, leaves out entries, or has entries that are
+	// missing an account
+	IsBalanced bool `json:"is_balanced"`
+
+	// describes the blocks that do not balance and the entries that cause it
+	Imbalance string `json:"imbalance"`
+}
+
+// swagger:model
+type LedgerReportUnreconcileInput struct {
+	// reason for undoing the reconciliation, e.g. "import into the accounting system failed"
+	Reason string `json:"reason"`
 }
 
 // swagger:model
@@ -113,10 +172,45 @@ type LedgerEntry struct {
 	// swagger:strfmt date-time
 	DateEntered *time.Time `json:"date_entered"`
 
+	// the entry that this entry reverses
+	//
+	// swagger:strfmt uuid4
+	ReversalOfID *uuid.UUID `json:"reversal_of_id"`
+
+	// the entry that this entry replaces
+	//
+	// swagger:strfmt uuid4
+	ReplacementOfID *uuid.UUID `json:"replacement_of_id"`
+
+	// reason for the reversal or replacement
+	CorrectionReason string `json:"correction_reason"`
+
+	// explains a submission or reconciliation date that was moved out of a closed fiscal period
+	PeriodNote string `json:"period_note"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// swagger:model
+type LedgerEntryReverseInput struct {
+	// reason for the reversal, e.g. "duplicate charge"
+	Reason string `json:"reason"`
+
+	// if given, a replacement entry with this amount is added after the reversal. Reimbursements/reductions are
+	// positive and charges are negative.
+	ReplacementAmount *Currency `json:"replacement_amount"`
+}
+
+// swagger:model
+type LedgerEntryCorrection struct {
+	// the entry that reverses the original entry
+	Reversal LedgerEntry `json:"reversal"`
+
+	// the entry that replaces the original entry, if a replacement amount was given
+	Replacement *LedgerEntry `json:"replacement"`
+}
+
 // swagger:model
 type RenewalStatus struct {
 	// is the process job complete? (i.e. is the process not running?)
@@ -128,3 +222,145 @@ type RenewalStatus struct {
 	// is it safe to allow the user to initiate a process job?
 	SafeToProcess bool `json:"safe_to_process"`
 }
+
+// RenewalAnomaly
+//
+// may be one of: ZeroPremium, PaidThroughLapsed, CoverageEnded
+//
+// swagger:model
+type RenewalAnomaly string
+
+const (
+	// the item would be renewed with no premium
+	RenewalAnomalyZeroPremium = RenewalAnomaly("ZeroPremium")
+
+	// the item was not paid through the end of the previous billing period, so a renewal was missed
+	RenewalAnomalyPaidThroughLapsed = RenewalAnomaly("PaidThroughLapsed")
+
+	// the coverage of the item ended before the billing period
+	RenewalAnomalyCoverageEnded = RenewalAnomaly("CoverageEnded")
+)
+
+// RenewalPreview lists the ledger entries that a renewal process would create, without creating them
+//
+// swagger:model
+type RenewalPreview struct {
+	// first day of the billing period to be renewed
+	//
+	// swagger:strfmt date
+	PeriodStart string `json:"period_start"`
+
+	// date the renewed items would be paid through
+	//
+	// swagger:strfmt date
+	PaidThroughDate string `json:"paid_through_date"`
+
+	PolicyCount int `json:"policy_count"`
+
+	ItemCount int `json:"item_count"`
+
+	EntryCount int `json:"entry_count"`
+
+	// total of the renewal premiums, as a positive amount
+	TotalPremium Currency `json:"total_premium"`
+
+	// total of the no-claims discounts
+	TotalDiscount Currency `json:"total_discount"`
+
+	// total premium less total discount
+	Net Currency `json:"net"`
+
+	// the entries that would be created, by policy and risk category
+	Entries []RenewalPreviewEntry `json:"entries"`
+
+	// items that may need attention before the renewal is processed
+	Anomalies []RenewalPreviewItem `json:"anomalies"`
+}
+
+// swagger:model
+type RenewalPreviewEntry struct {
+	// swagger:strfmt uuid4
+	PolicyID uuid.UUID `json:"policy_id"`
+
+	PolicyName string `json:"policy_name"`
+
+	Type LedgerEntryType `json:"type"`
+
+	RiskCategory string `json:"risk_category"`
+
+	// charges are negative and credits are positive, as they would be recorded in the ledger
+	Amount Currency `json:"amount"`
+
+	// the items included in a renewal entry
+	Items []RenewalPreviewItem `json:"items"`
+}
+
+// swagger:model
+type RenewalPreviewItem struct {
+	// swagger:strfmt uuid4
+	PolicyID uuid.UUID `json:"policy_id"`
+
+	// swagger:strfmt uuid4
+	ItemID uuid.UUID `json:"item_id"`
+
+	ItemName string `json:"item_name"`
+
+	RiskCategory string `json:"risk_category"`
+
+	// current paid-through date of the item
+	//
+	// swagger:strfmt date
+	PaidThroughDate string `json:"paid_through_date"`
+
+	// billing premium of the item, as a positive amount
+	Premium Currency `json:"premium"`
+
+	Anomalies []RenewalAnomaly `json:"anomalies"`
+}
+
+// LedgerJournalReconciliation is the result of matching the lines of a journal exported from the accounting system
+// to the entries of a ledger report. Only the matched entries are reconciled.
+//
+// swagger:model
+type LedgerJournalReconciliation struct {
+	// number of lines read from the journal, not including the header
+	LinesRead int `json:"lines_read"`
+
+	// number of lines without a reference or an amount, such as balancing lines
+	LinesIgnored int `json:"lines_ignored"`
+
+	// number of lines that match an entry of the report by reference and amount
+	Matched int `json:"matched"`
+
+	// number of matched entries that were newly marked as reconciled
+	Reconciled int `json:"reconciled"`
+
+	// lines with a reference that does not match any entry of the report
+	Unmatched []JournalLine `json:"unmatched"`
+
+	// lines with the reference of an entry of the report, but not its amount
+	Mismatched []JournalLine `json:"mismatched"`
+
+	// entries of the report that are not reconciled and were not found in the journal
+	Missing LedgerEntries `json:"missing"`
+
+	Report LedgerReport `json:"report"`
+}
+
+// JournalLine is a line of a journal exported from the accounting system
+//
+// swagger:model
+type JournalLine struct {
+	// line number in the journal file
+	Line int `json:"line"`
+
+	Reference string `json:"reference"`
+
+	Description string `json:"description"`
+
+	// debits are positive and credits are negative, as in the exported ledger reports
+	Amount Currency `json:"amount"`
+
+	// amounts of the unmatched report entries with the same reference, for a mismatched line
+	ExpectedAmounts []Currency `json:"expected_amounts"`
+}
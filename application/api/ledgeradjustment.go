@@ -0,0 +1,106 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// LedgerAdjustmentStatus
+//
+// may be one of: Proposed, Approved, Rejected
+//
+// swagger:model
+type LedgerAdjustmentStatus string
+
+const (
+	LedgerAdjustmentStatusProposed = LedgerAdjustmentStatus("Proposed")
+	LedgerAdjustmentStatusApproved = LedgerAdjustmentStatus("Approved")
+	LedgerAdjustmentStatusRejected = LedgerAdjustmentStatus("Rejected")
+)
+
+// swagger:model
+type LedgerAdjustments []LedgerAdjustment
+
+// LedgerAdjustment is a manual correction to the ledger. It is proposed by a steward and enters the ledger only
+// after a signator other than the proposer approves it.
+//
+// swagger:model
+type LedgerAdjustment struct {
+	// unique ID
+	//
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	// swagger:strfmt uuid4
+	PolicyID uuid.UUID `json:"policy_id"`
+
+	// swagger:strfmt uuid4
+	ItemID *uuid.UUID `json:"item_id"`
+
+	// ledger entry type
+	Type LedgerEntryType `json:"type"`
+
+	// reimbursements/reductions are positive and charges are negative
+	Amount Currency `json:"amount"`
+
+	// reason for the adjustment
+	Reason string `json:"reason"`
+
+	Status LedgerAdjustmentStatus `json:"status"`
+
+	// swagger:strfmt uuid4
+	ProposedByID uuid.UUID `json:"proposed_by_id"`
+
+	// name of the user who proposed the adjustment
+	ProposedByName string `json:"proposed_by_name"`
+
+	// swagger:strfmt uuid4
+	ReviewedByID *uuid.UUID `json:"reviewed_by_id"`
+
+	// name of the user who approved or rejected the adjustment
+	ReviewedByName string `json:"reviewed_by_name"`
+
+	// swagger:strfmt date-time
+	ReviewedAt *time.Time `json:"reviewed_at"`
+
+	// reason given by the reviewer for rejecting the adjustment
+	ReviewNote string `json:"review_note"`
+
+	// ledger entry created when the adjustment was approved
+	//
+	// swagger:strfmt uuid4
+	LedgerEntryID *uuid.UUID `json:"ledger_entry_id"`
+
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+
+	// swagger:strfmt date-time
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// swagger:model
+type LedgerAdjustmentInput struct {
+	// swagger:strfmt uuid4
+	PolicyID uuid.UUID `json:"policy_id"`
+
+	// optional item of the policy
+	//
+	// swagger:strfmt uuid4
+	ItemID *uuid.UUID `json:"item_id"`
+
+	// ledger entry type, one of: NewCoverage, CoverageChange, CoverageRefund, PolicyAdjustment
+	Type LedgerEntryType `json:"type"`
+
+	// reimbursements/reductions are positive and charges are negative
+	Amount Currency `json:"amount"`
+
+	// reason for the adjustment, e.g. "mis-billed premium" or "goodwill credit"
+	Reason string `json:"reason"`
+}
+
+// swagger:model
+type LedgerAdjustmentRejectInput struct {
+	// reason for rejecting the adjustment
+	Note string `json:"note"`
+}
@@ -42,6 +42,9 @@ type Policy struct {
 	// swagger:strfmt string
 	HouseholdID nulls.String `json:"household_id"`
 
+	// date (yyyy-mm-dd) the household ID was found not to match any member's staff record, null if it is valid
+	HouseholdIDInvalidDate *string `json:"household_id_invalid_date"`
+
 	// Cost center for billing
 	CostCenter string `json:"cost_center"`
 
@@ -54,6 +57,24 @@ type Policy struct {
 	// Entity code for billing
 	EntityCode EntityCode `json:"entity_code"`
 
+	// date (yyyy-mm-dd) the policy was closed, null if it is still open
+	ClosedDate *string `json:"closed_date"`
+
+	// The reason given for closing the policy
+	ClosedReason string `json:"closed_reason"`
+
+	// maximum total coverage of the policy's items, in cents. Null for Team policies, which have no limit.
+	MaxCoverage *Currency `json:"max_coverage"`
+
+	// how much more coverage can be added to the policy, in cents. Null for Team policies.
+	RemainingCoverage *Currency `json:"remaining_coverage"`
+
+	// number of full years since the policy was created or since its most recent approved claim
+	ClaimFreeYears int `json:"claim_free_years"`
+
+	// no-claims discount rate applied to renewal premiums, e.g. 0.05 for 5%
+	NoClaimsDiscount float64 `json:"no_claims_discount"`
+
 	// The time the policy was created
 	//
 	// swagger:strfmt date-time
@@ -124,6 +145,50 @@ type PolicyUpdate struct {
 	EntityCode string `json:"entity_code,omitempty"`
 }
 
+// PolicyCloseInput represents payload for closing a policy
+// swagger:model
+type PolicyCloseInput struct {
+	// date (yyyy-mm-dd) on which coverage of all the policy's items should end. Must be no earlier than
+	// today and no later than the end of the current year. Defaults to today.
+	Date string `json:"date"`
+
+	// reason for closing the policy, e.g. the member is leaving the organization
+	Reason string `json:"reason"`
+}
+
+// PolicyMergeInput represents payload for merging another policy into a policy
+// swagger:model
+type PolicyMergeInput struct {
+	// ID of the policy to be merged. Its members, dependents, items, claims, invites and strikes are moved and it
+	// is then closed.
+	//
+	// swagger:strfmt uuid4
+	PolicyID uuid.UUID `json:"policy_id"`
+
+	// Household ID of the merged policy, which must be the Household ID of one of the two policies. Defaults to the
+	// Household ID of the policy that remains, or that of the other policy if it has none.
+	HouseholdID string `json:"household_id"`
+}
+
+// PolicySplitInput represents payload for splitting a policy
+// swagger:model
+type PolicySplitInput struct {
+	// name of the new policy, defaults to the last name of the first member moved
+	Name string `json:"name"`
+
+	// Household ID of the new policy
+	HouseholdID string `json:"household_id"`
+
+	// user IDs of the members to move to the new policy. At least one member must remain on the original policy.
+	MemberIDs []uuid.UUID `json:"member_ids"`
+
+	// IDs of the dependents to move to the new policy
+	DependentIDs []uuid.UUID `json:"dependent_ids"`
+
+	// IDs of the items to move to the new policy. Items whose accountable person is moved are always moved.
+	ItemIDs []uuid.UUID `json:"item_ids"`
+}
+
 // swagger:model
 type PolicyLedgerReportCreateInput struct {
 	// Report types:
@@ -139,6 +204,11 @@ type PolicyLedgerReportCreateInput struct {
 
 	// Report year, e.g. return the policy's ledger entries entered in that year.
 	Year int `json:"year"`
+
+	// Report file format:
+	// + `csv` - CSV file (default)
+	// + `xlsx` - Excel workbook, with a summary sheet
+	Format string `json:"format"`
 }
 
 // swagger:model
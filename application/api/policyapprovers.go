@@ -0,0 +1,47 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// swagger:model
+type PolicyApprovers []PolicyApprover
+
+// PolicyApprover is a user, typically a cost-center manager, who must accept new coverage on a team policy
+// before it goes to the stewards for review
+// swagger:model
+type PolicyApprover struct {
+	// unique ID
+	//
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	// policy ID
+	//
+	// swagger:strfmt uuid4
+	PolicyID uuid.UUID `json:"policy_id"`
+
+	// user ID of the approver
+	//
+	// swagger:strfmt uuid4
+	UserID uuid.UUID `json:"user_id"`
+
+	// approver's name
+	Name string `json:"name"`
+
+	// approver's email address
+	Email string `json:"email"`
+
+	// The time the approver was added
+	//
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// swagger:model
+type PolicyApproverInput struct {
+	// email address of an existing user
+	Email string `json:"email"`
+}
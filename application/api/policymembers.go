@@ -9,6 +9,24 @@ import (
 // swagger:model
 type PolicyMembers []PolicyMember
 
+// PolicyUserRole
+//
+// may be one of: Owner, Member, Viewer
+//
+// swagger:model
+type PolicyUserRole string
+
+const (
+	// PolicyUserRoleOwner may manage the policy, its members and its items
+	PolicyUserRoleOwner = PolicyUserRole("Owner")
+
+	// PolicyUserRoleMember may add items and claims, but may not submit items for coverage or manage the policy
+	PolicyUserRoleMember = PolicyUserRole("Member")
+
+	// PolicyUserRoleViewer may only view the policy and its items and claims
+	PolicyUserRoleViewer = PolicyUserRole("Viewer")
+)
+
 // swagger:model
 type PolicyMember struct {
 	// unique ID
@@ -40,4 +58,20 @@ type PolicyMember struct {
 	//
 	// swagger:strfmt uuid4
 	PolicyUserID uuid.UUID `json:"policy_user_id"`
+
+	// the member's role on the policy
+	Role PolicyUserRole `json:"role"`
+}
+
+// PolicyMemberRoleInput is the input for changing the role of a policy member
+//
+// swagger:model
+type PolicyMemberRoleInput struct {
+	// ID of the PolicyUser object that is related to this policy and user
+	//
+	// swagger:strfmt uuid4
+	PolicyUserID uuid.UUID `json:"policy_user_id"`
+
+	// the new role
+	Role PolicyUserRole `json:"role"`
 }
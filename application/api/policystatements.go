@@ -0,0 +1,60 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// swagger:model
+type PolicyStatements []PolicyStatement
+
+// PolicyStatement is a monthly summary of the ledger activity on a policy. Amounts follow the ledger
+// convention: charges are negative and reimbursements are positive.
+// swagger:model
+type PolicyStatement struct {
+	// unique ID
+	//
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	// policy ID
+	//
+	// swagger:strfmt uuid4
+	PolicyID uuid.UUID `json:"policy_id"`
+
+	// month of the statement (1-12)
+	Month int `json:"month"`
+
+	// year of the statement
+	Year int `json:"year"`
+
+	// sum of all ledger entries before the start of the month
+	OpeningBalance Currency `json:"opening_balance"`
+
+	// new coverage, coverage changes and renewals
+	Premiums Currency `json:"premiums"`
+
+	// policy adjustments and discounts
+	Adjustments Currency `json:"adjustments"`
+
+	// coverage refunds for removed items
+	Refunds Currency `json:"refunds"`
+
+	// claim payouts
+	ClaimPayouts Currency `json:"claim_payouts"`
+
+	// sum of all ledger entries through the end of the month
+	ClosingBalance Currency `json:"closing_balance"`
+
+	// number of items that had coverage at some time during the month
+	CoveredItems int `json:"covered_items"`
+
+	// the PDF statement
+	File File `json:"file"`
+
+	// The time the statement was generated
+	//
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -18,4 +18,7 @@ type PolicyUserInviteCreate struct {
 
 	// A personal message from inviter to include in invite email
 	InviterMessage string `json:"inviter_message"`
+
+	// role given to the invitee on the policy: `Owner`, `Member` (default) or `Viewer`
+	Role PolicyUserRole `json:"role"`
 }
@@ -1,12 +1,21 @@
 package api
 
-import "time"
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
 
 // swagger:model
 type PolicyUserInvites []PolicyUserInvite
 
 // swagger:model
 type PolicyUserInvite struct {
+	// unique ID
+	//
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
 	// invitee's email
 	Email string `json:"email"`
 
@@ -17,4 +26,26 @@ type PolicyUserInvite struct {
 	//
 	// swagger:strfmt date-time
 	EmailSentAt *time.Time `json:"email_sent_at,omitempty"`
+
+	// number of times the invite email has been sent
+	EmailSendCount int `json:"email_send_count"`
+
+	// name of the member who sent the invite
+	InviterName string `json:"inviter_name"`
+
+	// role the invitee will have on the policy
+	Role PolicyUserRole `json:"role"`
+
+	// date and time after which the invite can no longer be accepted
+	//
+	// swagger:strfmt date-time
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// true if the invite can no longer be accepted
+	IsExpired bool `json:"is_expired"`
+
+	// date and time when the invite was created
+	//
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
 }
@@ -6,6 +6,19 @@ import (
 	"github.com/gofrs/uuid"
 )
 
+// StrikeStatus
+//
+// may be one of: Proposed, Active, Rejected
+//
+// swagger:model
+type StrikeStatus string
+
+const (
+	StrikeStatusProposed = StrikeStatus("Proposed")
+	StrikeStatusActive   = StrikeStatus("Active")
+	StrikeStatusRejected = StrikeStatus("Rejected")
+)
+
 // swagger:model
 type Strikes []Strike
 
@@ -22,6 +35,25 @@ type Strike struct {
 	// swagger:strfmt uuid4
 	PolicyID uuid.UUID `json:"policy_id"`
 
+	// Proposed strikes were created by a strike rule and are not in force until a steward approves them
+	Status StrikeStatus `json:"status"`
+
+	// ID of the claim that led to the strike, null for strikes entered manually
+	//
+	// swagger:strfmt uuid4
+	ClaimID *uuid.UUID `json:"claim_id"`
+
+	// ID of the strike rule that created the strike, null for strikes entered manually
+	//
+	// swagger:strfmt uuid4
+	StrikeRuleID *uuid.UUID `json:"strike_rule_id"`
+
+	// The time the strike was put in force, null for proposed and rejected strikes. The strike expires
+	// StrikeLifetimeMonths after this time.
+	//
+	// swagger:strfmt date-time
+	ActiveAt *time.Time `json:"active_at"`
+
 	// The time the strike was created
 	//
 	// swagger:strfmt date-time
@@ -38,3 +70,73 @@ type StrikeInput struct {
 	// strike description
 	Description string `json:"description"`
 }
+
+// swagger:model
+type StrikeRules []StrikeRule
+
+// StrikeRule creates or proposes a strike on a policy when one of its claims is approved. A claim matches a rule if
+// it matches all the rule's conditions that are set.
+//
+// swagger:model
+type StrikeRule struct {
+	// unique ID
+	//
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	// rule name, used as the description of the strikes it creates
+	Name string `json:"name"`
+
+	// if not empty, the claim must have this incident type
+	IncidentType ClaimIncidentType `json:"incident_type"`
+
+	// if not empty, the claim's incident description must contain this text, ignoring case, e.g. "unattended"
+	DescriptionContains string `json:"description_contains"`
+
+	// if more than 1, the policy must have at least this many approved claims, including this one, with an
+	// incident date in the preceding WindowMonths
+	MinClaims int `json:"min_claims"`
+
+	// number of months counted back from the claim's incident date for MinClaims
+	WindowMonths int `json:"window_months"`
+
+	// if true, strikes are created in force. Otherwise, they are proposed for review by a steward.
+	AutoCreate bool `json:"auto_create"`
+
+	// inactive rules are not applied
+	IsActive bool `json:"is_active"`
+
+	// The time the rule was created
+	//
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+
+	// The time the rule was updated
+	//
+	// swagger:strfmt date-time
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// swagger:model
+type StrikeRuleInput struct {
+	// rule name, used as the description of the strikes it creates
+	Name string `json:"name"`
+
+	// if not empty, the claim must have this incident type
+	IncidentType ClaimIncidentType `json:"incident_type"`
+
+	// if not empty, the claim's incident description must contain this text, ignoring case
+	DescriptionContains string `json:"description_contains"`
+
+	// if more than 1, the policy must have at least this many approved claims in the preceding WindowMonths
+	MinClaims int `json:"min_claims"`
+
+	// number of months counted back from the claim's incident date for MinClaims
+	WindowMonths int `json:"window_months"`
+
+	// if true, strikes are created in force. Otherwise, they are proposed for review by a steward.
+	AutoCreate bool `json:"auto_create"`
+
+	// inactive rules are not applied
+	IsActive bool `json:"is_active"`
+}
@@ -56,18 +56,27 @@ const (
 	ExtrasStatus = "status"
 	ExtrasURI    = "URI"
 
-	TypeClaim           = "claims"
-	TypeClaimItem       = "claim-items"
-	TypeClaimFile       = "claim-files"
-	TypeEntityCode      = "entity-codes"
-	TypeFile            = "files"
-	TypeItem            = "items"
-	TypeLedgerReport    = "ledger-reports"
-	TypePolicy          = "policies"
-	TypePolicyDependent = "policy-dependents"
-	TypePolicyMember    = "policy-members"
-	TypeStrike          = "strikes"
-	TypeUser            = "users"
+	TypeCertificate        = "certificates"
+	TypeClaim              = "claims"
+	TypeClaimItem          = "claim-items"
+	TypeClaimFile          = "claim-files"
+	TypeClaimReserveReport = "claim-reserve-reports"
+	TypeCoverageLimit      = "coverage-limits"
+	TypeEntityCode         = "entity-codes"
+	TypeFile               = "files"
+	TypeFiscalPeriod       = "fiscal-periods"
+	TypeItem               = "items"
+	TypeLedgerAdjustment   = "ledger-adjustments"
+	TypeLedgerEntry        = "ledger-entries"
+	TypeLedgerReport       = "ledger-reports"
+	TypePolicy             = "policies"
+	TypePolicyApprover     = "policy-approvers"
+	TypePolicyDependent    = "policy-dependents"
+	TypePolicyInvite       = "policy-invites"
+	TypePolicyMember       = "policy-members"
+	TypeStrike             = "strikes"
+	TypeStrikeRule         = "strike-rules"
+	TypeUser               = "users"
 )
 
 const (
@@ -89,18 +98,23 @@ const (
 	Megabyte    = 1048576
 
 	ContentCSV  = "text/csv"
+	ContentIIF  = "application/x-iif"
 	ContentJson = "application/json"
+	ContentPDF  = "application/pdf"
+	ContentXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
 	ContentZip  = "application/zip"
 )
 
 // Event Kinds
 const (
-	EventApiUserCreated      = "api:user:created"
-	EventApiItemSubmitted    = "api:item:submitted"
-	EventApiItemRevision     = "api:item:revision"
-	EventApiItemAutoApproved = "api:item:autoapproved"
-	EventApiItemApproved     = "api:item:approved"
-	EventApiItemDenied       = "api:item:denied"
+	EventApiUserCreated        = "api:user:created"
+	EventApiItemSubmitted      = "api:item:submitted"
+	EventApiItemRevision       = "api:item:revision"
+	EventApiItemAutoApproved   = "api:item:autoapproved"
+	EventApiItemApproved       = "api:item:approved"
+	EventApiItemDenied         = "api:item:denied"
+	EventApiItemPendingBudget  = "api:item:pending-budget"
+	EventApiItemBudgetRejected = "api:item:budget-rejected"
 
 	EventApiClaimReview1     = "api:claim:review1"
 	EventApiClaimRevision    = "api:claim:revision"
@@ -111,10 +125,23 @@ const (
 	EventApiClaimApproved    = "api:claim:approved"
 	EventApiClaimDenied      = "api:claim:denied"
 
+	EventApiCoverageLimitPending = "api:coverage-limit:pending"
+
 	EventApiNotificationCreated = "api:notification:created"
 
-	EventApiPolicyUserInviteCreated = "api:policy:invite:created"
-	EventApiPolicyUserInviteExpired = "api:policy:invite:expired"
+	EventApiPolicyClosed = "api:policy:closed"
+
+	EventApiPolicyStatementCreated = "api:policy:statement:created"
+
+	EventApiPolicyUserInviteCreated  = "api:policy:invite:created"
+	EventApiPolicyUserInviteExpired  = "api:policy:invite:expired"
+	EventApiPolicyUserInviteResent   = "api:policy:invite:resent"
+	EventApiPolicyUserInviteExtended = "api:policy:invite:extended"
+	EventApiPolicyUserInviteRevoked  = "api:policy:invite:revoked"
+
+	EventApiStrikeCreated  = "api:strike:created"
+	EventApiStrikeProposed = "api:strike:proposed"
+	EventApiStrikeExpired  = "api:strike:expired"
 )
 
 // redirect url for after logout
@@ -185,6 +212,9 @@ type EnvStruct struct {
 	DependentAutoApproveMax int `default:"4000" split_words:"true"`
 	PremiumMinimum          int `default:"25" split_words:"true"`
 
+	// CoverageLimitApprovalThreshold is the coverage limit override above which Signator approval is required
+	CoverageLimitApprovalThreshold int `default:"100000" split_words:"true"`
+
 	// PremiumFactor is multiplied by CoverageAmount to calculate the annual premium of an item
 	PremiumFactor         float64 `default:"0.02" split_words:"true"`
 	RepairThreshold       float64 `default:"0.7" split_words:"true"`
@@ -197,6 +227,13 @@ type EnvStruct struct {
 	StrikeLifetimeMonths  int     `default:"24" split_words:"true"`
 	VehiclePremiumFactor  float64 `default:"0.02" split_words:"true"` // TODO use actual rate
 
+	// A policy with no approved claims for NoClaimsDiscountYears gets NoClaimsDiscountRate off its renewal
+	// premiums, increased by NoClaimsDiscountIncrease for each additional claim-free year
+	NoClaimsDiscountYears    int     `default:"3" split_words:"true"`
+	NoClaimsDiscountRate     float64 `default:"0.05" split_words:"true"`
+	NoClaimsDiscountIncrease float64 `default:"0.05" split_words:"true"`
+	NoClaimsDiscountMaximum  float64 `default:"0.2" split_words:"true"`
+
 	FiscalStartMonth   int    `default:"1" split_words:"true"`
 	ExpenseAccount     string `required:"true" split_words:"true"`
 	ClaimIncomeAccount string `required:"true" split_words:"true"`
@@ -208,6 +245,10 @@ type EnvStruct struct {
 	HouseholdIDLookupUsername string `default:"" split_words:"true"`
 	HouseholdIDLookupPassword string `default:"" split_words:"true"`
 
+	// When HouseholdIDBlockBilling is true, charges to a policy with an invalid household ID are left out of the
+	// ledger reports until the household ID is corrected
+	HouseholdIDBlockBilling bool `default:"false" split_words:"true"`
+
 	UserWelcomeEmailIntro       string `default:"" split_words:"true"`
 	UserWelcomeEmailPreviewText string `default:"" split_words:"true"`
 	UserWelcomeEmailEnding      string `default:"" split_words:"true"`
@@ -243,6 +284,7 @@ func readEnv() *EnvStruct {
 	env.PolicyMaxCoverage *= CurrencyFactor
 	env.DependentAutoApproveMax *= CurrencyFactor
 	env.PremiumMinimum *= CurrencyFactor
+	env.CoverageLimitApprovalThreshold *= CurrencyFactor
 	env.RepairThresholdString = PercentString(env.RepairThreshold)
 	env.DeductibleRateString = PercentString(env.DeductibleRate)
 
@@ -2,6 +2,8 @@ package fin
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/silinternational/cover-api/api"
@@ -9,9 +11,11 @@ import (
 )
 
 const (
-	ReportFormatNetSuite = "netsuite"
-	ReportFormatPolicy   = "policy"
-	ReportFormatSage     = "sage"
+	ReportFormatJSON       = "json"
+	ReportFormatNetSuite   = "netsuite"
+	ReportFormatPolicy     = "policy"
+	ReportFormatQuickBooks = "quickbooks"
+	ReportFormatSage       = "sage"
 )
 
 type (
@@ -38,32 +42,202 @@ type Transaction struct {
 	Description string
 	Reference   *string // Override the reference if given
 	Date        time.Time
+
+	// IsBalance is true for the balancing transaction of a block, which posts the total of the block to the credit
+	// account
+	IsBalance bool
 }
 
 type Report interface {
 	AppendToBatch(string, Transaction)
 	RenderBatch() ([]byte, string)
 
+	// RenderedBlocks returns the blocks of transactions as they are written to the report, in the order in which
+	// they were added
+	RenderedBlocks() []RenderedBlock
+
 	getReference(Transaction) string
 }
 
-func NewBatch(reportFormat, reportType string, date time.Time) Report {
-	batchDesc := fmt.Sprintf("%s %s JE", date.Format("January 2006"), domain.Env.AppName)
+// RenderedBlock is a block of transactions as it is written to a report
+type RenderedBlock struct {
+	Name string
+
+	// Transactions are the transactions of the block other than the balancing transaction
+	Transactions Transactions
+
+	// CreditAccount is the account of the balancing transaction, or empty if the block has none
+	CreditAccount string
+
+	// Balance is the amount posted to the credit account
+	Balance api.Currency
+}
+
+// Format describes a report format that can be selected by name. Formats add themselves to the registry with
+// RegisterFormat in an init function.
+type Format struct {
+	Name string
+
+	// FileExtension is used in the name of the report file, e.g. "csv"
+	FileExtension string
+
+	// ExportOnly is true for a format that is not an accounting journal, e.g. the policy ledger export. It cannot
+	// be selected for a ledger report.
+	ExportOnly bool
+
+	// New returns an empty report. The batch description is a name for the journal entry as a whole.
+	New func(batchDesc, reportType string, date time.Time) Report
+}
+
+var formats = map[string]Format{}
+
+// RegisterFormat adds a report format to the registry. It panics if the name is already taken.
+func RegisterFormat(f Format) {
+	if _, ok := formats[f.Name]; ok {
+		panic("fin: report format registered twice: " + f.Name)
+	}
+	formats[f.Name] = f
+}
 
-	switch reportFormat {
-	case ReportFormatNetSuite:
-		return newNetSuiteReport(batchDesc, reportType, date)
-	case ReportFormatPolicy:
-		return &Policy{}
-	case ReportFormatSage:
-		return &Sage{
-			Period:             getFiscalPeriod(int(date.Month())),
-			Year:               getFiscalYear(date),
-			JournalDescription: batchDesc,
-			Transactions:       nil,
+// GetFormat returns the registered report format with the given name. A name ending in SpreadsheetSuffix returns
+// the XLSX variant of the registered format.
+func GetFormat(name string) (Format, error) {
+	if base, ok := strings.CutSuffix(name, SpreadsheetSuffix); ok {
+		if f, ok := formats[base]; ok {
+			return f.Spreadsheet(), nil
 		}
 	}
-	panic("fin: invalid provider type")
+
+	f, ok := formats[name]
+	if !ok {
+		return Format{}, fmt.Errorf("fin: invalid report format %q", name)
+	}
+	return f, nil
+}
+
+// GetReportFormat returns the registered format with the given name if it can be selected for a ledger report
+func GetReportFormat(name string) (Format, error) {
+	f, err := GetFormat(name)
+	if err != nil {
+		return Format{}, err
+	}
+	if f.ExportOnly {
+		return Format{}, fmt.Errorf("fin: %q is not a ledger report format", name)
+	}
+	return f, nil
+}
+
+// Formats returns the names of all registered report formats in alphabetical order
+func Formats() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// blockBalances holds the balancing transaction of each block. A balancing transaction with a zero amount is kept
+// here even though it is not written to the report.
+type blockBalances map[string]Transaction
+
+// record keeps the transaction if it is a balancing transaction
+func (b *blockBalances) record(block string, t Transaction) {
+	if !t.IsBalance {
+		return
+	}
+	if *b == nil {
+		*b = blockBalances{}
+	}
+	(*b)[block] = t
+}
+
+// withoutBalance returns the transactions of a block other than its balancing transaction
+func (t Transactions) withoutBalance() Transactions {
+	var transactions Transactions
+	for _, tr := range t {
+		if !tr.IsBalance {
+			transactions = append(transactions, tr)
+		}
+	}
+	return transactions
+}
+
+// renderedBlocks returns the named blocks in order, each with its recorded balancing transaction
+func renderedBlocks(names []string, blocks TransactionBlocks, balances blockBalances) []RenderedBlock {
+	rendered := make([]RenderedBlock, 0, len(names))
+	for _, name := range names {
+		balance := balances[name]
+		rendered = append(rendered, RenderedBlock{
+			Name:          name,
+			Transactions:  blocks[name].withoutBalance(),
+			CreditAccount: balance.Account,
+			Balance:       balance.Amount,
+		})
+	}
+	return rendered
+}
+
+// getReference returns the reference used by most formats: the household ID and member name for a household
+// policy, or the entity code, account and cost center and the policy name for other policies
+func getReference(t Transaction) string {
+	if t.Reference != nil {
+		return *t.Reference
+	}
+
+	// For household policies
+	if t.PolicyType == api.PolicyTypeHousehold {
+		ref := fmt.Sprintf("MC %s", t.HouseholdID)
+
+		if t.Name == "" {
+			return ref
+		}
+
+		return fmt.Sprintf("%s / %s", ref, t.Name)
+	}
+
+	// For non-household policies
+	ref := fmt.Sprintf("%s %s%s", t.EntityCode, t.AccountNumber, t.CostCenter)
+
+	if t.PolicyName == "" {
+		return ref
+	}
+
+	return fmt.Sprintf("%s / %s", ref, t.PolicyName)
+}
+
+// getAccount returns the account of the transaction, or the expense account if it has none
+func getAccount(t Transaction) string {
+	if t.Account != "" {
+		return t.Account
+	}
+
+	return domain.Env.ExpenseAccount
+}
+
+// Reference returns the reference that this format gives to the transaction
+func (f Format) Reference(t Transaction) string {
+	return f.New("", "", time.Time{}).getReference(t)
+}
+
+// NewBatch returns an empty report in the named format
+func NewBatch(reportFormat, reportType string, date time.Time) (Report, error) {
+	f, err := GetFormat(reportFormat)
+	if err != nil {
+		return nil, err
+	}
+	return f.NewBatch(reportType, date), nil
+}
+
+// NewBatch returns an empty report in this format
+func (f Format) NewBatch(reportType string, date time.Time) Report {
+	batchDesc := fmt.Sprintf("%s %s JE", date.Format("January 2006"), domain.Env.AppName)
+	return f.New(batchDesc, reportType, date)
+}
+
+// FiscalPeriod returns the fiscal year and period of the given date
+func FiscalPeriod(date time.Time) (year, period int) {
+	return getFiscalYear(date), getFiscalPeriod(int(date.Month()))
 }
 
 func getFiscalPeriod(month int) int {
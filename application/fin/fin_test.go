@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/silinternational/cover-api/api"
 	"github.com/silinternational/cover-api/domain"
 )
 
@@ -162,3 +163,55 @@ func Test_getFiscalYear(t *testing.T) {
 		})
 	}
 }
+
+func TestNewBatch(t *testing.T) {
+	for _, name := range Formats() {
+		t.Run(name, func(t *testing.T) {
+			r, err := NewBatch(name, "", time.Now())
+			assert.NoError(t, err)
+			assert.NotNil(t, r)
+		})
+	}
+
+	_, err := NewBatch("invalid", "", time.Now())
+	assert.Error(t, err)
+}
+
+func TestRegisterFormat(t *testing.T) {
+	assert.Panics(t, func() {
+		RegisterFormat(Format{Name: ReportFormatSage})
+	})
+}
+
+func TestFormat_Reference(t *testing.T) {
+	household := Transaction{PolicyType: api.PolicyTypeHousehold, HouseholdID: "123456", Name: "Alice"}
+	ref := "override"
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: ReportFormatSage, want: "MC 123456 / Alice"},
+		{format: ReportFormatSage + SpreadsheetSuffix, want: "MC 123456 / Alice"},
+		{format: ReportFormatNetSuite, want: "Alice"},
+	}
+	for _, tt := range tests {
+		f, err := GetFormat(tt.format)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, f.Reference(household), tt.format)
+		assert.Equal(t, ref, f.Reference(Transaction{Reference: &ref}), tt.format)
+	}
+}
+
+func TestGetReportFormat(t *testing.T) {
+	for _, name := range []string{ReportFormatSage, ReportFormatQuickBooks, ReportFormatSage + SpreadsheetSuffix} {
+		f, err := GetReportFormat(name)
+		assert.NoError(t, err, name)
+		assert.Equal(t, name, f.Name)
+	}
+
+	for _, name := range []string{ReportFormatPolicy, ReportFormatPolicy + SpreadsheetSuffix, "invalid"} {
+		_, err := GetReportFormat(name)
+		assert.Error(t, err, name)
+	}
+}
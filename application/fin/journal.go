@@ -0,0 +1,116 @@
+package fin
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+func init() {
+	RegisterFormat(Format{
+		Name:          ReportFormatJSON,
+		FileExtension: "json",
+		New: func(batchDesc, reportType string, date time.Time) Report {
+			return &Journal{
+				Description:  batchDesc,
+				ReportType:   reportType,
+				Date:         date.Format(domain.DateFormat),
+				FiscalYear:   getFiscalYear(date),
+				FiscalPeriod: getFiscalPeriod(int(date.Month())),
+				Blocks:       []JournalBlock{},
+			}
+		},
+	})
+}
+
+// Journal is a general journal in a JSON layout that does not depend on any particular accounting package. Each
+// block of transactions balances to zero. Amounts are debits if positive and credits if negative.
+type Journal struct {
+	Description  string         `json:"description"`
+	ReportType   string         `json:"report_type"`
+	Date         string         `json:"date"`
+	FiscalYear   int            `json:"fiscal_year"`
+	FiscalPeriod int            `json:"fiscal_period"`
+	Blocks       []JournalBlock `json:"blocks"`
+
+	blockIndex   map[string]int
+	transactions TransactionBlocks
+	balances     blockBalances
+}
+
+type JournalBlock struct {
+	Name  string        `json:"name"`
+	Lines []JournalLine `json:"lines"`
+}
+
+type JournalLine struct {
+	Account     string         `json:"account"`
+	Amount      string         `json:"amount"`
+	Description string         `json:"description"`
+	Reference   string         `json:"reference"`
+	Date        string         `json:"date"`
+	Type        string         `json:"type,omitempty"`
+	PolicyType  api.PolicyType `json:"policy_type,omitempty"`
+	EntityCode  string         `json:"entity_code,omitempty"`
+	HouseholdID string         `json:"household_id,omitempty"`
+	CostCenter  string         `json:"cost_center,omitempty"`
+}
+
+func (j *Journal) AppendToBatch(block string, t Transaction) {
+	j.balances.record(block, t)
+	if t.Amount == 0 {
+		return
+	}
+
+	if j.blockIndex == nil {
+		j.blockIndex = map[string]int{}
+		j.transactions = make(TransactionBlocks)
+	}
+
+	i, ok := j.blockIndex[block]
+	if !ok {
+		i = len(j.Blocks)
+		j.blockIndex[block] = i
+		j.Blocks = append(j.Blocks, JournalBlock{Name: block})
+	}
+
+	j.transactions[block] = append(j.transactions[block], t)
+	j.Blocks[i].Lines = append(j.Blocks[i].Lines, JournalLine{
+		Account:     j.getAccount(t),
+		Amount:      api.Currency(-t.Amount).String(),
+		Description: t.Description,
+		Reference:   j.getReference(t),
+		Date:        t.Date.Format(domain.DateFormat),
+		Type:        t.Type,
+		PolicyType:  t.PolicyType,
+		EntityCode:  t.EntityCode,
+		HouseholdID: t.HouseholdID,
+		CostCenter:  t.CostCenter,
+	})
+}
+
+func (j *Journal) RenderBatch() ([]byte, string) {
+	content, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		panic("fin: failed to marshal journal, " + err.Error())
+	}
+	return content, domain.ContentJson
+}
+
+func (j *Journal) RenderedBlocks() []RenderedBlock {
+	names := make([]string, len(j.Blocks))
+	for i, b := range j.Blocks {
+		names[i] = b.Name
+	}
+	return renderedBlocks(names, j.transactions, j.balances)
+}
+
+func (j *Journal) getAccount(t Transaction) string {
+	return getAccount(t)
+}
+
+func (j *Journal) getReference(t Transaction) string {
+	return getReference(t)
+}
@@ -0,0 +1,63 @@
+package fin
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+func TestJournal_Export(t *testing.T) {
+	now := time.Now().UTC()
+	t1 := Transaction{
+		Type:        "NewCoverage",
+		PolicyType:  api.PolicyTypeHousehold,
+		HouseholdID: "mno5",
+		Name:        "stu7",
+		Amount:      -150,
+		Date:        now,
+		Description: "transaction description",
+	}
+	ref := ""
+	s1 := Transaction{Account: "summaryONE", Amount: 150, Reference: &ref, Date: now, Description: "Total"}
+
+	r, err := NewBatch(ReportFormatJSON, "Monthly", now)
+	require.NoError(t, err)
+	r.AppendToBatch("foo", t1)
+	r.AppendToBatch("foo", s1)
+	r.AppendToBatch("bar", Transaction{Amount: 0})
+
+	got, gotType := r.RenderBatch()
+	require.Equal(t, domain.ContentJson, gotType)
+
+	var j Journal
+	require.NoError(t, json.Unmarshal(got, &j))
+	require.Equal(t, "Monthly", j.ReportType)
+	require.Equal(t, now.Format(domain.DateFormat), j.Date)
+	require.Equal(t, getFiscalYear(now), j.FiscalYear)
+	require.Equal(t, getFiscalPeriod(int(now.Month())), j.FiscalPeriod)
+	require.Len(t, j.Blocks, 1)
+	require.Equal(t, "foo", j.Blocks[0].Name)
+	require.Equal(t, []JournalLine{
+		{
+			Account:     domain.Env.ExpenseAccount,
+			Amount:      "1.50",
+			Description: t1.Description,
+			Reference:   "MC mno5 / stu7",
+			Date:        now.Format(domain.DateFormat),
+			Type:        t1.Type,
+			PolicyType:  t1.PolicyType,
+			HouseholdID: t1.HouseholdID,
+		},
+		{
+			Account:     "summaryONE",
+			Amount:      "-1.50",
+			Description: "Total",
+			Date:        now.Format(domain.DateFormat),
+		},
+	}, j.Blocks[0].Lines)
+}
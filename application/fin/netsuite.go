@@ -14,6 +14,16 @@ const (
 	netSuiteTransactionRowTemplate = `MAP,,%d,%s,"%s","%s","%s",%s,%s,USD,"%s",,%s` + "\n"
 )
 
+func init() {
+	RegisterFormat(Format{
+		Name:          ReportFormatNetSuite,
+		FileExtension: "csv",
+		New: func(batchDesc, reportType string, date time.Time) Report {
+			return newNetSuiteReport(batchDesc, reportType, date)
+		},
+	})
+}
+
 type NetSuite struct {
 	Period             int
 	Year               int
@@ -23,6 +33,7 @@ type NetSuite struct {
 	date       time.Time
 	rowID      int64
 	blockNames []string
+	balances   blockBalances
 }
 
 func newNetSuiteReport(batchDesc, reportType string, date time.Time) *NetSuite {
@@ -46,6 +57,7 @@ func newNetSuiteReport(batchDesc, reportType string, date time.Time) *NetSuite {
 }
 
 func (n *NetSuite) AppendToBatch(block string, t Transaction) {
+	n.balances.record(block, t)
 	if t.Amount == 0 {
 		return
 	}
@@ -62,15 +74,10 @@ func (n *NetSuite) RenderBatch() ([]byte, string) {
 	buf.Write([]byte(netSuiteHeader))
 
 	for _, name := range n.blockNames {
-		block := n.TransactionBlocks[name]
-		last := len(block) - 1
-
-		creditAccount := block[last].Account
-
-		// TODO clean this up when Sage report is removed
-		// Last row is a summary row. Can't remove it completely
-		// as it is being used by Sage, so removing it here
-		for _, transaction := range block[:last] {
+		// Each row posts its own amount to the credit account, so the balancing transaction only gives the account
+		// and is not written.
+		creditAccount := n.balances[name].Account
+		for _, transaction := range n.TransactionBlocks[name].withoutBalance() {
 			buf.Write(n.transactionRow(transaction, creditAccount))
 		}
 	}
@@ -78,6 +85,19 @@ func (n *NetSuite) RenderBatch() ([]byte, string) {
 	return buf.Bytes(), domain.ContentCSV
 }
 
+// RenderedBlocks returns the blocks as written by RenderBatch, where the credit account takes the total of the rows
+// rather than the amount of the balancing transaction
+func (n *NetSuite) RenderedBlocks() []RenderedBlock {
+	blocks := renderedBlocks(n.blockNames, n.TransactionBlocks, n.balances)
+	for i := range blocks {
+		blocks[i].Balance = 0
+		for _, t := range blocks[i].Transactions {
+			blocks[i].Balance -= t.Amount
+		}
+	}
+	return blocks
+}
+
 func (n *NetSuite) getDebitAccount(t Transaction) string {
 	if t.Account != "" {
 		return t.Account
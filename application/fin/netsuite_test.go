@@ -2,6 +2,7 @@ package fin
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -41,8 +42,8 @@ func TestNetSuite_Export(t *testing.T) {
 		Date:              now,
 		Description:       "transaction description",
 	}
-	s1 := Transaction{Account: "summaryONE", Amount: t1.Amount}
-	s2 := Transaction{Account: "summaryTWO", Amount: t2.Amount}
+	s1 := Transaction{Account: "summaryONE", Amount: t1.Amount, IsBalance: true}
+	s2 := Transaction{Account: "summaryTWO", Amount: t2.Amount, IsBalance: true}
 
 	n := newNetSuiteReport("journal description", "", now)
 	n.AppendToBatch("", t1)
@@ -80,3 +81,27 @@ func TestNetSuite_Export(t *testing.T) {
 	require.Equal(t, want, string(got))
 	require.Equal(t, domain.ContentCSV, gotType)
 }
+
+func TestNetSuite_RenderedBlocks(t *testing.T) {
+	n := newNetSuiteReport("journal description", "", time.Now().UTC())
+	n.AppendToBatch("foo", Transaction{Amount: -100})
+	n.AppendToBatch("foo", Transaction{Amount: -50})
+	n.AppendToBatch("foo", Transaction{Account: "40200", Amount: 100, IsBalance: true})
+	n.AppendToBatch("bar", Transaction{Amount: -100})
+	n.AppendToBatch("bar", Transaction{Amount: 100})
+	n.AppendToBatch("bar", Transaction{Account: "40300", Amount: 0, IsBalance: true})
+
+	got := n.RenderedBlocks()
+	require.Len(t, got, 2)
+	require.Equal(t, "foo", got[0].Name)
+	require.Len(t, got[0].Transactions, 2)
+	require.Equal(t, "40200", got[0].CreditAccount)
+	require.Equal(t, api.Currency(150), got[0].Balance, "the credit account takes the total of the rows")
+
+	require.Len(t, got[1].Transactions, 2, "a block that nets to zero should keep all of its rows")
+	require.Equal(t, "40300", got[1].CreditAccount)
+	require.Equal(t, api.Currency(0), got[1].Balance)
+
+	content, _ := n.RenderBatch()
+	require.Equal(t, 4, strings.Count(string(content), `"40300"`)+strings.Count(string(content), `"40200"`))
+}
@@ -3,6 +3,7 @@ package fin
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	"github.com/silinternational/cover-api/api"
 	"github.com/silinternational/cover-api/domain"
@@ -13,6 +14,17 @@ const (
 	policyTransactionRowTemplate = `%s,"%s","%s",%s` + "\n"
 )
 
+func init() {
+	RegisterFormat(Format{
+		Name:          ReportFormatPolicy,
+		FileExtension: "csv",
+		ExportOnly:    true,
+		New: func(string, string, time.Time) Report {
+			return &Policy{}
+		},
+	})
+}
+
 type Policy struct {
 	Transactions []Transaction
 }
@@ -33,30 +45,13 @@ func (p *Policy) RenderBatch() ([]byte, string) {
 	return buf.Bytes(), domain.ContentCSV
 }
 
-func (p *Policy) getReference(t Transaction) string {
-	if t.Reference != nil {
-		return *t.Reference
-	}
-
-	// For household policies
-	if t.PolicyType == api.PolicyTypeHousehold {
-		ref := fmt.Sprintf("MC %s", t.HouseholdID)
-
-		if t.Name == "" {
-			return ref
-		}
-
-		return fmt.Sprintf("%s / %s", ref, t.Name)
-	}
-
-	// For non-household policies
-	ref := fmt.Sprintf("%s %s%s", t.EntityCode, t.AccountNumber, t.CostCenter)
-
-	if t.PolicyName == "" {
-		return ref
-	}
+// RenderedBlocks returns nil since the policy ledger is a list of entries rather than a journal
+func (p *Policy) RenderedBlocks() []RenderedBlock {
+	return nil
+}
 
-	return fmt.Sprintf("%s / %s", ref, t.PolicyName)
+func (p *Policy) getReference(t Transaction) string {
+	return getReference(t)
 }
 
 func (p *Policy) transactionRow(rowNumber int) []byte {
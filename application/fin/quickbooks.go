@@ -0,0 +1,107 @@
+package fin
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+const (
+	quickBooksHeader = "!TRNS\tTRNSTYPE\tDATE\tACCNT\tAMOUNT\tDOCNUM\tMEMO\n" +
+		"!SPL\tTRNSTYPE\tDATE\tACCNT\tAMOUNT\tDOCNUM\tMEMO\n" +
+		"!ENDTRNS\n"
+	quickBooksRowTemplate = "%s\tGENERAL JOURNAL\t%s\t%s\t%s\t%s\t%s\n"
+	quickBooksEndRow      = "ENDTRNS\n"
+)
+
+func init() {
+	RegisterFormat(Format{
+		Name:          ReportFormatQuickBooks,
+		FileExtension: "iif",
+		New: func(_, _ string, date time.Time) Report {
+			return &QuickBooks{
+				DocumentNumber:    fmt.Sprintf("%d-%02d", getFiscalYear(date), getFiscalPeriod(int(date.Month()))),
+				TransactionBlocks: make(TransactionBlocks),
+			}
+		},
+	})
+}
+
+// QuickBooks is a general journal in the Intuit Interchange Format (IIF). Each block of transactions is written as
+// one journal transaction. The balancing transaction of the block is the TRNS line and the others are SPL lines.
+type QuickBooks struct {
+	DocumentNumber    string // fiscal year and period, e.g. 2021-03
+	TransactionBlocks TransactionBlocks
+
+	blockNames []string
+	balances   blockBalances
+}
+
+func (q *QuickBooks) AppendToBatch(block string, t Transaction) {
+	q.balances.record(block, t)
+	if t.Amount == 0 {
+		return
+	}
+
+	if _, ok := q.TransactionBlocks[block]; !ok {
+		q.blockNames = append(q.blockNames, block)
+	}
+
+	q.TransactionBlocks[block] = append(q.TransactionBlocks[block], t)
+}
+
+func (q *QuickBooks) RenderBatch() ([]byte, string) {
+	var buf bytes.Buffer
+	buf.Write([]byte(quickBooksHeader))
+
+	for _, name := range q.blockNames {
+		buf.Write(q.transactionRow("TRNS", q.balances[name]))
+		for _, t := range q.TransactionBlocks[name].withoutBalance() {
+			buf.Write(q.transactionRow("SPL", t))
+		}
+		buf.Write([]byte(quickBooksEndRow))
+	}
+
+	return buf.Bytes(), domain.ContentIIF
+}
+
+func (q *QuickBooks) RenderedBlocks() []RenderedBlock {
+	return renderedBlocks(q.blockNames, q.TransactionBlocks, q.balances)
+}
+
+func (q *QuickBooks) getAccount(t Transaction) string {
+	return getAccount(t)
+}
+
+func (q *QuickBooks) getReference(t Transaction) string {
+	return getReference(t)
+}
+
+// getMemo combines the reference and the description, since IIF has no separate reference field on a split line
+func (q *QuickBooks) getMemo(t Transaction) string {
+	memo := t.Description
+	if ref := q.getReference(t); ref != "" {
+		memo = ref + ": " + memo
+	}
+	return iifReplacer.Replace(memo)
+}
+
+// iifReplacer removes characters that would break the tab-delimited IIF layout
+var iifReplacer = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ", `"`, "'")
+
+func (q *QuickBooks) transactionRow(rowType string, t Transaction) []byte {
+	str := fmt.Sprintf(
+		quickBooksRowTemplate,
+		rowType,
+		t.Date.Format("01/02/2006"),
+		iifReplacer.Replace(q.getAccount(t)),
+		api.Currency(-t.Amount).String(),
+		q.DocumentNumber,
+		q.getMemo(t),
+	)
+	return []byte(str)
+}
@@ -0,0 +1,60 @@
+package fin
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+func TestQuickBooks_Export(t *testing.T) {
+	now := time.Now().UTC()
+	t1 := Transaction{
+		PolicyType:  api.PolicyTypeHousehold,
+		HouseholdID: "mno5",
+		Name:        "stu7",
+		Amount:      100,
+		Date:        now,
+		Description: "transaction\tdescription",
+	}
+	t2 := Transaction{
+		PolicyType:    api.PolicyTypeTeam,
+		EntityCode:    "zyx9",
+		PolicyName:    "nml5",
+		AccountNumber: "kji4",
+		CostCenter:    "hgf3",
+		Amount:        200,
+		Date:          now,
+		Description:   "transaction description",
+	}
+	ref := ""
+	s1 := Transaction{Account: "summaryONE", Amount: -t1.Amount - t2.Amount, Reference: &ref, Date: now, Description: "Total",
+		IsBalance: true}
+
+	march := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	r, err := NewBatch(ReportFormatQuickBooks, "", march)
+	require.NoError(t, err)
+	q := r.(*QuickBooks)
+	q.AppendToBatch("foo", t1)
+	q.AppendToBatch("foo", t2)
+	q.AppendToBatch("foo", Transaction{Amount: 0})
+	q.AppendToBatch("foo", s1)
+
+	date := now.Format("01/02/2006")
+	docNum := fmt.Sprintf("%d-%02d", getFiscalYear(march), getFiscalPeriod(3))
+	want := quickBooksHeader +
+		fmt.Sprintf("TRNS\tGENERAL JOURNAL\t%s\tsummaryONE\t3.00\t%s\tTotal\n", date, docNum) +
+		fmt.Sprintf("SPL\tGENERAL JOURNAL\t%s\t%s\t-1.00\t%s\tMC mno5 / stu7: transaction description\n",
+			date, domain.Env.ExpenseAccount, docNum) +
+		fmt.Sprintf("SPL\tGENERAL JOURNAL\t%s\t%s\t-2.00\t%s\tzyx9 kji4hgf3 / nml5: transaction description\n",
+			date, domain.Env.ExpenseAccount, docNum) +
+		quickBooksEndRow
+
+	got, gotType := q.RenderBatch()
+	require.Equal(t, want, string(got))
+	require.Equal(t, domain.ContentIIF, gotType)
+}
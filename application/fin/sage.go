@@ -3,6 +3,7 @@ package fin
 import (
 	"bytes"
 	"fmt"
+	"time"
 
 	"github.com/silinternational/cover-api/api"
 	"github.com/silinternational/cover-api/domain"
@@ -18,17 +19,49 @@ const (
 	sageSummaryRowTemplate     = `"1","000000","00001","","GL","JE","%d","%02d",0,"%s","00",0,0,0,2` + "\n"
 )
 
+func init() {
+	RegisterFormat(Format{
+		Name:          ReportFormatSage,
+		FileExtension: "csv",
+		New: func(batchDesc, _ string, date time.Time) Report {
+			return &Sage{
+				Period:             getFiscalPeriod(int(date.Month())),
+				Year:               getFiscalYear(date),
+				JournalDescription: batchDesc,
+				Transactions:       nil,
+			}
+		},
+	})
+}
+
 type Sage struct {
 	Period             int
 	Year               int
 	JournalDescription string
 	Transactions       []Transaction
+
+	blocks     TransactionBlocks
+	blockNames []string
+	balances   blockBalances
 }
 
-func (s *Sage) AppendToBatch(_ string, t Transaction) {
-	if t.Amount != 0 {
-		s.Transactions = append(s.Transactions, t)
+// AppendToBatch adds the transaction to the batch. Sage does not group the transactions, but the blocks are kept
+// for RenderedBlocks.
+func (s *Sage) AppendToBatch(block string, t Transaction) {
+	s.balances.record(block, t)
+	if t.Amount == 0 {
+		return
+	}
+
+	if s.blocks == nil {
+		s.blocks = make(TransactionBlocks)
 	}
+	if _, ok := s.blocks[block]; !ok {
+		s.blockNames = append(s.blockNames, block)
+	}
+
+	s.blocks[block] = append(s.blocks[block], t)
+	s.Transactions = append(s.Transactions, t)
 }
 
 func (s *Sage) RenderBatch() ([]byte, string) {
@@ -43,38 +76,16 @@ func (s *Sage) RenderBatch() ([]byte, string) {
 	return buf.Bytes(), domain.ContentCSV
 }
 
-func (s *Sage) getAccount(t Transaction) string {
-	if t.Account != "" {
-		return t.Account
-	}
+func (s *Sage) RenderedBlocks() []RenderedBlock {
+	return renderedBlocks(s.blockNames, s.blocks, s.balances)
+}
 
-	return domain.Env.ExpenseAccount
+func (s *Sage) getAccount(t Transaction) string {
+	return getAccount(t)
 }
 
 func (s *Sage) getReference(t Transaction) string {
-	if t.Reference != nil {
-		return *t.Reference
-	}
-
-	// For household policies
-	if t.PolicyType == api.PolicyTypeHousehold {
-		ref := fmt.Sprintf("MC %s", t.HouseholdID)
-
-		if t.Name == "" {
-			return ref
-		}
-
-		return fmt.Sprintf("%s / %s", ref, t.Name)
-	}
-
-	// For non-household policies
-	ref := fmt.Sprintf("%s %s%s", t.EntityCode, t.AccountNumber, t.CostCenter)
-
-	if t.PolicyName == "" {
-		return ref
-	}
-
-	return fmt.Sprintf("%s / %s", ref, t.PolicyName)
+	return getReference(t)
 }
 
 func (s *Sage) summaryRow() []byte {
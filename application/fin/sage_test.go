@@ -52,3 +52,23 @@ func TestSage_Export(t *testing.T) {
 	require.Equal(t, want, string(got))
 	require.Equal(t, domain.ContentCSV, gotType)
 }
+
+func TestSage_RenderedBlocks(t *testing.T) {
+	s := &Sage{}
+	s.AppendToBatch("foo", Transaction{Amount: -100})
+	s.AppendToBatch("bar", Transaction{Amount: -50})
+	s.AppendToBatch("foo", Transaction{Amount: 0})
+	s.AppendToBatch("foo", Transaction{Account: "40200", Amount: 100, IsBalance: true})
+	s.AppendToBatch("bar", Transaction{Account: "40300", Amount: 50, IsBalance: true})
+	s.AppendToBatch("zero", Transaction{Amount: -100})
+	s.AppendToBatch("zero", Transaction{Amount: 100})
+	s.AppendToBatch("zero", Transaction{Account: "40400", Amount: 0, IsBalance: true})
+
+	want := []RenderedBlock{
+		{Name: "foo", Transactions: Transactions{{Amount: -100}}, CreditAccount: "40200", Balance: 100},
+		{Name: "bar", Transactions: Transactions{{Amount: -50}}, CreditAccount: "40300", Balance: 50},
+		{Name: "zero", Transactions: Transactions{{Amount: -100}, {Amount: 100}}, CreditAccount: "40400"},
+	}
+	require.Equal(t, want, s.RenderedBlocks())
+	require.Len(t, s.Transactions, 6, "a zero balancing transaction should not be written")
+}
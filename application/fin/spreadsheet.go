@@ -0,0 +1,323 @@
+package fin
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/silinternational/cover-api/domain"
+)
+
+// SpreadsheetSuffix selects the XLSX rendering of a report format when appended to its name, e.g. "sage-xlsx"
+const SpreadsheetSuffix = "-xlsx"
+
+const (
+	spreadsheetTransactionsSheet = "Transactions"
+	spreadsheetSummarySheet      = "Summary"
+
+	// built-in Excel number format "#,##0.00"
+	spreadsheetAmountFormat = 4
+	spreadsheetDateFormat   = "yyyy-mm-dd"
+)
+
+var (
+	spreadsheetTransactionsHeader = []any{
+		"Block", "Date", "Account", "Amount", "Description", "Reference", "Type", "Policy Type", "Entity Code",
+		"Cost Center", "Risk Category",
+	}
+	spreadsheetSummaryHeader = []any{"Block", "Transactions", "Debits", "Credits", "Net"}
+)
+
+// Spreadsheet renders the transactions of another report format as an XLSX workbook. The accounts and references
+// are those of the wrapped format. Amounts and dates are typed cells rather than text, the header rows are frozen,
+// and a summary sheet gives the totals of each block.
+type Spreadsheet struct {
+	Title             string
+	TransactionBlocks TransactionBlocks
+
+	report     Report
+	blockNames []string
+	balances   blockBalances
+}
+
+// NewSpreadsheet returns an empty spreadsheet that takes its accounts and references from the given report
+func NewSpreadsheet(report Report, title string) *Spreadsheet {
+	return &Spreadsheet{
+		Title:             title,
+		TransactionBlocks: make(TransactionBlocks),
+		report:            report,
+		blockNames:        []string{},
+	}
+}
+
+// Spreadsheet returns the XLSX variant of the format
+func (f Format) Spreadsheet() Format {
+	return Format{
+		Name:          f.Name + SpreadsheetSuffix,
+		FileExtension: "xlsx",
+		ExportOnly:    f.ExportOnly,
+		New: func(batchDesc, reportType string, date time.Time) Report {
+			return NewSpreadsheet(f.New(batchDesc, reportType, date), batchDesc)
+		},
+	}
+}
+
+func (s *Spreadsheet) AppendToBatch(block string, t Transaction) {
+	s.balances.record(block, t)
+	if t.Amount == 0 {
+		return
+	}
+
+	if _, ok := s.TransactionBlocks[block]; !ok {
+		s.blockNames = append(s.blockNames, block)
+	}
+
+	s.TransactionBlocks[block] = append(s.TransactionBlocks[block], t)
+}
+
+func (s *Spreadsheet) RenderBatch() ([]byte, string) {
+	content, err := s.render()
+	if err != nil {
+		panic("fin: failed to render spreadsheet, " + err.Error())
+	}
+	return content, domain.ContentXLSX
+}
+
+func (s *Spreadsheet) RenderedBlocks() []RenderedBlock {
+	return renderedBlocks(s.blockNames, s.TransactionBlocks, s.balances)
+}
+
+func (s *Spreadsheet) getReference(t Transaction) string {
+	return s.report.getReference(t)
+}
+
+// getAccount uses the account of the wrapped format, if it has one
+func (s *Spreadsheet) getAccount(t Transaction) string {
+	switch r := s.report.(type) {
+	case interface{ getAccount(Transaction) string }:
+		return r.getAccount(t)
+	case interface{ getDebitAccount(Transaction) string }:
+		return r.getDebitAccount(t)
+	}
+	return t.Account
+}
+
+func (s *Spreadsheet) render() ([]byte, error) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := f.SetDocProps(&excelize.DocProperties{Title: s.Title, Creator: domain.Env.AppName}); err != nil {
+		return nil, err
+	}
+
+	if err := f.SetSheetName("Sheet1", spreadsheetTransactionsSheet); err != nil {
+		return nil, err
+	}
+	if _, err := f.NewSheet(spreadsheetSummarySheet); err != nil {
+		return nil, err
+	}
+
+	styles, err := newSpreadsheetStyles(f)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.renderTransactions(f, styles); err != nil {
+		return nil, err
+	}
+	if err := s.renderSummary(f, styles); err != nil {
+		return nil, err
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type spreadsheetStyles struct {
+	header, amount, date, totalAmount int
+}
+
+func newSpreadsheetStyles(f *excelize.File) (spreadsheetStyles, error) {
+	var styles spreadsheetStyles
+	var err error
+
+	dateFormat := spreadsheetDateFormat
+	bold := &excelize.Font{Bold: true}
+
+	if styles.header, err = f.NewStyle(&excelize.Style{Font: bold}); err != nil {
+		return styles, err
+	}
+	if styles.amount, err = f.NewStyle(&excelize.Style{NumFmt: spreadsheetAmountFormat}); err != nil {
+		return styles, err
+	}
+	if styles.date, err = f.NewStyle(&excelize.Style{CustomNumFmt: &dateFormat}); err != nil {
+		return styles, err
+	}
+	styles.totalAmount, err = f.NewStyle(&excelize.Style{Font: bold, NumFmt: spreadsheetAmountFormat})
+	return styles, err
+}
+
+// renderTransactions writes one row per transaction, block by block, with the debits positive and the credits
+// negative
+func (s *Spreadsheet) renderTransactions(f *excelize.File, styles spreadsheetStyles) error {
+	sheet := spreadsheetTransactionsSheet
+
+	if err := writeSpreadsheetHeader(f, sheet, spreadsheetTransactionsHeader, styles.header); err != nil {
+		return err
+	}
+
+	row := 1
+	for _, name := range s.blockNames {
+		for _, t := range s.TransactionBlocks[name] {
+			row++
+			values := []any{
+				spreadsheetBlockName(name),
+				t.Date,
+				s.getAccount(t),
+				spreadsheetAmount(-t.Amount),
+				t.Description,
+				s.getReference(t),
+				t.Type,
+				string(t.PolicyType),
+				t.EntityCode,
+				t.CostCenter,
+				t.RiskCategoryName,
+			}
+			if err := f.SetSheetRow(sheet, spreadsheetCell(1, row), &values); err != nil {
+				return err
+			}
+		}
+	}
+
+	if row > 1 {
+		if err := f.SetCellStyle(sheet, "B2", spreadsheetCell(2, row), styles.date); err != nil {
+			return err
+		}
+		if err := f.SetCellStyle(sheet, "D2", spreadsheetCell(4, row), styles.amount); err != nil {
+			return err
+		}
+	}
+
+	widths := map[string]float64{"A": 24, "B": 12, "C": 14, "D": 14, "E": 48, "F": 36}
+	for col, width := range widths {
+		if err := f.SetColWidth(sheet, col, col, width); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderSummary writes the totals of each block, followed by the grand total
+func (s *Spreadsheet) renderSummary(f *excelize.File, styles spreadsheetStyles) error {
+	sheet := spreadsheetSummarySheet
+
+	if err := writeSpreadsheetHeader(f, sheet, spreadsheetSummaryHeader, styles.header); err != nil {
+		return err
+	}
+
+	var total spreadsheetTotals
+	row := 1
+	for _, name := range s.blockNames {
+		var t spreadsheetTotals
+		for _, transaction := range s.TransactionBlocks[name] {
+			t.add(transaction)
+		}
+		total.addTotals(t)
+
+		row++
+		if err := f.SetSheetRow(sheet, spreadsheetCell(1, row), t.values(spreadsheetBlockName(name))); err != nil {
+			return err
+		}
+	}
+	if row > 1 {
+		if err := f.SetCellStyle(sheet, "C2", spreadsheetCell(5, row), styles.amount); err != nil {
+			return err
+		}
+	}
+
+	row++
+	if err := f.SetSheetRow(sheet, spreadsheetCell(1, row), total.values("Total")); err != nil {
+		return err
+	}
+	if err := f.SetCellStyle(sheet, spreadsheetCell(1, row), spreadsheetCell(2, row), styles.header); err != nil {
+		return err
+	}
+	if err := f.SetCellStyle(sheet, spreadsheetCell(3, row), spreadsheetCell(5, row), styles.totalAmount); err != nil {
+		return err
+	}
+
+	return f.SetColWidth(sheet, "A", "A", 24)
+}
+
+// writeSpreadsheetHeader writes the header row of a sheet and freezes it so it stays visible while scrolling
+func writeSpreadsheetHeader(f *excelize.File, sheet string, header []any, style int) error {
+	if err := f.SetSheetRow(sheet, "A1", &header); err != nil {
+		return err
+	}
+	if err := f.SetCellStyle(sheet, "A1", spreadsheetCell(len(header), 1), style); err != nil {
+		return err
+	}
+	return f.SetPanes(sheet, &excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	})
+}
+
+type spreadsheetTotals struct {
+	count   int
+	debits  int
+	credits int
+}
+
+func (t *spreadsheetTotals) add(transaction Transaction) {
+	t.count++
+	if amount := int(-transaction.Amount); amount > 0 {
+		t.debits += amount
+	} else {
+		t.credits -= amount
+	}
+}
+
+func (t *spreadsheetTotals) addTotals(other spreadsheetTotals) {
+	t.count += other.count
+	t.debits += other.debits
+	t.credits += other.credits
+}
+
+func (t *spreadsheetTotals) values(name string) *[]any {
+	return &[]any{
+		name,
+		t.count,
+		spreadsheetAmount(t.debits),
+		spreadsheetAmount(t.credits),
+		spreadsheetAmount(t.debits - t.credits),
+	}
+}
+
+// spreadsheetAmount converts an amount in cents to a number of dollars
+func spreadsheetAmount[T ~int](amount T) float64 {
+	return float64(amount) / domain.CurrencyFactor
+}
+
+func spreadsheetBlockName(block string) string {
+	if block == "" {
+		return "none"
+	}
+	return strings.ReplaceAll(block, "_", " ")
+}
+
+// spreadsheetCell returns the name of the cell in the given column and row, both starting at 1
+func spreadsheetCell(col, row int) string {
+	name, err := excelize.CoordinatesToCellName(col, row)
+	if err != nil {
+		panic(fmt.Sprintf("fin: invalid spreadsheet cell %d,%d", col, row))
+	}
+	return name
+}
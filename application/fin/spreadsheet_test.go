@@ -0,0 +1,91 @@
+package fin
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+func TestGetFormat_Spreadsheet(t *testing.T) {
+	for _, name := range Formats() {
+		f, err := GetFormat(name + SpreadsheetSuffix)
+		require.NoError(t, err, name)
+		require.Equal(t, name+SpreadsheetSuffix, f.Name)
+		require.Equal(t, "xlsx", f.FileExtension)
+	}
+
+	_, err := GetFormat(ReportFormatSage + SpreadsheetSuffix + SpreadsheetSuffix)
+	require.Error(t, err)
+}
+
+func TestSpreadsheet_RenderBatch(t *testing.T) {
+	date := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	balance := ""
+
+	format, err := GetFormat(ReportFormatSage + SpreadsheetSuffix)
+	require.NoError(t, err)
+
+	report := format.NewBatch("Monthly", date)
+	report.AppendToBatch("Household_Mobile", Transaction{
+		PolicyType:  api.PolicyTypeHousehold,
+		HouseholdID: "123456",
+		Name:        "Alice",
+		Amount:      -1234,
+		Description: "Premium",
+		Date:        date,
+	})
+	report.AppendToBatch("Household_Mobile", Transaction{Amount: 0, Date: date})
+	report.AppendToBatch("Household_Mobile", Transaction{
+		Account:     "40200",
+		Amount:      1234,
+		Description: "Total Household Mobile",
+		Reference:   &balance,
+		Date:        date,
+	})
+
+	content, contentType := report.RenderBatch()
+	require.Equal(t, domain.ContentXLSX, contentType)
+
+	f, err := excelize.OpenReader(bytes.NewReader(content))
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.Equal(t, []string{spreadsheetTransactionsSheet, spreadsheetSummarySheet}, f.GetSheetList())
+
+	rows, err := f.GetRows(spreadsheetTransactionsSheet)
+	require.NoError(t, err)
+	require.Len(t, rows, 3, "zero amounts should be skipped")
+	require.Equal(t, "MC 123456 / Alice", rows[1][5], "reference should be that of the wrapped format")
+	require.Equal(t, domain.Env.ExpenseAccount, rows[1][2])
+	require.Equal(t, "40200", rows[2][2])
+	require.Equal(t, "Household Mobile", rows[1][0])
+
+	amount, err := f.GetCellValue(spreadsheetTransactionsSheet, "D2", excelize.Options{RawCellValue: true})
+	require.NoError(t, err)
+	require.Equal(t, "12.34", amount, "amount should be a number")
+
+	formatted, err := f.GetCellValue(spreadsheetTransactionsSheet, "B2")
+	require.NoError(t, err)
+	require.Equal(t, "2021-03-01", formatted, "date should be a date cell")
+
+	for _, sheet := range []string{spreadsheetTransactionsSheet, spreadsheetSummarySheet} {
+		panes, err := f.GetPanes(sheet)
+		require.NoError(t, err)
+		require.True(t, panes.Freeze, "header of %s should be frozen", sheet)
+		require.Equal(t, 1, panes.YSplit)
+	}
+
+	summary, err := f.GetRows(spreadsheetSummarySheet, excelize.Options{RawCellValue: true})
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"Block", "Transactions", "Debits", "Credits", "Net"},
+		{"Household Mobile", "2", "12.34", "12.34", "0"},
+		{"Total", "2", "12.34", "12.34", "0"},
+	}, summary)
+}
@@ -0,0 +1,46 @@
+package job
+
+import (
+	"github.com/gobuffalo/buffalo/worker"
+	"github.com/gobuffalo/pop/v6"
+
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/log"
+	"github.com/silinternational/cover-api/messages"
+	"github.com/silinternational/cover-api/models"
+)
+
+// householdIDValidationHandler is the Worker handler for re-checking household and staff IDs against the
+// household ID lookup service. The stewards are notified of anything that no longer matches.
+func householdIDValidationHandler(_ worker.Args) error {
+	if domain.Env.HouseholdIDLookupURL == "" {
+		log.Info("household ID lookup is not configured, skipping household ID validation")
+		return nil
+	}
+
+	ctx := createJobContext()
+
+	return models.DB.Transaction(func(tx *pop.Connection) error {
+		ctx.Set(domain.ContextKeyTx, tx)
+
+		v, err := models.ValidateHouseholdIDs(ctx)
+		if err != nil {
+			return err
+		}
+
+		log.WithFields(map[string]any{
+			"users_checked":      v.UsersChecked,
+			"policies_checked":   v.PoliciesChecked,
+			"lookup_failures":    v.LookupFailures,
+			"mismatches":         len(v.Mismatches),
+			"terminated_staff":   len(v.TerminatedStaff),
+			"invalid_policies":   len(v.InvalidPolicies),
+			"corrected_policies": len(v.CorrectedPolicies),
+		}).Info("household ID validation")
+
+		if v.HasFindings() {
+			messages.HouseholdIDValidationQueueMessage(tx, v)
+		}
+		return nil
+	})
+}
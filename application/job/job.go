@@ -1,7 +1,7 @@
 package job
 
 import (
-	"os"
+	"fmt"
 	"runtime/debug"
 	"time"
 
@@ -19,17 +19,27 @@ const (
 )
 
 const (
-	InactivateItems = "inactivate_items"
-	AnnualRenewal   = "annual_renewal"
-	MonthlyRenewal  = "monthly_renewal"
+	InactivateItems   = "inactivate_items"
+	AnnualRenewal     = "annual_renewal"
+	MonthlyRenewal    = "monthly_renewal"
+	MonthlyStatements = "monthly_statements"
+	MonthEndReserves  = "month_end_reserves"
+
+	HouseholdIDValidation = "household_id_validation"
+	StrikeExpiry          = "strike_expiry"
 )
 
 var w *worker.Worker
 
 var handlers = map[string]func(worker.Args) error{
-	InactivateItems: inactivateItemsHandler,
-	AnnualRenewal:   annualRenewalHandler,
-	MonthlyRenewal:  monthlyRenewalHandler,
+	InactivateItems:   inactivateItemsHandler,
+	AnnualRenewal:     annualRenewalHandler,
+	MonthlyRenewal:    monthlyRenewalHandler,
+	MonthlyStatements: monthlyStatementsHandler,
+	MonthEndReserves:  monthEndReservesHandler,
+
+	HouseholdIDValidation: householdIDValidationHandler,
+	StrikeExpiry:          strikeExpiryHandler,
 }
 
 // jobBuffaloContext is a buffalo context for jobs
@@ -65,19 +75,12 @@ func Init(appWorker *worker.Worker) {
 	if err := (*w).Register(handlerKey, mainHandler); err != nil {
 		log.Errorf("error registering '%s' handler, %s", handlerKey, err)
 	}
-
-	delay := time.Second * 10
-
-	// Kick off first run of inactivating items between 1h11 and 3h27 from now
-	if domain.Env.GoEnv != domain.EnvDevelopment {
-		randMins := time.Duration(domain.RandomInsecureIntInRange(71, 387))
-		delay = randMins * time.Minute
+	if err := (*w).Register(schedulerKey, schedulerHandler); err != nil {
+		log.Errorf("error registering '%s' handler, %s", schedulerKey, err)
 	}
 
-	if err := SubmitDelayed(InactivateItems, delay, map[string]any{}); err != nil {
-		log.Error("error initializing InactivateItems job:", err)
-		os.Exit(1)
-	}
+	// The periodic jobs are started by the scheduler, according to the job_schedules table
+	submitScheduler(time.Second * 10)
 }
 
 func mainHandler(args worker.Args) error {
@@ -86,20 +89,34 @@ func mainHandler(args worker.Args) error {
 	log.Infof("starting %s job", jobType)
 	start := time.Now().UTC()
 
-	defer func() {
-		if err := recover(); err != nil {
-			log.Errorf("panic in job handler %s: %s\n%s", jobType, err, debug.Stack())
-		}
-	}()
+	run, err := startJobRun(jobType, args)
+	if err != nil {
+		log.Errorf("batch job %s not started: %s", jobType, err)
+		return nil
+	}
 
-	if err := handlers[jobType](args); err != nil {
+	err = runHandler(jobType, args)
+	if err != nil {
 		log.Errorf("batch job %s failed: %s", jobType, err)
 	}
+	finishJobRun(run, err)
 
 	log.Infof("completed %s job in %s seconds", jobType, time.Since(start))
 	return nil
 }
 
+// runHandler runs the handler of the job type. A panic in the handler is returned as an error.
+func runHandler(jobType string, args worker.Args) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("panic in job handler %s: %s\n%s", jobType, r, debug.Stack())
+			err = fmt.Errorf("panic in job handler: %v", r)
+		}
+	}()
+
+	return handlers[jobType](args)
+}
+
 // Submit enqueues a new Worker job for the given job type. Arguments can be provided in `args`.
 func Submit(jobType string, args map[string]any) error {
 	if domain.Env.GoEnv == domain.EnvTest {
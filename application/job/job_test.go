@@ -0,0 +1,35 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+type JobSuite struct {
+	suite.Suite
+	*require.Assertions
+	DB *pop.Connection
+}
+
+func (js *JobSuite) SetupTest() {
+	js.Assertions = require.New(js.T())
+	models.DestroyAll()
+	models.InsertTestData()
+}
+
+// Test_JobSuite runs the test suite
+func Test_JobSuite(t *testing.T) {
+	js := &JobSuite{}
+	c, err := pop.Connect(domain.Env.GoEnv)
+	if err == nil {
+		models.DB = c
+		js.DB = c
+	}
+	suite.Run(t, js)
+}
@@ -0,0 +1,31 @@
+package job
+
+import (
+	"time"
+
+	"github.com/gobuffalo/buffalo/worker"
+	"github.com/gobuffalo/pop/v6"
+
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+// monthEndReservesHandler is the Worker handler for archiving the claim reserves of the month that just ended. The
+// reserves are estimated when the job runs, so the job runs every day and does nothing if the report exists.
+func monthEndReservesHandler(_ worker.Args) error {
+	now := time.Now().UTC()
+	lastMonthEnd := time.Date(now.Year(), now.Month(), 0, 0, 0, 0, 0, time.UTC)
+
+	exists, err := models.ClaimReserveReportExists(models.DB, lastMonthEnd)
+	if err != nil || exists {
+		return err
+	}
+
+	ctx := createJobContext()
+
+	return models.DB.Transaction(func(tx *pop.Connection) error {
+		ctx.Set(domain.ContextKeyTx, tx)
+		_, err := models.NewClaimReserveReport(ctx, lastMonthEnd)
+		return err
+	})
+}
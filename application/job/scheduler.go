@@ -0,0 +1,216 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gobuffalo/buffalo/worker"
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+	"github.com/robfig/cron/v3"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/log"
+	"github.com/silinternational/cover-api/models"
+)
+
+const (
+	schedulerKey = "job_scheduler"
+	argJobRunID  = "job_run_id"
+
+	// schedulerInterval is the time between checks for scheduled jobs that are due
+	schedulerInterval = time.Minute
+
+	// jobLockDuration is the longest a job is expected to run. If an instance stops while running a job, the job
+	// can be run by another instance after this time.
+	jobLockDuration = time.Hour * 6
+)
+
+// instanceID identifies this instance of the app in the job schedule locks and the job run records
+var instanceID = newInstanceID()
+
+func newInstanceID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
+}
+
+// schedulerHandler is the Worker handler that starts the scheduled jobs that are due. Every instance of the app runs
+// the scheduler, but a due job is claimed by only one instance, which runs it.
+func schedulerHandler(_ worker.Args) error {
+	defer submitScheduler(schedulerInterval)
+
+	now := time.Now().UTC()
+
+	var schedules models.JobSchedules
+	if err := schedules.FindDue(models.DB, now); err != nil {
+		log.Error("error finding scheduled jobs:", err)
+		return nil
+	}
+
+	for _, s := range schedules {
+		if err := startScheduledJob(s, now); err != nil {
+			log.Errorf("error starting scheduled job %s: %s", s.JobType, err)
+		}
+	}
+	return nil
+}
+
+// startScheduledJob claims a due job for this instance and submits it to the worker. A schedule that has no next run
+// time is only given one.
+func startScheduledJob(s models.JobSchedule, now time.Time) error {
+	if _, ok := handlers[s.JobType]; !ok {
+		return fmt.Errorf("no handler for job type %s", s.JobType)
+	}
+
+	next, err := nextRun(s.Schedule, now)
+	if err != nil {
+		return err
+	}
+
+	if !s.NextRunAt.Valid {
+		return s.SetNextRun(models.DB, next)
+	}
+
+	claimed, err := s.ClaimDue(models.DB, instanceID, now, next, now.Add(jobLockDuration))
+	if err != nil || !claimed {
+		return err
+	}
+
+	run := models.JobRun{
+		JobScheduleID: s.ID,
+		Trigger:       api.JobRunTriggerSchedule,
+		Result:        api.JobRunResultQueued,
+	}
+	if err := run.Create(models.DB); err != nil {
+		_ = s.Unlock(models.DB, instanceID)
+		return err
+	}
+
+	return Submit(s.JobType, map[string]any{argJobRunID: run.ID.String()})
+}
+
+// nextRun returns the first time after the given time that matches the cron expression
+func nextRun(schedule string, after time.Time) (time.Time, error) {
+	sched, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid job schedule %q: %w", schedule, err)
+	}
+	return sched.Next(after).UTC(), nil
+}
+
+// Trigger submits a run of the job to the worker on behalf of the current user. The job is run even if its schedule
+// is not enabled, but not while another run of the job is in progress. The run is saved outside of the request
+// transaction so the worker can find it.
+func Trigger(ctx context.Context, jobType string) (models.JobRun, error) {
+	tx := models.DB
+
+	var schedule models.JobSchedule
+	if err := schedule.FindByJobType(tx, jobType); err != nil {
+		return models.JobRun{}, err
+	}
+
+	if schedule.IsLocked(time.Now().UTC()) {
+		err := fmt.Errorf("job %s is already running on %s", jobType, schedule.LockedBy)
+		return models.JobRun{}, api.NewAppError(err, api.ErrorJobRunning, api.CategoryUser)
+	}
+
+	run := models.JobRun{
+		JobScheduleID: schedule.ID,
+		Trigger:       api.JobRunTriggerManual,
+		TriggeredByID: nulls.NewUUID(models.CurrentUser(ctx).ID),
+		Result:        api.JobRunResultQueued,
+	}
+	if err := run.Create(tx); err != nil {
+		return models.JobRun{}, err
+	}
+
+	if err := Submit(jobType, map[string]any{argJobRunID: run.ID.String()}); err != nil {
+		return models.JobRun{}, api.NewAppError(err, api.ErrorFailedToSubmitJob, api.CategoryInternal)
+	}
+	return run, nil
+}
+
+// startJobRun records the start of a run of the job. A scheduled run holds the lock of the job schedule, which was
+// taken when the scheduler claimed it. Other runs take the lock here, and fail if another run is in progress. A job
+// that has no schedule is not recorded and the returned run is nil.
+func startJobRun(jobType string, args worker.Args) (*models.JobRun, error) {
+	now := time.Now().UTC()
+
+	var run models.JobRun
+	if id, ok := args[argJobRunID].(string); ok {
+		if err := run.FindByID(models.DB, uuid.FromStringOrNil(id)); err != nil {
+			return nil, fmt.Errorf("job run %s not found: %w", id, err)
+		}
+		run.LoadJobSchedule(models.DB, false)
+	} else {
+		var schedule models.JobSchedule
+		if err := schedule.FindByJobType(models.DB, jobType); err != nil {
+			if domain.IsOtherThanNoRows(err) {
+				return nil, err
+			}
+			return nil, nil
+		}
+		run = models.JobRun{
+			JobScheduleID: schedule.ID,
+			Trigger:       api.JobRunTriggerSubmit,
+			Result:        api.JobRunResultQueued,
+			JobSchedule:   schedule,
+		}
+		if err := run.Create(models.DB); err != nil {
+			return nil, err
+		}
+	}
+
+	if run.Trigger != api.JobRunTriggerSchedule {
+		locked, err := run.JobSchedule.Lock(models.DB, instanceID, now, now.Add(jobLockDuration))
+		if err != nil {
+			return nil, err
+		}
+		if !locked {
+			err := fmt.Errorf("job %s is already running on %s", jobType, run.JobSchedule.LockedBy)
+			_ = run.Finish(models.DB, now, err)
+			return nil, err
+		}
+	}
+
+	if err := run.Start(models.DB, instanceID, now); err != nil {
+		_ = run.JobSchedule.Unlock(models.DB, instanceID)
+		return nil, err
+	}
+	return &run, nil
+}
+
+// finishJobRun records the result of the run and releases the lock of the job schedule
+func finishJobRun(run *models.JobRun, runErr error) {
+	if run == nil {
+		return
+	}
+
+	if err := run.Finish(models.DB, time.Now().UTC(), runErr); err != nil {
+		log.Errorf("error recording the result of job run %s: %s", run.ID, err)
+	}
+	if err := run.JobSchedule.Unlock(models.DB, instanceID); err != nil {
+		log.Errorf("error unlocking job schedule %s: %s", run.JobSchedule.JobType, err)
+	}
+}
+
+// submitScheduler enqueues the next check for scheduled jobs
+func submitScheduler(delay time.Duration) {
+	if domain.Env.GoEnv == domain.EnvTest {
+		return
+	}
+	job := worker.Job{
+		Queue:   "default",
+		Args:    worker.Args{},
+		Handler: schedulerKey,
+	}
+	if err := (*w).PerformIn(job, delay); err != nil {
+		log.Error("error submitting job scheduler:", err)
+	}
+}
@@ -0,0 +1,46 @@
+package job
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gobuffalo/buffalo/worker"
+	"github.com/gobuffalo/pop/v6"
+
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/log"
+	"github.com/silinternational/cover-api/models"
+)
+
+// monthlyStatementsHandler is the Worker handler for creating the statements of the previous month. Policies
+// that already have a statement are skipped, so the job can run every day and will catch up after a failure.
+func monthlyStatementsHandler(_ worker.Args) error {
+	now := time.Now().UTC()
+	lastMonth := now.AddDate(0, 0, -now.Day())
+	month, year := int(lastMonth.Month()), lastMonth.Year()
+
+	var policies models.Policies
+	if err := policies.FindNeedingStatement(models.DB, month, year); err != nil {
+		return err
+	}
+
+	ctx := createJobContext()
+
+	var failures int
+	for _, policy := range policies {
+		err := models.DB.Transaction(func(tx *pop.Connection) error {
+			ctx.Set(domain.ContextKeyTx, tx)
+			_, err := models.NewPolicyStatement(ctx, policy, month, year)
+			return err
+		})
+		if err != nil {
+			log.Errorf("error creating statement for policy %s: %s", policy.ID, err)
+			failures++
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("failed to create %d of %d policy statements", failures, len(policies))
+	}
+	return nil
+}
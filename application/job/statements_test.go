@@ -0,0 +1,31 @@
+package job
+
+import (
+	"time"
+
+	"github.com/gobuffalo/buffalo/worker"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (js *JobSuite) Test_monthlyStatementsHandler() {
+	f := models.CreateItemFixtures(js.DB, models.FixturesConfig{NumberOfPolicies: 3, ItemsPerPolicy: 1})
+
+	// the first two policies have covered items, the third does not
+	models.UpdateItemStatus(js.DB, f.Items[0], api.ItemCoverageStatusApproved, "")
+	models.UpdateItemStatus(js.DB, f.Items[1], api.ItemCoverageStatusApproved, "")
+
+	now := time.Now().UTC()
+	lastMonth := now.AddDate(0, 0, -now.Day())
+	statementDate := time.Date(lastMonth.Year(), lastMonth.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	// the job runs every day, so a second run must skip the policies that already have a statement
+	for run := 1; run <= 2; run++ {
+		js.NoError(monthlyStatementsHandler(worker.Args{}), "run %d failed", run)
+
+		n, err := js.DB.Where("statement_date = ?", statementDate).Count(&models.PolicyStatements{})
+		js.NoError(err)
+		js.Equal(2, n, "incorrect number of statements after run %d", run)
+	}
+}
@@ -0,0 +1,22 @@
+package job
+
+import (
+	"time"
+
+	"github.com/gobuffalo/buffalo/worker"
+	"github.com/gobuffalo/pop/v6"
+
+	"github.com/silinternational/cover-api/log"
+	"github.com/silinternational/cover-api/models"
+)
+
+// strikeExpiryHandler is the Worker handler for notifying policy members of strikes that have aged out
+func strikeExpiryHandler(_ worker.Args) error {
+	return models.DB.Transaction(func(tx *pop.Connection) error {
+		n, err := models.NotifyExpiredStrikes(tx, time.Now().UTC())
+		if n > 0 {
+			log.Infof("%d strikes expired", n)
+		}
+		return err
+	})
+}
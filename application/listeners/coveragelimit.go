@@ -0,0 +1,25 @@
+package listeners
+
+import (
+	"github.com/gobuffalo/events"
+	"github.com/gobuffalo/pop/v6"
+
+	"github.com/silinternational/cover-api/log"
+	"github.com/silinternational/cover-api/messages"
+	"github.com/silinternational/cover-api/models"
+)
+
+func coverageLimitPending(e events.Event) {
+	var limit models.CoverageLimit
+	if err := findObject(e.Payload, &limit, e.Kind); err != nil {
+		return
+	}
+
+	err := models.DB.Transaction(func(tx *pop.Connection) error {
+		messages.CoverageLimitPendingQueueMessage(tx, limit)
+		return nil
+	})
+	if err != nil {
+		log.Error("error queuing coverage limit pending messages:", err)
+	}
+}
@@ -97,3 +97,37 @@ func itemDenied(e events.Event) {
 		return nil
 	})
 }
+
+func itemPendingBudget(e events.Event) {
+	var item models.Item
+	if err := findObject(e.Payload, &item, e.Kind); err != nil {
+		return
+	}
+
+	if item.CoverageStatus != api.ItemCoverageStatusPendingBudget {
+		log.Errorf(wrongStatusMsg, "itemPendingBudget", item.CoverageStatus)
+		return
+	}
+
+	models.DB.Transaction(func(tx *pop.Connection) error {
+		messages.ItemPendingBudgetQueueMessage(tx, item)
+		return nil
+	})
+}
+
+func itemBudgetRejected(e events.Event) {
+	var item models.Item
+	if err := findObject(e.Payload, &item, e.Kind); err != nil {
+		return
+	}
+
+	if item.CoverageStatus != api.ItemCoverageStatusRevision {
+		log.Errorf(wrongStatusMsg, "itemBudgetRejected", item.CoverageStatus)
+		return
+	}
+
+	models.DB.Transaction(func(tx *pop.Connection) error {
+		messages.ItemBudgetRejectedQueueMessage(tx, item)
+		return nil
+	})
+}
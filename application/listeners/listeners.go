@@ -19,23 +19,34 @@ import (
 const EventPayloadNotifier = "notifier"
 
 var eventTypes = map[string]func(event events.Event){
-	domain.EventApiItemAutoApproved:        itemAutoApproved,
-	domain.EventApiUserCreated:             userCreated,
-	domain.EventApiItemSubmitted:           itemSubmitted,
-	domain.EventApiItemRevision:            itemRevision,
-	domain.EventApiItemApproved:            itemApproved,
-	domain.EventApiItemDenied:              itemDenied,
-	domain.EventApiClaimReview1:            claimReview1,
-	domain.EventApiClaimRevision:           claimRevision,
-	domain.EventApiClaimPreapproved:        claimPreapproved,
-	domain.EventApiClaimReceipt:            claimReceipt,
-	domain.EventApiClaimReview2:            claimReview2,
-	domain.EventApiClaimReview3:            claimReview3,
-	domain.EventApiClaimApproved:           claimApproved,
-	domain.EventApiClaimDenied:             claimDenied,
-	domain.EventApiNotificationCreated:     notificationCreated,
-	domain.EventApiPolicyUserInviteCreated: policyUserInviteCreated,
-	domain.EventApiPolicyUserInviteExpired: policyUserInviteExpired,
+	domain.EventApiItemAutoApproved:         itemAutoApproved,
+	domain.EventApiUserCreated:              userCreated,
+	domain.EventApiItemSubmitted:            itemSubmitted,
+	domain.EventApiItemRevision:             itemRevision,
+	domain.EventApiItemApproved:             itemApproved,
+	domain.EventApiItemDenied:               itemDenied,
+	domain.EventApiItemPendingBudget:        itemPendingBudget,
+	domain.EventApiItemBudgetRejected:       itemBudgetRejected,
+	domain.EventApiClaimReview1:             claimReview1,
+	domain.EventApiClaimRevision:            claimRevision,
+	domain.EventApiClaimPreapproved:         claimPreapproved,
+	domain.EventApiClaimReceipt:             claimReceipt,
+	domain.EventApiClaimReview2:             claimReview2,
+	domain.EventApiClaimReview3:             claimReview3,
+	domain.EventApiClaimApproved:            claimApproved,
+	domain.EventApiClaimDenied:              claimDenied,
+	domain.EventApiCoverageLimitPending:     coverageLimitPending,
+	domain.EventApiNotificationCreated:      notificationCreated,
+	domain.EventApiPolicyClosed:             policyClosed,
+	domain.EventApiPolicyStatementCreated:   policyStatementCreated,
+	domain.EventApiPolicyUserInviteCreated:  policyUserInviteCreated,
+	domain.EventApiPolicyUserInviteExpired:  policyUserInviteExpired,
+	domain.EventApiPolicyUserInviteResent:   policyUserInviteCreated,
+	domain.EventApiPolicyUserInviteExtended: policyUserInviteCreated,
+	domain.EventApiPolicyUserInviteRevoked:  policyUserInviteRevoked,
+	domain.EventApiStrikeCreated:            strikeCreated,
+	domain.EventApiStrikeProposed:           strikeProposed,
+	domain.EventApiStrikeExpired:            strikeExpired,
 }
 
 func notificationCreated(e events.Event) {
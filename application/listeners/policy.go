@@ -3,12 +3,43 @@ package listeners
 import (
 	"github.com/gobuffalo/events"
 	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
 
 	"github.com/silinternational/cover-api/log"
 	"github.com/silinternational/cover-api/messages"
 	"github.com/silinternational/cover-api/models"
 )
 
+func policyClosed(e events.Event) {
+	var policy models.Policy
+	if err := findObject(e.Payload, &policy, e.Kind); err != nil {
+		return
+	}
+
+	err := models.DB.Transaction(func(tx *pop.Connection) error {
+		messages.PolicyClosedQueueMessage(tx, policy)
+		return nil
+	})
+	if err != nil {
+		log.Error("error queuing policy closed messages:", err)
+	}
+}
+
+func policyStatementCreated(e events.Event) {
+	var statement models.PolicyStatement
+	if err := findObject(e.Payload, &statement, e.Kind); err != nil {
+		return
+	}
+
+	err := models.DB.Transaction(func(tx *pop.Connection) error {
+		messages.PolicyStatementQueueMessage(tx, statement)
+		return nil
+	})
+	if err != nil {
+		log.Error("error queuing policy statement messages:", err)
+	}
+}
+
 func policyUserInviteCreated(e events.Event) {
 	var invite models.PolicyUserInvite
 	if err := findObject(e.Payload, &invite, e.Kind); err != nil {
@@ -37,3 +68,30 @@ func policyUserInviteExpired(e events.Event) {
 		log.Error("error destroying expired policy user invite:", err)
 	}
 }
+
+func policyUserInviteRevoked(e events.Event) {
+	// the invite has already been destroyed, so its details are carried in the payload
+	policyID, _ := e.Payload["policy_id"].(uuid.UUID)
+	email, _ := e.Payload["email"].(string)
+	inviteeName, _ := e.Payload["invitee_name"].(string)
+	inviterName, _ := e.Payload["inviter_name"].(string)
+	if policyID == uuid.Nil || email == "" {
+		log.Errorf("invalid payload in %s event: %v", e.Kind, e.Payload)
+		return
+	}
+
+	invite := models.PolicyUserInvite{
+		PolicyID:    policyID,
+		Email:       email,
+		InviteeName: inviteeName,
+		InviterName: inviterName,
+	}
+
+	err := models.DB.Transaction(func(tx *pop.Connection) error {
+		messages.PolicyUserInviteRevokedQueueMessage(tx, invite)
+		return nil
+	})
+	if err != nil {
+		log.Error("error queuing policy user invite revoked message:", err)
+	}
+}
@@ -0,0 +1,55 @@
+package listeners
+
+import (
+	"github.com/gobuffalo/events"
+	"github.com/gobuffalo/pop/v6"
+
+	"github.com/silinternational/cover-api/log"
+	"github.com/silinternational/cover-api/messages"
+	"github.com/silinternational/cover-api/models"
+)
+
+func strikeCreated(e events.Event) {
+	var strike models.Strike
+	if err := findObject(e.Payload, &strike, e.Kind); err != nil {
+		return
+	}
+
+	err := models.DB.Transaction(func(tx *pop.Connection) error {
+		messages.StrikeCreatedQueueMessage(tx, strike)
+		return nil
+	})
+	if err != nil {
+		log.Error("error queuing strike created messages:", err)
+	}
+}
+
+func strikeProposed(e events.Event) {
+	var strike models.Strike
+	if err := findObject(e.Payload, &strike, e.Kind); err != nil {
+		return
+	}
+
+	err := models.DB.Transaction(func(tx *pop.Connection) error {
+		messages.StrikeProposedQueueMessage(tx, strike)
+		return nil
+	})
+	if err != nil {
+		log.Error("error queuing strike proposed messages:", err)
+	}
+}
+
+func strikeExpired(e events.Event) {
+	var strike models.Strike
+	if err := findObject(e.Payload, &strike, e.Kind); err != nil {
+		return
+	}
+
+	err := models.DB.Transaction(func(tx *pop.Connection) error {
+		messages.StrikeExpiredQueueMessage(tx, strike)
+		return nil
+	})
+	if err != nil {
+		log.Error("error queuing strike expired messages:", err)
+	}
+}
@@ -0,0 +1,57 @@
+package messages
+
+import (
+	"fmt"
+
+	"github.com/gobuffalo/pop/v6"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+// CoverageLimitPendingQueueMessage queues messages to the signators to notify them that a
+// coverage limit override needs their approval
+func CoverageLimitPendingQueueMessage(tx *pop.Connection, limit models.CoverageLimit) {
+	data := newEmailMessageData()
+	data.addStewardData(tx)
+
+	var creator models.User
+	if err := creator.FindByID(tx, limit.CreatedByID); err != nil {
+		panic("error finding coverage limit creator: " + err.Error())
+	}
+
+	data["limitURL"] = domain.Env.UIURL
+	if limit.PolicyID.Valid {
+		var policy models.Policy
+		if err := policy.FindByID(tx, limit.PolicyID.UUID); err != nil {
+			panic("error finding coverage limit policy: " + err.Error())
+		}
+		data["limitTarget"] = "policy " + policy.Name
+		data["limitURL"] = fmt.Sprintf("%s/policies/%s", domain.Env.UIURL, policy.ID)
+	} else {
+		var entityCode models.EntityCode
+		if err := entityCode.FindByID(tx, limit.EntityCodeID.UUID); err != nil {
+			panic("error finding coverage limit entity code: " + err.Error())
+		}
+		data["limitTarget"] = "entity code " + entityCode.Code
+	}
+
+	data["maxCoverage"] = "$" + api.Currency(limit.MaxCoverage).String()
+	data["expirationDate"] = limit.ExpirationDate.Format(domain.LocalizedDate)
+	data["reason"] = limit.Reason
+	data["creatorName"] = creator.Name()
+
+	notn := models.Notification{
+		Body:          data.renderHTML(MessageTemplateCoverageLimitPendingSignator),
+		Subject:       "Coverage limit override needs approval",
+		InappText:     "A coverage limit override is waiting for your approval",
+		Event:         "Coverage Limit Pending Notification",
+		EventCategory: "CoverageLimit",
+	}
+	if err := notn.Create(tx); err != nil {
+		panic("error creating new Coverage Limit Pending Notification: " + err.Error())
+	}
+
+	notn.CreateNotificationUsersForSignators(tx)
+}
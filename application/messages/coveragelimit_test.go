@@ -0,0 +1,57 @@
+package messages
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (ts *TestSuite) Test_CoverageLimitPendingQueueMessage() {
+	t := ts.T()
+	db := ts.DB
+
+	admins := models.CreateAdminUsers(db)
+	steward := admins[models.AppRoleSteward]
+	signator := admins[models.AppRoleSignator]
+
+	f := models.CreatePolicyFixtures(db, models.FixturesConfig{})
+	policy := f.Policies[0]
+
+	limit := models.CoverageLimit{
+		PolicyID:       nulls.NewUUID(policy.ID),
+		MaxCoverage:    domain.Env.CoverageLimitApprovalThreshold + 100,
+		ExpirationDate: time.Now().UTC().AddDate(0, 6, 0),
+		Reason:         "expensive medical equipment",
+		CreatedByID:    steward.ID,
+	}
+	ts.NoError(limit.Create(db))
+
+	tests := []testData{
+		{
+			name:                  "policy limit",
+			wantToEmails:          []any{signator.EmailOfChoice()},
+			wantSubjectContains:   "Coverage limit override needs approval",
+			wantInappTextContains: "A coverage limit override is waiting for your approval",
+			wantBodyContains: []string{
+				domain.Env.UIURL,
+				policy.Name,
+				steward.Name(),
+				limit.Reason,
+				api.Currency(limit.MaxCoverage).String(),
+				limit.ExpirationDate.Format(domain.LocalizedDate),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			CoverageLimitPendingQueueMessage(db, limit)
+			validateNotificationUsers(ts, db, tt)
+		})
+	}
+}
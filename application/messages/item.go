@@ -167,3 +167,55 @@ func ItemDeniedQueueMessage(tx *pop.Connection, item models.Item) {
 		notn.CreateNotificationUserForUser(tx, m)
 	}
 }
+
+// ItemPendingBudgetQueueMessage queues messages to the approvers of an item's team policy to
+//  notify them that new coverage needs their budget approval
+func ItemPendingBudgetQueueMessage(tx *pop.Connection, item models.Item) {
+	item.LoadPolicyMembers(tx, false)
+
+	data := newEmailMessageData()
+	data.addItemData(tx, item)
+	data["memberName"] = item.Policy.Members[0].Name()
+
+	notn := models.Notification{
+		ItemID:        nulls.NewUUID(item.ID),
+		Body:          data.renderHTML(MessageTemplateItemPendingBudgetApprover),
+		Subject:       "Coverage Needs Budget Approval: " + item.Name,
+		InappText:     "New coverage on a team policy is waiting for your budget approval",
+		Event:         "Item Pending Budget Notification",
+		EventCategory: EventCategoryItem,
+	}
+	if err := notn.Create(tx); err != nil {
+		panic("error creating new Item Pending Budget Notification: " + err.Error())
+	}
+
+	for _, approver := range item.Policy.GetApprovers(tx) {
+		approver.LoadUser(tx, false)
+		notn.CreateNotificationUserForUser(tx, approver.User)
+	}
+}
+
+// ItemBudgetRejectedQueueMessage queues messages to an item's members to
+//  notify them that an approver did not accept the cost of the coverage
+func ItemBudgetRejectedQueueMessage(tx *pop.Connection, item models.Item) {
+	item.LoadPolicyMembers(tx, false)
+
+	data := newEmailMessageData()
+	data.addItemData(tx, item)
+
+	notn := models.Notification{
+		ItemID:        nulls.NewUUID(item.ID),
+		Body:          data.renderHTML(MessageTemplateItemBudgetRejectedMember),
+		Subject:       "Coverage Not Approved for Budget",
+		InappText:     "coverage on your policy item was not approved for the budget",
+		Event:         "Item Budget Rejected Notification",
+		EventCategory: EventCategoryItem,
+	}
+	if err := notn.Create(tx); err != nil {
+		panic("error creating new Item Budget Rejected Notification: " + err.Error())
+	}
+
+	for _, m := range item.Policy.Members {
+		notn.CreateNotificationUserForUser(tx, m)
+	}
+}
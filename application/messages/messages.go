@@ -39,8 +39,22 @@ const (
 	MessageTemplateItemRevisionMember = "item_revision_member"
 	MessageTemplateItemDeniedMember   = "item_denied_member"
 
-	MessageTemplatePolicyUserInvite = "policy_user_invite"
-	MessageTemplateUserWelcome      = "user_welcome"
+	MessageTemplateItemPendingBudgetApprover = "item_pending_budget_approver"
+	MessageTemplateItemBudgetRejectedMember  = "item_budget_rejected_member"
+
+	MessageTemplateCoverageLimitPendingSignator = "coverage_limit_pending_signator"
+
+	MessageTemplateHouseholdIDValidationSteward = "household_id_validation_steward"
+
+	MessageTemplatePolicyClosedMember      = "policy_closed_member"
+	MessageTemplatePolicyStatementMember   = "policy_statement_member"
+	MessageTemplatePolicyUserInvite        = "policy_user_invite"
+	MessageTemplatePolicyUserInviteRevoked = "policy_user_invite_revoked"
+	MessageTemplateUserWelcome             = "user_welcome"
+
+	MessageTemplateStrikeCreatedMember   = "strike_created_member"
+	MessageTemplateStrikeProposedSteward = "strike_proposed_steward"
+	MessageTemplateStrikeExpiredMember   = "strike_expired_member"
 )
 
 const (
@@ -3,6 +3,7 @@ package messages
 import (
 	"fmt"
 	"html/template"
+	"strconv"
 
 	"github.com/gobuffalo/nulls"
 	"github.com/gobuffalo/pop/v6"
@@ -48,3 +49,146 @@ func PolicyUserInviteQueueMessage(tx *pop.Connection, invite models.PolicyUserIn
 	}
 
 }
+
+// PolicyUserInviteRevokedQueueMessage queues a message to a person whose invite to a policy has been revoked
+func PolicyUserInviteRevokedQueueMessage(tx *pop.Connection, invite models.PolicyUserInvite) {
+	invite.LoadPolicy(tx, false)
+
+	data := newEmailMessageData()
+	data.addStewardData(tx)
+
+	data["inviteeName"] = invite.InviteeName
+	data["inviterName"] = invite.InviterName
+	data["policy"] = invite.Policy
+
+	notn := models.Notification{
+		PolicyID:      nulls.NewUUID(invite.PolicyID),
+		Body:          data.renderHTML(MessageTemplatePolicyUserInviteRevoked),
+		Subject:       fmt.Sprintf("Invitation to %s policy on %s withdrawn", invite.Policy.Name, domain.Env.AppName),
+		Event:         "Policy User Invite Revoked Notification",
+		EventCategory: "PolicyUserInvite",
+	}
+	if err := notn.Create(tx); err != nil {
+		panic("error creating new Policy User Invite Revoked Notification: " + err.Error())
+	}
+
+	notn.CreateNotificationUser(tx, nulls.UUID{}, invite.Email, invite.InviteeName)
+}
+
+// PolicyClosedQueueMessage queues messages to the members of a policy that has been closed
+func PolicyClosedQueueMessage(tx *pop.Connection, policy models.Policy) {
+	policy.LoadMembers(tx, false)
+
+	data := newEmailMessageData()
+	data.addStewardData(tx)
+
+	data["policy"] = policy
+	data["policyURL"] = fmt.Sprintf("%s/policies/%s", domain.Env.UIURL, policy.ID)
+	data["closedDate"] = policy.ClosedDate.Time.Format(domain.LocalizedDate)
+	data["closedReason"] = policy.ClosedReason
+
+	notn := models.Notification{
+		PolicyID:      nulls.NewUUID(policy.ID),
+		Body:          data.renderHTML(MessageTemplatePolicyClosedMember),
+		Subject:       fmt.Sprintf("Your %s policy has been closed", policy.Name),
+		InappText:     "your policy has been closed",
+		Event:         "Policy Closed Notification",
+		EventCategory: "Policy",
+	}
+	if err := notn.Create(tx); err != nil {
+		panic("error creating new Policy Closed Notification: " + err.Error())
+	}
+
+	for _, m := range policy.Members {
+		notn.CreateNotificationUserForUser(tx, m)
+	}
+}
+
+// PolicyStatementQueueMessage queues messages to the members of a policy to let them know that their
+// monthly statement is available
+func PolicyStatementQueueMessage(tx *pop.Connection, statement models.PolicyStatement) {
+	statement.LoadPolicy(tx, false)
+	policy := statement.Policy
+	policy.LoadMembers(tx, false)
+
+	data := newEmailMessageData()
+	data.addStewardData(tx)
+
+	period := statement.StatementDate.Format("January 2006")
+
+	data["policy"] = policy
+	data["statementURL"] = fmt.Sprintf("%s/policies/%s/statements", domain.Env.UIURL, policy.ID)
+	data["statementPeriod"] = period
+	data["openingBalance"] = statement.OpeningBalance.Dollars()
+	data["closingBalance"] = statement.ClosingBalance.Dollars()
+	data["coveredItems"] = statement.CoveredItems
+
+	notn := models.Notification{
+		PolicyID:      nulls.NewUUID(policy.ID),
+		Body:          data.renderHTML(MessageTemplatePolicyStatementMember),
+		Subject:       fmt.Sprintf("Your %s policy statement for %s", policy.Name, period),
+		InappText:     "your statement for " + period + " is available",
+		Event:         "Policy Statement Notification",
+		EventCategory: "Policy",
+	}
+	if err := notn.Create(tx); err != nil {
+		panic("error creating new Policy Statement Notification: " + err.Error())
+	}
+
+	for _, m := range policy.Members {
+		notn.CreateNotificationUserForUser(tx, m)
+	}
+}
+
+// HouseholdIDValidationQueueMessage queues messages to the stewards to notify them of household and staff IDs
+// that no longer match the household ID lookup service
+func HouseholdIDValidationQueueMessage(tx *pop.Connection, v models.HouseholdIDValidation) {
+	data := newEmailMessageData()
+
+	policyLine := func(p models.Policy) string {
+		return fmt.Sprintf("%s (household ID %s)", p.Name, p.HouseholdID.String)
+	}
+
+	invalidPolicies := make([]string, len(v.InvalidPolicies))
+	for i, p := range v.InvalidPolicies {
+		invalidPolicies[i] = policyLine(p)
+	}
+
+	correctedPolicies := make([]string, len(v.CorrectedPolicies))
+	for i, p := range v.CorrectedPolicies {
+		correctedPolicies[i] = policyLine(p)
+	}
+
+	mismatches := make([]string, len(v.Mismatches))
+	for i, m := range v.Mismatches {
+		mismatches[i] = fmt.Sprintf("%s (staff ID %s) on %s: staff record is in household %s",
+			m.User.Name(), m.User.StaffID.String, policyLine(m.Policy), m.FoundHouseholdID)
+	}
+
+	terminatedStaff := make([]string, len(v.TerminatedStaff))
+	for i, u := range v.TerminatedStaff {
+		terminatedStaff[i] = fmt.Sprintf("%s (staff ID %s, %s)", u.Name(), u.StaffID.String, u.Email)
+	}
+
+	data["policiesChecked"] = strconv.Itoa(v.PoliciesChecked)
+	data["usersChecked"] = strconv.Itoa(v.UsersChecked)
+	data["lookupFailures"] = v.LookupFailures
+	data["invalidPolicies"] = invalidPolicies
+	data["correctedPolicies"] = correctedPolicies
+	data["mismatches"] = mismatches
+	data["terminatedStaff"] = terminatedStaff
+	data["billingBlocked"] = domain.Env.HouseholdIDBlockBilling
+
+	notn := models.Notification{
+		Body:          data.renderHTML(MessageTemplateHouseholdIDValidationSteward),
+		Subject:       "Household and staff IDs need review",
+		InappText:     "Some household or staff IDs no longer match the staff records",
+		Event:         "Household ID Validation Notification",
+		EventCategory: "Policy",
+	}
+	if err := notn.Create(tx); err != nil {
+		panic("error creating new Household ID Validation Notification: " + err.Error())
+	}
+
+	notn.CreateNotificationUsersForStewards(tx)
+}
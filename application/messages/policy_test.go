@@ -2,6 +2,9 @@ package messages
 
 import (
 	"testing"
+	"time"
+
+	"github.com/gobuffalo/nulls"
 
 	"github.com/silinternational/cover-api/domain"
 	"github.com/silinternational/cover-api/models"
@@ -44,3 +47,152 @@ func (ts *TestSuite) Test_PolicyUserInviteQueueMessage() {
 		})
 	}
 }
+
+func (ts *TestSuite) Test_PolicyUserInviteRevokedQueueMessage() {
+	t := ts.T()
+	db := ts.DB
+
+	models.CreateAdminUsers(db)
+
+	f := models.CreatePolicyUserInviteFixtures(db, models.Policies{}, 1)
+
+	policy := f.Policies[0]
+	invite := f.PolicyUserInvites[0]
+
+	tests := []testData{
+		{
+			name:                "ok",
+			wantToEmails:        []any{invite.Email},
+			wantSubjectContains: "withdrawn",
+			wantBodyContains: []string{
+				invite.InviteeName,
+				invite.InviterName,
+				policy.Name,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			PolicyUserInviteRevokedQueueMessage(db, invite)
+			validateNotificationUsers(ts, db, tt)
+		})
+	}
+}
+
+func (ts *TestSuite) Test_PolicyClosedQueueMessage() {
+	t := ts.T()
+	db := ts.DB
+
+	models.CreateAdminUsers(db)
+
+	f := models.CreateItemFixtures(db, models.FixturesConfig{UsersPerPolicy: 2})
+
+	policy := f.Policies[0]
+	policy.ClosedDate = nulls.NewTime(time.Now().UTC())
+	policy.ClosedReason = "leaving the organization"
+
+	tests := []testData{
+		{
+			name:                  "ok",
+			wantToEmails:          []any{policy.Members[0].EmailOfChoice(), policy.Members[1].EmailOfChoice()},
+			wantSubjectContains:   "has been closed",
+			wantInappTextContains: "your policy has been closed",
+			wantBodyContains: []string{
+				domain.Env.UIURL,
+				policy.Name,
+				policy.ClosedReason,
+				policy.ClosedDate.Time.Format(domain.LocalizedDate),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			PolicyClosedQueueMessage(db, policy)
+			validateNotificationUsers(ts, db, tt)
+		})
+	}
+}
+
+func (ts *TestSuite) Test_PolicyStatementQueueMessage() {
+	t := ts.T()
+	db := ts.DB
+
+	models.CreateAdminUsers(db)
+
+	f := models.CreateLedgerFixtures(db, models.FixturesConfig{UsersPerPolicy: 2})
+	policy := f.Policies[0]
+
+	now := time.Now().UTC()
+	lastMonth := now.AddDate(0, 0, -now.Day())
+	statement, err := models.NewPolicyStatement(models.CreateTestContext(f.Users[0]), policy,
+		int(lastMonth.Month()), lastMonth.Year())
+	ts.NoError(err)
+
+	tests := []testData{
+		{
+			name:                  "ok",
+			wantToEmails:          []any{policy.Members[0].EmailOfChoice(), policy.Members[1].EmailOfChoice()},
+			wantSubjectContains:   "policy statement",
+			wantInappTextContains: "your statement for",
+			wantBodyContains: []string{
+				domain.Env.UIURL,
+				policy.Name,
+				lastMonth.Format("January 2006"),
+				statement.ClosingBalance.Dollars(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			PolicyStatementQueueMessage(db, statement)
+			validateNotificationUsers(ts, db, tt)
+		})
+	}
+}
+
+func (ts *TestSuite) Test_HouseholdIDValidationQueueMessage() {
+	t := ts.T()
+	db := ts.DB
+
+	steward0 := models.CreateAdminUsers(db)[models.AppRoleSteward]
+	steward1 := models.CreateAdminUsers(db)[models.AppRoleSteward]
+
+	f := models.CreatePolicyFixtures(db, models.FixturesConfig{NumberOfPolicies: 2})
+	mismatched := f.Policies[0]
+	terminated := f.Policies[1].Members[0]
+
+	v := models.HouseholdIDValidation{
+		UsersChecked:    2,
+		PoliciesChecked: 2,
+		Mismatches: []models.HouseholdIDMismatch{
+			{Policy: mismatched, User: mismatched.Members[0], FoundHouseholdID: "other-household"},
+		},
+		TerminatedStaff: models.Users{terminated},
+		InvalidPolicies: models.Policies{mismatched},
+	}
+
+	tests := []testData{
+		{
+			name:                  "ok",
+			wantToEmails:          []any{steward0.EmailOfChoice(), steward1.EmailOfChoice()},
+			wantSubjectContains:   "Household and staff IDs need review",
+			wantInappTextContains: "no longer match the staff records",
+			wantBodyContains: []string{
+				mismatched.Name,
+				mismatched.HouseholdID.String,
+				"other-household",
+				terminated.StaffID.String,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			HouseholdIDValidationQueueMessage(db, v)
+			validateNotificationUsers(ts, db, tt)
+		})
+	}
+}
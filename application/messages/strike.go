@@ -0,0 +1,91 @@
+package messages
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+// StrikeCreatedQueueMessage queues messages to the members of a policy to notify them that a strike
+// was added to their policy
+func StrikeCreatedQueueMessage(tx *pop.Connection, strike models.Strike) {
+	data := newStrikeMessageData(tx, &strike)
+
+	notn := models.Notification{
+		PolicyID:      nulls.NewUUID(strike.PolicyID),
+		Body:          data.renderHTML(MessageTemplateStrikeCreatedMember),
+		Subject:       fmt.Sprintf("A strike was added to your %s policy", strike.Policy.Name),
+		InappText:     "a strike was added to your policy",
+		Event:         "Strike Created Notification",
+		EventCategory: "Strike",
+	}
+	if err := notn.Create(tx); err != nil {
+		panic("error creating new Strike Created Notification: " + err.Error())
+	}
+
+	for _, m := range strike.Policy.Members {
+		notn.CreateNotificationUserForUser(tx, m)
+	}
+}
+
+// StrikeProposedQueueMessage queues messages to the stewards to notify them that a strike rule
+// proposed a strike that needs their review
+func StrikeProposedQueueMessage(tx *pop.Connection, strike models.Strike) {
+	data := newStrikeMessageData(tx, &strike)
+
+	notn := models.Notification{
+		PolicyID:      nulls.NewUUID(strike.PolicyID),
+		Body:          data.renderHTML(MessageTemplateStrikeProposedSteward),
+		Subject:       "Strike needs review on policy " + strike.Policy.Name,
+		InappText:     "A proposed strike is waiting for your review",
+		Event:         "Strike Proposed Notification",
+		EventCategory: "Strike",
+	}
+	if err := notn.Create(tx); err != nil {
+		panic("error creating new Strike Proposed Notification: " + err.Error())
+	}
+
+	notn.CreateNotificationUsersForStewards(tx)
+}
+
+// StrikeExpiredQueueMessage queues messages to the members of a policy to notify them that a strike
+// has aged out and their deductible has dropped
+func StrikeExpiredQueueMessage(tx *pop.Connection, strike models.Strike) {
+	data := newStrikeMessageData(tx, &strike)
+
+	notn := models.Notification{
+		PolicyID:      nulls.NewUUID(strike.PolicyID),
+		Body:          data.renderHTML(MessageTemplateStrikeExpiredMember),
+		Subject:       fmt.Sprintf("A strike on your %s policy has expired", strike.Policy.Name),
+		InappText:     "a strike on your policy has expired",
+		Event:         "Strike Expired Notification",
+		EventCategory: "Strike",
+	}
+	if err := notn.Create(tx); err != nil {
+		panic("error creating new Strike Expired Notification: " + err.Error())
+	}
+
+	for _, m := range strike.Policy.Members {
+		notn.CreateNotificationUserForUser(tx, m)
+	}
+}
+
+func newStrikeMessageData(tx *pop.Connection, strike *models.Strike) MessageData {
+	strike.LoadPolicy(tx, false)
+	strike.Policy.LoadMembers(tx, false)
+
+	data := newEmailMessageData()
+	data.addStewardData(tx)
+
+	data["policy"] = strike.Policy
+	data["policyURL"] = fmt.Sprintf("%s/policies/%s", domain.Env.UIURL, strike.PolicyID)
+	data["strikeDescription"] = strike.Description
+	data["strikeExpirationDate"] = strike.ExpiresAt().Format(domain.LocalizedDate)
+	data["deductible"] = fmt.Sprintf("%.0f%%", strike.Policy.DeductibleRate(tx, time.Now().UTC())*100)
+	return data
+}
@@ -0,0 +1,107 @@
+package messages
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (ts *TestSuite) Test_StrikeCreatedQueueMessage() {
+	t := ts.T()
+	db := ts.DB
+
+	models.CreateAdminUsers(db)
+
+	f := models.CreatePolicyFixtures(db, models.FixturesConfig{UsersPerPolicy: 2})
+	policy := f.Policies[0]
+	strike := models.CreateStrikeFixtures(db, f.Policies, [][]*time.Time{{nil}})[0]
+
+	tests := []testData{
+		{
+			name:                  "ok",
+			wantToEmails:          []any{policy.Members[0].EmailOfChoice(), policy.Members[1].EmailOfChoice()},
+			wantSubjectContains:   "A strike was added",
+			wantInappTextContains: "a strike was added to your policy",
+			wantBodyContains: []string{
+				domain.Env.UIURL,
+				policy.Name,
+				strike.Description,
+				strike.ExpiresAt().Format(domain.LocalizedDate),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			StrikeCreatedQueueMessage(db, strike)
+			validateNotificationUsers(ts, db, tt)
+		})
+	}
+}
+
+func (ts *TestSuite) Test_StrikeProposedQueueMessage() {
+	t := ts.T()
+	db := ts.DB
+
+	steward0 := models.CreateAdminUsers(db)[models.AppRoleSteward]
+	steward1 := models.CreateAdminUsers(db)[models.AppRoleSteward]
+
+	f := models.CreatePolicyFixtures(db, models.FixturesConfig{})
+	policy := f.Policies[0]
+	strike := models.Strike{Description: "Unattended theft", PolicyID: policy.ID, Status: api.StrikeStatusProposed}
+	ts.NoError(strike.Create(db))
+
+	tests := []testData{
+		{
+			name:                  "ok",
+			wantToEmails:          []any{steward0.EmailOfChoice(), steward1.EmailOfChoice()},
+			wantSubjectContains:   "Strike needs review",
+			wantInappTextContains: "waiting for your review",
+			wantBodyContains:      []string{policy.Name, strike.Description},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			StrikeProposedQueueMessage(db, strike)
+			validateNotificationUsers(ts, db, tt)
+		})
+	}
+}
+
+func (ts *TestSuite) Test_StrikeExpiredQueueMessage() {
+	t := ts.T()
+	db := ts.DB
+
+	models.CreateAdminUsers(db)
+
+	f := models.CreatePolicyFixtures(db, models.FixturesConfig{})
+	policy := f.Policies[0]
+	expired := time.Now().UTC().AddDate(0, -domain.Env.StrikeLifetimeMonths, -1)
+	strike := models.CreateStrikeFixtures(db, f.Policies, [][]*time.Time{{&expired}})[0]
+
+	tests := []testData{
+		{
+			name:                  "ok",
+			wantToEmails:          []any{policy.Members[0].EmailOfChoice()},
+			wantSubjectContains:   "has expired",
+			wantInappTextContains: "a strike on your policy has expired",
+			wantBodyContains: []string{
+				policy.Name,
+				strike.Description,
+				fmt.Sprintf("%.0f%%", domain.Env.DeductibleRate*100),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			StrikeExpiredQueueMessage(db, strike)
+			validateNotificationUsers(ts, db, tt)
+		})
+	}
+}
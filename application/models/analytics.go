@@ -0,0 +1,321 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+// analyticsNoGroup is the group name of entries that have no value for the group_by dimension, e.g. a policy
+// adjustment has no risk category
+const analyticsNoGroup = "none"
+
+var ValidAnalyticsGroupBys = map[api.AnalyticsGroupBy]struct{}{
+	api.AnalyticsGroupByRiskCategory: {},
+	api.AnalyticsGroupByItemCategory: {},
+	api.AnalyticsGroupByCountry:      {},
+	api.AnalyticsGroupByPolicyType:   {},
+	api.AnalyticsGroupByYear:         {},
+}
+
+var ValidAnalyticsIntervals = map[api.AnalyticsInterval]struct{}{
+	api.AnalyticsIntervalMonth: {},
+	api.AnalyticsIntervalYear:  {},
+}
+
+// analyticsEntry holds the fields of a ledger entry and its item that are needed for the portfolio analytics
+type analyticsEntry struct {
+	Type             LedgerEntryType `db:"type"`
+	Amount           api.Currency    `db:"amount"`
+	DateSubmitted    time.Time       `db:"date_submitted"`
+	RiskCategoryName string          `db:"risk_category_name"`
+	PolicyType       api.PolicyType  `db:"policy_type"`
+	ItemID           nulls.UUID      `db:"item_id"`
+	ClaimID          nulls.UUID      `db:"claim_id"`
+	ItemCategoryName string          `db:"item_category_name"`
+	Country          string          `db:"country"`
+	BillingPeriod    int             `db:"billing_period"`
+}
+
+// analyticsAccumulator collects the ledger entries of one group and period
+type analyticsAccumulator struct {
+	earned  api.Currency
+	written api.Currency
+	claims  api.Currency
+	items   map[string]struct{}
+	claimed map[string]struct{}
+}
+
+func newAnalyticsAccumulator() *analyticsAccumulator {
+	return &analyticsAccumulator{items: map[string]struct{}{}, claimed: map[string]struct{}{}}
+}
+
+// PortfolioAnalytics calculates the premium and claim metrics between the given dates (inclusive), in total and by the
+// given dimension, with a time series at the given interval. Premium is earned evenly over the coverage period it pays
+// for, so premium charged before the start date may be earned in the range. Amounts are reported as positive numbers,
+// unlike the ledger convention.
+func PortfolioAnalytics(tx *pop.Connection, start, end time.Time, groupBy api.AnalyticsGroupBy,
+	interval api.AnalyticsInterval,
+) (api.PortfolioAnalytics, error) {
+	if _, ok := ValidAnalyticsGroupBys[groupBy]; !ok {
+		err := fmt.Errorf("invalid analytics group_by: %s", groupBy)
+		return api.PortfolioAnalytics{}, api.NewAppError(err, api.ErrorInvalidAnalyticsParam, api.CategoryUser)
+	}
+	if _, ok := ValidAnalyticsIntervals[interval]; !ok {
+		err := fmt.Errorf("invalid analytics interval: %s", interval)
+		return api.PortfolioAnalytics{}, api.NewAppError(err, api.ErrorInvalidAnalyticsParam, api.CategoryUser)
+	}
+	if end.Before(start) {
+		err := fmt.Errorf("end date %s is before start date %s",
+			end.Format(domain.DateFormat), start.Format(domain.DateFormat))
+		return api.PortfolioAnalytics{}, api.NewAppError(err, api.ErrorInvalidDate, api.CategoryUser)
+	}
+
+	start, end = domain.BeginningOfDay(start), domain.BeginningOfDay(end)
+
+	// coverage never extends past the end of the year in which it was charged
+	firstCoverageDate := time.Date(start.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var entries []analyticsEntry
+	err := tx.RawQuery(`
+		SELECT le.type, le.amount, le.date_submitted, le.risk_category_name, le.policy_type, le.item_id, le.claim_id,
+			COALESCE(ic.name, '') AS item_category_name, COALESCE(i.country, '') AS country,
+			COALESCE(ic.billing_period, ?) AS billing_period
+		FROM ledger_entries le
+		LEFT JOIN items i ON i.id = le.item_id
+		LEFT JOIN item_categories ic ON ic.id = i.category_id
+		WHERE le.date_submitted >= ? AND le.date_submitted < ?
+		`, domain.BillingPeriodAnnual, firstCoverageDate, end.AddDate(0, 0, 1)).All(&entries)
+	if err != nil {
+		return api.PortfolioAnalytics{}, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	periods := analyticsPeriods(start, end, interval)
+
+	total := newAnalyticsAccumulator()
+	totalSeries := map[string]*analyticsAccumulator{}
+	groups := map[string]*analyticsAccumulator{}
+	groupSeries := map[string]map[string]*analyticsAccumulator{}
+
+	// accumulators returns the accumulators that an entry of the given group and period adds to
+	accumulators := func(group, period string) []*analyticsAccumulator {
+		if _, ok := groups[group]; !ok {
+			groups[group] = newAnalyticsAccumulator()
+			groupSeries[group] = map[string]*analyticsAccumulator{}
+		}
+		if _, ok := totalSeries[period]; !ok {
+			totalSeries[period] = newAnalyticsAccumulator()
+		}
+		if _, ok := groupSeries[group][period]; !ok {
+			groupSeries[group][period] = newAnalyticsAccumulator()
+		}
+		return []*analyticsAccumulator{total, totalSeries[period], groups[group], groupSeries[group][period]}
+	}
+
+	for _, e := range entries {
+		group := e.group(groupBy)
+		submittedInRange := !e.DateSubmitted.Before(start)
+
+		if e.Type.IsClaim() {
+			if submittedInRange {
+				for _, a := range accumulators(group, analyticsPeriod(e.DateSubmitted, interval)) {
+					a.addClaim(e)
+				}
+			}
+			continue
+		}
+
+		if submittedInRange {
+			for _, a := range accumulators(group, analyticsPeriod(e.DateSubmitted, interval)) {
+				a.written -= e.Amount
+			}
+		}
+		for period, earned := range e.earned(start, end, interval) {
+			for _, a := range accumulators(group, period) {
+				a.addEarned(e, earned)
+			}
+		}
+	}
+
+	analytics := api.PortfolioAnalytics{
+		Start:    start.Format(domain.DateFormat),
+		End:      end.Format(domain.DateFormat),
+		GroupBy:  groupBy,
+		Interval: interval,
+		Totals:   total.metrics(),
+		Series:   analyticsSeries(periods, totalSeries),
+		Groups:   make([]api.PortfolioGroup, 0, len(groups)),
+	}
+	for name, acc := range groups {
+		analytics.Groups = append(analytics.Groups, api.PortfolioGroup{
+			Name:   name,
+			Totals: acc.metrics(),
+			Series: analyticsSeries(periods, groupSeries[name]),
+		})
+	}
+	sort.Slice(analytics.Groups, func(i, j int) bool {
+		return analytics.Groups[i].Name < analytics.Groups[j].Name
+	})
+
+	return analytics, nil
+}
+
+func (e analyticsEntry) group(groupBy api.AnalyticsGroupBy) string {
+	var name string
+	switch groupBy {
+	case api.AnalyticsGroupByRiskCategory:
+		name = e.RiskCategoryName
+	case api.AnalyticsGroupByItemCategory:
+		name = e.ItemCategoryName
+	case api.AnalyticsGroupByCountry:
+		name = e.Country
+	case api.AnalyticsGroupByPolicyType:
+		name = string(e.PolicyType)
+	case api.AnalyticsGroupByYear:
+		name = strconv.Itoa(e.DateSubmitted.Year())
+	}
+	if name == "" {
+		return analyticsNoGroup
+	}
+	return name
+}
+
+// coverageStart returns the first day of the coverage paid for by a premium entry
+func (e analyticsEntry) coverageStart() time.Time {
+	return domain.BeginningOfDay(e.DateSubmitted)
+}
+
+// coverageEnd returns the last day of the coverage paid for by a premium entry: the end of the month for monthly
+// billing, otherwise the end of the year
+func (e analyticsEntry) coverageEnd() time.Time {
+	d := e.coverageStart()
+	if e.BillingPeriod == domain.BillingPeriodMonthly {
+		return domain.EndOfMonth(d)
+	}
+	return time.Date(d.Year(), 12, 31, 0, 0, 0, 0, time.UTC)
+}
+
+// earned spreads the premium of an entry evenly over the days of its coverage and returns the part of it that is
+// earned in each period between start and end (inclusive). Charges are negative in the ledger, so premiums are negated.
+func (e analyticsEntry) earned(start, end time.Time, interval api.AnalyticsInterval) map[string]api.Currency {
+	coverageStart, coverageEnd := e.coverageStart(), e.coverageEnd()
+	coverageDays := daysInRange(coverageStart, coverageEnd)
+
+	from, to := coverageStart, coverageEnd
+	if from.Before(start) {
+		from = start
+	}
+	if to.After(end) {
+		to = end
+	}
+
+	// Round the premium earned through each day rather than each month, so that a fully earned premium adds up to
+	// the amount charged
+	earnedThrough := func(day time.Time) api.Currency {
+		days := daysInRange(coverageStart, day)
+		return api.Currency(math.Round(float64(-e.Amount) * float64(days) / float64(coverageDays)))
+	}
+
+	earned := map[string]api.Currency{}
+	previous := earnedThrough(from.AddDate(0, 0, -1))
+	for d := from; !d.After(to); {
+		monthEnd := domain.EndOfMonth(d)
+		if monthEnd.After(to) {
+			monthEnd = to
+		}
+		running := earnedThrough(monthEnd)
+		earned[analyticsPeriod(d, interval)] += running - previous
+		previous = running
+		d = monthEnd.AddDate(0, 0, 1)
+	}
+	return earned
+}
+
+// daysInRange returns the number of days from start to end (inclusive)
+func daysInRange(start, end time.Time) int {
+	return int(end.Sub(start).Hours()/24) + 1
+}
+
+// addClaim includes a claim payout or adjustment in the metrics
+func (a *analyticsAccumulator) addClaim(e analyticsEntry) {
+	a.claims += e.Amount
+	if e.Type == LedgerEntryTypeClaim && e.ClaimID.Valid {
+		a.claimed[e.ClaimID.UUID.String()] = struct{}{}
+	}
+}
+
+// addEarned includes the premium earned by an entry in the metrics
+func (a *analyticsAccumulator) addEarned(e analyticsEntry, earned api.Currency) {
+	a.earned += earned
+	if e.ItemID.Valid {
+		a.items[e.ItemID.UUID.String()] = struct{}{}
+	}
+}
+
+func (a *analyticsAccumulator) metrics() api.PortfolioMetrics {
+	m := api.PortfolioMetrics{
+		EarnedPremium:  a.earned,
+		WrittenPremium: a.written,
+		ClaimsPaid:     a.claims,
+		ItemCount:      len(a.items),
+		ClaimCount:     len(a.claimed),
+	}
+	if m.EarnedPremium != 0 {
+		m.LossRatio = roundRatio(float64(m.ClaimsPaid) / float64(m.EarnedPremium))
+	}
+	if m.ItemCount > 0 {
+		m.ClaimFrequency = roundRatio(float64(m.ClaimCount) / float64(m.ItemCount))
+	}
+	if m.ClaimCount > 0 {
+		m.AverageSeverity = m.ClaimsPaid / api.Currency(m.ClaimCount)
+	}
+	return m
+}
+
+func roundRatio(r float64) float64 {
+	return math.Round(r*10000) / 10000
+}
+
+// analyticsPeriods lists every interval from start to end, so that a time series has no gaps
+func analyticsPeriods(start, end time.Time, interval api.AnalyticsInterval) []string {
+	var periods []string
+	d := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if interval == api.AnalyticsIntervalYear {
+		d = time.Date(start.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	for !d.After(end) {
+		periods = append(periods, analyticsPeriod(d, interval))
+		if interval == api.AnalyticsIntervalYear {
+			d = d.AddDate(1, 0, 0)
+		} else {
+			d = d.AddDate(0, 1, 0)
+		}
+	}
+	return periods
+}
+
+func analyticsPeriod(date time.Time, interval api.AnalyticsInterval) string {
+	if interval == api.AnalyticsIntervalYear {
+		return date.Format("2006")
+	}
+	return date.Format("2006-01")
+}
+
+func analyticsSeries(periods []string, accumulators map[string]*analyticsAccumulator) []api.PortfolioSeriesPoint {
+	series := make([]api.PortfolioSeriesPoint, len(periods))
+	for i, period := range periods {
+		series[i].Period = period
+		if acc, ok := accumulators[period]; ok {
+			series[i].PortfolioMetrics = acc.metrics()
+		}
+	}
+	return series
+}
@@ -0,0 +1,149 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+func (ms *ModelSuite) TestPortfolioAnalytics() {
+	f := CreateLedgerFixtures(ms.DB, FixturesConfig{
+		NumberOfPolicies:   1,
+		ItemsPerPolicy:     2,
+		ClaimsPerPolicy:    1,
+		ClaimItemsPerClaim: 1,
+	})
+
+	// one premium charged at the start of an annual coverage period and one halfway through, both at 100 per day
+	yearStart := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	f.LedgerEntries[0].DateSubmitted = yearStart
+	f.LedgerEntries[0].Amount = -36500
+	f.LedgerEntries[1].DateSubmitted = time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)
+	f.LedgerEntries[1].Amount = -18400
+
+	claimEntry := f.LedgerEntries[0]
+	claimEntry.ID = uuid.Nil
+	claimEntry.Type = LedgerEntryTypeClaim
+	claimEntry.ClaimID = nulls.NewUUID(f.Claims[0].ID)
+	claimEntry.Amount = 5000
+	ms.NoError(claimEntry.Create(ms.DB))
+	claimEntry.DateSubmitted = time.Date(2022, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	for _, e := range []LedgerEntry{f.LedgerEntries[0], f.LedgerEntries[1], claimEntry} {
+		ms.NoError(ms.DB.Update(&e))
+	}
+
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name        string
+		start       time.Time
+		end         time.Time
+		groupBy     api.AnalyticsGroupBy
+		interval    api.AnalyticsInterval
+		wantErr     *api.AppError
+		wantEarned  api.Currency
+		wantWritten api.Currency
+		wantClaims  api.Currency
+		wantItems   int
+		wantSeries  []api.Currency
+	}{
+		{
+			name:     "invalid group_by",
+			start:    now,
+			end:      now,
+			groupBy:  "color",
+			interval: api.AnalyticsIntervalMonth,
+			wantErr:  &api.AppError{Key: api.ErrorInvalidAnalyticsParam, Category: api.CategoryUser},
+		},
+		{
+			name:     "invalid interval",
+			start:    now,
+			end:      now,
+			groupBy:  api.AnalyticsGroupByCountry,
+			interval: "week",
+			wantErr:  &api.AppError{Key: api.ErrorInvalidAnalyticsParam, Category: api.CategoryUser},
+		},
+		{
+			name:     "end before start",
+			start:    now,
+			end:      now.AddDate(0, 0, -1),
+			groupBy:  api.AnalyticsGroupByCountry,
+			interval: api.AnalyticsIntervalMonth,
+			wantErr:  &api.AppError{Key: api.ErrorInvalidDate, Category: api.CategoryUser},
+		},
+		{
+			name:        "by risk category",
+			start:       yearStart,
+			end:         time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC),
+			groupBy:     api.AnalyticsGroupByRiskCategory,
+			interval:    api.AnalyticsIntervalMonth,
+			wantEarned:  9000,
+			wantWritten: 36500,
+			wantClaims:  5000,
+			wantItems:   1,
+			wantSeries:  []api.Currency{3100, 2800, 3100},
+		},
+		{
+			name:        "premium charged before the range",
+			start:       time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+			end:         time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC),
+			groupBy:     api.AnalyticsGroupByItemCategory,
+			interval:    api.AnalyticsIntervalMonth,
+			wantEarned:  18400,
+			wantWritten: 0,
+			wantClaims:  0,
+			wantItems:   2,
+			wantSeries:  []api.Currency{6200, 6000, 6200},
+		},
+		{
+			name:        "by policy type",
+			start:       yearStart,
+			end:         time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC),
+			groupBy:     api.AnalyticsGroupByPolicyType,
+			interval:    api.AnalyticsIntervalYear,
+			wantEarned:  54900,
+			wantWritten: 54900,
+			wantClaims:  5000,
+			wantItems:   2,
+			wantSeries:  []api.Currency{54900},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			got, err := PortfolioAnalytics(ms.DB, tt.start, tt.end, tt.groupBy, tt.interval)
+			if tt.wantErr != nil {
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+
+			ms.Equal(tt.wantEarned, got.Totals.EarnedPremium)
+			ms.Equal(tt.wantWritten, got.Totals.WrittenPremium)
+			ms.Equal(tt.wantClaims, got.Totals.ClaimsPaid)
+			ms.Equal(tt.wantItems, got.Totals.ItemCount)
+			if tt.wantClaims != 0 {
+				ms.Equal(1, got.Totals.ClaimCount)
+				ms.Equal(roundRatio(1/float64(tt.wantItems)), got.Totals.ClaimFrequency)
+				ms.Equal(tt.wantClaims, got.Totals.AverageSeverity)
+			}
+			ms.Equal(roundRatio(float64(tt.wantClaims)/float64(tt.wantEarned)), got.Totals.LossRatio)
+
+			ms.Len(got.Series, len(tt.wantSeries), "series should include every interval in the range")
+			for i, want := range tt.wantSeries {
+				ms.Equal(want, got.Series[i].EarnedPremium, "incorrect earned premium in %s", got.Series[i].Period)
+			}
+
+			var groupTotal api.Currency
+			for _, g := range got.Groups {
+				groupTotal += g.Totals.EarnedPremium
+				ms.Len(g.Series, len(got.Series))
+			}
+			ms.Equal(tt.wantEarned, groupTotal, "group totals should add up to the overall total")
+		})
+	}
+}
@@ -0,0 +1,287 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+const (
+	CertificateVerificationCodeLength = 10
+
+	// verification codes exclude characters that are easily confused with each other, e.g. 0 and O
+	certificateVerificationCodeChars = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+type Certificates []Certificate
+
+// Certificate is a proof-of-coverage document issued for an item or for all the covered items on a policy
+type Certificate struct {
+	ID               uuid.UUID  `db:"id"`
+	PolicyID         uuid.UUID  `db:"policy_id" validate:"required"`
+	ItemID           nulls.UUID `db:"item_id"`
+	FileID           uuid.UUID  `db:"file_id" validate:"required"`
+	VerificationCode string     `db:"verification_code" validate:"required"`
+	CoverageAmount   int        `db:"coverage_amount" validate:"min=0"`
+	CreatedAt        time.Time  `db:"created_at"`
+	UpdatedAt        time.Time  `db:"updated_at"`
+
+	File   File   `belongs_to:"files" validate:"-"`
+	Policy Policy `belongs_to:"policies" validate:"-"`
+}
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (c *Certificate) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validateModel(c), nil
+}
+
+// Create stores the certificate File and then the Certificate record
+func (c *Certificate) Create(tx *pop.Connection) error {
+	c.File.Linked = true
+	if err := c.File.Store(tx); err != nil {
+		return err
+	}
+	c.FileID = c.File.ID
+
+	return create(tx, c)
+}
+
+func (c *Certificate) FindByID(tx *pop.Connection, id uuid.UUID) error {
+	return tx.Find(c, id)
+}
+
+// FindByVerificationCode loads the Certificate with the given verification code
+func (c *Certificate) FindByVerificationCode(tx *pop.Connection, code string) error {
+	return tx.Where("verification_code = ?", code).First(c)
+}
+
+// LoadFile - a simple wrapper method for loading the file on the struct
+func (c *Certificate) LoadFile(tx *pop.Connection, reload bool) {
+	if c.File.ID == uuid.Nil || reload {
+		if err := tx.Load(c, "File"); err != nil {
+			panic("database error loading Certificate.File, " + err.Error())
+		}
+	}
+}
+
+// LoadPolicy - a simple wrapper method for loading the policy on the struct
+func (c *Certificate) LoadPolicy(tx *pop.Connection, reload bool) {
+	if c.Policy.ID == uuid.Nil || reload {
+		if err := tx.Load(c, "Policy"); err != nil {
+			panic("database error loading Certificate.Policy, " + err.Error())
+		}
+	}
+}
+
+// NewItemCertificate creates a proof-of-coverage certificate for an approved item
+func NewItemCertificate(ctx context.Context, item Item) (Certificate, error) {
+	if item.CoverageStatus != api.ItemCoverageStatusApproved {
+		err := fmt.Errorf("item %s does not have approved coverage", item.ID)
+		return Certificate{}, api.NewAppError(err, api.ErrorCertificateNoCoverage, api.CategoryUser)
+	}
+
+	tx := Tx(ctx)
+	item.LoadPolicy(tx, false)
+
+	cert := Certificate{
+		PolicyID: item.PolicyID,
+		ItemID:   nulls.NewUUID(item.ID),
+	}
+	if err := cert.create(ctx, item.Policy, Items{item}); err != nil {
+		return Certificate{}, err
+	}
+	return cert, nil
+}
+
+// NewPolicyCertificate creates a proof-of-coverage certificate listing all the approved items on a policy
+func NewPolicyCertificate(ctx context.Context, policy Policy) (Certificate, error) {
+	tx := Tx(ctx)
+
+	var items Items
+	if err := tx.Where("policy_id = ? AND coverage_status = ?", policy.ID, api.ItemCoverageStatusApproved).
+		Order("name asc").All(&items); err != nil {
+		return Certificate{}, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	if len(items) == 0 {
+		err := fmt.Errorf("policy %s has no items with approved coverage", policy.ID)
+		return Certificate{}, api.NewAppError(err, api.ErrorCertificateNoCoverage, api.CategoryUser)
+	}
+
+	cert := Certificate{PolicyID: policy.ID}
+	if err := cert.create(ctx, policy, items); err != nil {
+		return Certificate{}, err
+	}
+	return cert, nil
+}
+
+func (c *Certificate) create(ctx context.Context, policy Policy, items Items) error {
+	tx := Tx(ctx)
+
+	c.VerificationCode = uniqueCertificateVerificationCode(tx)
+	c.Policy = policy
+	for _, item := range items {
+		c.CoverageAmount += item.CoverageAmount
+	}
+
+	issued := time.Now().UTC()
+	content, err := renderCertificate(tx, *c, items, issued)
+	if err != nil {
+		return api.NewAppError(err, api.ErrorUnableToStoreFile, api.CategoryInternal)
+	}
+
+	c.File = File{
+		Name: fmt.Sprintf("%s_certificate_%s_%s.pdf",
+			domain.Env.AppName, c.VerificationCode, issued.Format(domain.DateFormat)),
+		Content:     content,
+		ContentType: domain.ContentPDF,
+		CreatedByID: CurrentUser(ctx).ID,
+	}
+
+	return c.Create(tx)
+}
+
+func renderCertificate(tx *pop.Connection, c Certificate, items Items, issued time.Time) ([]byte, error) {
+	doc := newPDFDocument("Certificate of Coverage")
+
+	doc.paragraph(fmt.Sprintf("This certifies that the following items are covered by %s under the policy named below, "+
+		"as of the date of issue.", domain.Env.AppName))
+
+	doc.heading("Policy")
+	doc.labeledValue("Policy name", c.Policy.Name)
+	doc.labeledValue("Policy type", string(c.Policy.Type))
+	doc.labeledValue("Total coverage", "$"+api.Currency(c.CoverageAmount).String())
+	doc.labeledValue("Date of issue", pdfDate(issued))
+
+	for _, item := range items {
+		doc.heading(item.Name)
+		doc.labeledValue("Accountable person", item.GetAccountablePersonName(tx).String())
+		doc.labeledValue("Make and model", item.GetMakeModel())
+		doc.labeledValue("Serial number", item.SerialNumber)
+		doc.labeledValue("Coverage amount", "$"+api.Currency(item.CoverageAmount).String())
+
+		coverageEnd := "ongoing"
+		if item.CoverageEndDate.Valid {
+			coverageEnd = pdfDate(item.CoverageEndDate.Time)
+		}
+		doc.labeledValue("Coverage period", pdfDate(item.CoverageStartDate)+" to "+coverageEnd)
+		doc.labeledValue("Paid through", pdfDate(item.PaidThroughDate))
+	}
+
+	doc.heading("Verification")
+	doc.labeledValue("Verification code", c.VerificationCode)
+	doc.paragraph(fmt.Sprintf("The authenticity of this certificate can be confirmed at %s/%s/%s",
+		domain.Env.ApiBaseURL, domain.TypeCertificate, c.VerificationCode))
+
+	return doc.render()
+}
+
+// Verify returns the public view of the certificate, using the current state of its items
+func (c *Certificate) Verify(tx *pop.Connection) api.CertificateVerification {
+	c.LoadPolicy(tx, false)
+
+	var items Items
+	q := tx.Where("policy_id = ?", c.PolicyID)
+	if c.ItemID.Valid {
+		q = q.Where("id = ?", c.ItemID.UUID)
+	} else {
+		// a policy certificate lists the items that were covered on the date it was issued
+		q = q.Where("coverage_start_date <= ?", c.CreatedAt).
+			Where("coverage_status IN (?, ?)", api.ItemCoverageStatusApproved, api.ItemCoverageStatusInactive).
+			Where("(coverage_end_date IS NULL OR coverage_end_date >= ?)", c.CreatedAt)
+	}
+	if err := q.Order("name asc").All(&items); err != nil {
+		panic("database error loading certificate items, " + err.Error())
+	}
+
+	verification := api.CertificateVerification{
+		VerificationCode: c.VerificationCode,
+		IsCurrent:        len(items) > 0,
+		PolicyName:       c.Policy.Name,
+		CoverageAmount:   api.Currency(c.CoverageAmount),
+		IssuedAt:         c.CreatedAt,
+		Items:            make([]api.CertificateItem, len(items)),
+	}
+
+	for i, item := range items {
+		if item.CoverageStatus != api.ItemCoverageStatusApproved {
+			verification.IsCurrent = false
+		}
+
+		var coverageEndDate *string
+		if item.CoverageEndDate.Valid {
+			s := item.CoverageEndDate.Time.Format(domain.DateFormat)
+			coverageEndDate = &s
+		}
+
+		verification.Items[i] = api.CertificateItem{
+			Name:              item.Name,
+			MakeModel:         item.GetMakeModel(),
+			SerialNumber:      item.SerialNumber,
+			CoverageAmount:    api.Currency(item.CoverageAmount),
+			CoverageStatus:    item.CoverageStatus,
+			CoverageStartDate: item.CoverageStartDate.Format(domain.DateFormat),
+			CoverageEndDate:   coverageEndDate,
+			PaidThroughDate:   item.PaidThroughDate.Format(domain.DateFormat),
+		}
+	}
+
+	return verification
+}
+
+func (c *Certificate) ConvertToAPI(tx *pop.Connection) api.Certificate {
+	c.LoadFile(tx, false)
+
+	return api.Certificate{
+		ID:               c.ID,
+		PolicyID:         c.PolicyID,
+		ItemID:           convertUUIDToAPI(c.ItemID),
+		VerificationCode: c.VerificationCode,
+		CoverageAmount:   api.Currency(c.CoverageAmount),
+		File:             c.File.ConvertToAPI(tx),
+		CreatedAt:        c.CreatedAt,
+	}
+}
+
+// FindCertificateByVerificationCode loads the Certificate with the given code, returning
+// an ErrorCertificateNotFound AppError if there is none
+func FindCertificateByVerificationCode(tx *pop.Connection, code string) (Certificate, error) {
+	var cert Certificate
+	if err := cert.FindByVerificationCode(tx, code); err != nil {
+		if domain.IsOtherThanNoRows(err) {
+			return cert, appErrorFromDB(err, api.ErrorQueryFailure)
+		}
+		err = errors.New("no certificate found with verification code " + code)
+		return cert, api.NewAppError(err, api.ErrorCertificateNotFound, api.CategoryNotFound)
+	}
+	return cert, nil
+}
+
+func uniqueCertificateVerificationCode(tx *pop.Connection) string {
+	attempts := 0
+	for {
+		code := domain.RandomString(CertificateVerificationCodeLength, certificateVerificationCodeChars)
+
+		count, err := tx.Where("verification_code = ?", code).Count(Certificate{})
+		if domain.IsOtherThanNoRows(err) {
+			panic("database error: " + err.Error())
+		}
+		if count == 0 && err == nil {
+			return code
+		}
+
+		attempts++
+		if attempts > 100 {
+			panic(fmt.Errorf("failed to find unique certificate verification code after %d attempts", attempts-1))
+		}
+	}
+}
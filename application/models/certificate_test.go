@@ -0,0 +1,120 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/gobuffalo/nulls"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+func (ms *ModelSuite) TestNewItemCertificate() {
+	t := ms.T()
+
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 2})
+	approvedItem := UpdateItemStatus(ms.DB, f.Items[0], api.ItemCoverageStatusApproved, "")
+	draftItem := f.Items[1]
+
+	ctx := CreateTestContext(f.Users[0])
+
+	tests := []struct {
+		name    string
+		item    Item
+		wantErr *api.AppError
+	}{
+		{
+			name:    "not approved",
+			item:    draftItem,
+			wantErr: &api.AppError{Key: api.ErrorCertificateNoCoverage, Category: api.CategoryUser},
+		},
+		{
+			name: "approved",
+			item: approvedItem,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewItemCertificate(ctx, tt.item)
+			if tt.wantErr != nil {
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+
+			ms.Equal(tt.item.ID, got.ItemID.UUID, "incorrect ItemID")
+			ms.Equal(tt.item.PolicyID, got.PolicyID, "incorrect PolicyID")
+			ms.Equal(tt.item.CoverageAmount, got.CoverageAmount, "incorrect CoverageAmount")
+			ms.Len(got.VerificationCode, CertificateVerificationCodeLength, "incorrect verification code")
+			ms.Equal("application/pdf", got.File.ContentType, "incorrect file content type")
+			ms.True(got.File.Linked, "certificate file should be linked")
+		})
+	}
+}
+
+func (ms *ModelSuite) TestNewPolicyCertificate() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 2, ItemsPerPolicy: 3})
+	ctx := CreateTestContext(f.Users[0])
+
+	// the second policy has no approved items
+	_, err := NewPolicyCertificate(ctx, f.Policies[1])
+	ms.EqualAppError(api.AppError{Key: api.ErrorCertificateNoCoverage, Category: api.CategoryUser}, err)
+
+	item0 := UpdateItemStatus(ms.DB, f.Items[0], api.ItemCoverageStatusApproved, "")
+	item1 := UpdateItemStatus(ms.DB, f.Items[1], api.ItemCoverageStatusApproved, "")
+
+	got, err := NewPolicyCertificate(ctx, f.Policies[0])
+	ms.NoError(err)
+	ms.False(got.ItemID.Valid, "policy certificate should not have an ItemID")
+	ms.Equal(item0.CoverageAmount+item1.CoverageAmount, got.CoverageAmount, "incorrect CoverageAmount")
+}
+
+func (ms *ModelSuite) TestCertificate_Verify() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 2})
+	item := UpdateItemStatus(ms.DB, f.Items[0], api.ItemCoverageStatusApproved, "")
+	ctx := CreateTestContext(f.Users[0])
+
+	cert, err := NewItemCertificate(ctx, item)
+	ms.NoError(err)
+
+	found, err := FindCertificateByVerificationCode(ms.DB, cert.VerificationCode)
+	ms.NoError(err)
+
+	got := found.Verify(ms.DB)
+	ms.True(got.IsCurrent, "certificate should be current")
+	ms.Equal(f.Policies[0].Name, got.PolicyName, "incorrect PolicyName")
+	ms.Len(got.Items, 1, "incorrect number of items")
+	ms.Equal(item.SerialNumber, got.Items[0].SerialNumber, "incorrect SerialNumber")
+
+	UpdateItemStatus(ms.DB, item, api.ItemCoverageStatusInactive, "")
+	got = found.Verify(ms.DB)
+	ms.False(got.IsCurrent, "certificate should no longer be current")
+
+	_, err = FindCertificateByVerificationCode(ms.DB, "NOTACODE")
+	ms.EqualAppError(api.AppError{Key: api.ErrorCertificateNotFound, Category: api.CategoryNotFound}, err)
+}
+
+func (ms *ModelSuite) TestCertificate_Verify_OtherPolicies() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 2, ItemsPerPolicy: 2})
+	ctx := CreateTestContext(f.Users[0])
+
+	item := UpdateItemStatus(ms.DB, f.Items[0], api.ItemCoverageStatusApproved, "")
+	otherItem := UpdateItemStatus(ms.DB, f.Items[2], api.ItemCoverageStatusApproved, "")
+	ms.NotEqual(item.PolicyID, otherItem.PolicyID, "fixture items should be on different policies")
+
+	policyCert, err := NewPolicyCertificate(ctx, f.Policies[0])
+	ms.NoError(err)
+	itemCert, err := NewItemCertificate(ctx, item)
+	ms.NoError(err)
+
+	for _, cert := range []Certificate{policyCert, itemCert} {
+		got := cert.Verify(ms.DB)
+		ms.Len(got.Items, 1, "incorrect number of items")
+		for _, i := range got.Items {
+			ms.NotEqual(otherItem.SerialNumber, i.SerialNumber, "an item of another policy should not be listed")
+		}
+	}
+
+	// an item certificate only lists an item of its own policy
+	itemCert.ItemID = nulls.NewUUID(otherItem.ID)
+	ms.Len(itemCert.Verify(ms.DB).Items, 0, "an item of another policy should not be listed")
+}
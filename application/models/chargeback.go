@@ -0,0 +1,319 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+var chargebackHeader = []string{
+	"Parent Entity", "Entity", "Cost Center", "Policy", "Item", "Premiums", "Refunds", "Claims", "Adjustments", "Net",
+}
+
+// chargebackTotals holds the sums of the ledger entries charged to an entity. Amounts follow the ledger convention:
+// charges are negative and reimbursements are positive.
+type chargebackTotals struct {
+	Premiums    api.Currency
+	Refunds     api.Currency
+	Claims      api.Currency
+	Adjustments api.Currency
+	Net         api.Currency
+}
+
+// chargebackRow holds the totals of a single item on a team policy
+type chargebackRow struct {
+	ParentEntity string
+	Entity       string
+	CostCenter   string
+	PolicyName   string
+	ItemID       nulls.UUID
+	ItemName     string
+	chargebackTotals
+}
+
+// chargebackEntry is a ledger entry with the item it is for. The item of a claim entry is that of the claim.
+type chargebackEntry struct {
+	Type       LedgerEntryType `db:"type"`
+	Amount     api.Currency    `db:"amount"`
+	EntityCode string          `db:"entity_code"`
+	CostCenter string          `db:"cost_center"`
+	PolicyID   uuid.UUID       `db:"policy_id"`
+	PolicyName string          `db:"policy_name"`
+	ItemID     nulls.UUID      `db:"item_id"`
+	ItemName   string          `db:"item_name"`
+}
+
+// NewChargebackReport creates a report of the ledger entries of team policies submitted between the given dates,
+// grouped by entity code (rolled up to the parent entity), cost center, policy and item. If an entity code is
+// given, only the entries of that entity and its child entities are included. It is rendered in the given format
+// (csv or pdf) and stored as an unlinked File.
+func NewChargebackReport(ctx context.Context, start, end time.Time, entityCode, format string) (File, error) {
+	if end.Before(start) {
+		err := fmt.Errorf("end date %s is before start date %s",
+			end.Format(domain.DateFormat), start.Format(domain.DateFormat))
+		return File{}, api.NewAppError(err, api.ErrorInvalidDate, api.CategoryUser)
+	}
+
+	tx := Tx(ctx)
+
+	if entityCode != "" {
+		ec := EntityCode{Code: entityCode}
+		if err := ec.FindByCode(tx); err != nil {
+			return File{}, err
+		}
+	}
+
+	rows, err := chargebackRows(tx, start, end, entityCode)
+	if err != nil {
+		return File{}, err
+	}
+
+	var content []byte
+	var contentType string
+	switch format {
+	case api.ExportFormatCSV:
+		content, err = renderChargebackCSV(rows)
+		contentType = domain.ContentCSV
+	case api.ExportFormatPDF:
+		content, err = renderChargebackPDF(rows, start, end)
+		contentType = domain.ContentPDF
+	default:
+		err := errors.New("invalid export format: " + format)
+		return File{}, api.NewAppError(err, api.ErrorInvalidExportFormat, api.CategoryUser)
+	}
+	if err != nil {
+		return File{}, api.NewAppError(err, api.ErrorUnableToStoreFile, api.CategoryInternal)
+	}
+
+	name := "chargeback"
+	if entityCode != "" {
+		name += "_" + entityCode
+	}
+	f := File{
+		Name: fmt.Sprintf("%s_%s_%s_%s.%s", domain.Env.AppName, name,
+			start.Format(domain.DateFormat), end.Format(domain.DateFormat), format),
+		Content:     content,
+		ContentType: contentType,
+		CreatedByID: CurrentUser(ctx).ID,
+	}
+	if err := f.Store(tx); err != nil {
+		return File{}, err
+	}
+	return f, nil
+}
+
+// chargebackRows returns one row per entity, cost center, policy and item of the ledger entries of team policies
+// submitted between the given dates (inclusive), sorted by parent entity, entity, cost center, policy and item. If
+// an entity code is given, only the entries of that entity and its child entities are included.
+func chargebackRows(tx *pop.Connection, start, end time.Time, entityCode string) ([]chargebackRow, error) {
+	query := `
+		SELECT le.type, le.amount, le.entity_code, le.cost_center, le.policy_id, le.policy_name, i.id AS item_id,
+			COALESCE(i.name, '') AS item_name
+		FROM ledger_entries le
+		LEFT JOIN items i ON i.id = COALESCE(le.item_id,
+			(SELECT ci.item_id FROM claim_items ci WHERE ci.claim_id = le.claim_id ORDER BY ci.created_at LIMIT 1))
+		WHERE le.policy_type = ? AND le.date_submitted >= ? AND le.date_submitted < ?`
+	args := []any{api.PolicyTypeTeam, start, end.AddDate(0, 0, 1)}
+	if entityCode != "" {
+		query += `
+			AND (le.entity_code = ? OR le.entity_code IN (SELECT code FROM entity_codes WHERE parent_entity = ?))`
+		args = append(args, entityCode, entityCode)
+	}
+
+	var entries []chargebackEntry
+	if err := tx.RawQuery(query, args...).All(&entries); err != nil {
+		return nil, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	var entityCodes EntityCodes
+	if err := entityCodes.All(tx); err != nil {
+		return nil, err
+	}
+	parents := map[string]string{}
+	for _, e := range entityCodes {
+		parents[e.Code] = e.ParentEntity
+	}
+
+	type rowKey struct {
+		entity, costCenter string
+		policyID           uuid.UUID
+		itemID             nulls.UUID
+	}
+	rowsByKey := map[rowKey]*chargebackRow{}
+	for _, e := range entries {
+		key := rowKey{e.EntityCode, e.CostCenter, e.PolicyID, e.ItemID}
+
+		r, ok := rowsByKey[key]
+		if !ok {
+			parent := parents[e.EntityCode]
+			if parent == "" {
+				parent = e.EntityCode
+			}
+			r = &chargebackRow{
+				ParentEntity: parent,
+				Entity:       e.EntityCode,
+				CostCenter:   e.CostCenter,
+				PolicyName:   e.PolicyName,
+				ItemID:       e.ItemID,
+				ItemName:     e.ItemName,
+			}
+			rowsByKey[key] = r
+		}
+		r.add(e.Type, e.Amount)
+	}
+
+	rows := make([]chargebackRow, 0, len(rowsByKey))
+	for _, r := range rowsByKey {
+		rows = append(rows, *r)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.ParentEntity != b.ParentEntity {
+			return a.ParentEntity < b.ParentEntity
+		}
+		if a.Entity != b.Entity {
+			return a.Entity < b.Entity
+		}
+		if a.CostCenter != b.CostCenter {
+			return a.CostCenter < b.CostCenter
+		}
+		if a.PolicyName != b.PolicyName {
+			return a.PolicyName < b.PolicyName
+		}
+		if a.ItemName != b.ItemName {
+			return a.ItemName < b.ItemName
+		}
+		return a.ItemID.UUID.String() < b.ItemID.UUID.String()
+	})
+	return rows, nil
+}
+
+func (t *chargebackTotals) add(entryType LedgerEntryType, amount api.Currency) {
+	switch entryType {
+	case LedgerEntryTypeNewCoverage, LedgerEntryTypeCoverageChange, LedgerEntryTypeCoverageRenewal:
+		t.Premiums += amount
+	case LedgerEntryTypeCoverageRefund:
+		t.Refunds += amount
+	case LedgerEntryTypeClaim, LedgerEntryTypeClaimAdjustment:
+		t.Claims += amount
+	default:
+		t.Adjustments += amount
+	}
+	t.Net += amount
+}
+
+func (t *chargebackTotals) addTotals(o chargebackTotals) {
+	t.Premiums += o.Premiums
+	t.Refunds += o.Refunds
+	t.Claims += o.Claims
+	t.Adjustments += o.Adjustments
+	t.Net += o.Net
+}
+
+func renderChargebackCSV(rows []chargebackRow) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(chargebackHeader); err != nil {
+		return nil, err
+	}
+
+	for _, r := range rows {
+		record := []string{
+			r.ParentEntity,
+			r.Entity,
+			r.CostCenter,
+			r.PolicyName,
+			r.ItemName,
+			r.Premiums.String(),
+			r.Refunds.String(),
+			r.Claims.String(),
+			r.Adjustments.String(),
+			r.Net.String(),
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func renderChargebackPDF(rows []chargebackRow, start, end time.Time) ([]byte, error) {
+	doc := newPDFDocument("Entity Chargeback Report")
+
+	doc.labeledValue("Period", pdfDate(start)+" to "+pdfDate(end))
+	doc.labeledValue("Date", pdfDate(time.Now().UTC()))
+	doc.paragraph("Charges to an entity are shown as negative amounts and reimbursements as positive amounts. " +
+		"Entities are grouped under their parent entity.")
+
+	if len(rows) == 0 {
+		doc.paragraph("There were no transactions on team policies during the period.")
+		return doc.render()
+	}
+
+	// rows are sorted, so each parent entity and each entity is a contiguous block
+	for i := 0; i < len(rows); {
+		parent := rows[i].ParentEntity
+		var parentTotals chargebackTotals
+		j := i
+		for ; j < len(rows) && rows[j].ParentEntity == parent; j++ {
+			parentTotals.addTotals(rows[j].chargebackTotals)
+		}
+
+		doc.heading("Parent entity " + parent)
+		writeChargebackTotals(doc, parentTotals)
+
+		for k := i; k < j; {
+			entity := rows[k].Entity
+			var entityTotals chargebackTotals
+			var tableRows [][]string
+			for ; k < j && rows[k].Entity == entity; k++ {
+				r := rows[k]
+				entityTotals.addTotals(r.chargebackTotals)
+				tableRows = append(tableRows, []string{
+					r.CostCenter,
+					r.PolicyName,
+					r.ItemName,
+					r.Premiums.Dollars(),
+					r.Refunds.Dollars(),
+					r.Claims.Dollars(),
+					r.Adjustments.Dollars(),
+					r.Net.Dollars(),
+				})
+			}
+
+			doc.Ln(2)
+			doc.labeledValue("Entity", entity)
+			doc.labeledValue("Net", entityTotals.Net.Dollars())
+			doc.table(
+				[]string{"Cost Center", "Policy", "Item", "Premiums", "Refunds", "Claims", "Adjust.", "Net"},
+				[]float64{30, 30, 34, 19, 19, 19, 19, 20},
+				tableRows,
+			)
+		}
+		i = j
+	}
+
+	return doc.render()
+}
+
+func writeChargebackTotals(doc *pdfDocument, t chargebackTotals) {
+	doc.labeledValue("Premiums", t.Premiums.Dollars())
+	doc.labeledValue("Refunds", t.Refunds.Dollars())
+	doc.labeledValue("Claims", t.Claims.Dollars())
+	doc.labeledValue("Adjustments", t.Adjustments.Dollars())
+	doc.labeledValue("Net", t.Net.Dollars())
+}
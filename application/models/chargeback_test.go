@@ -0,0 +1,140 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+func (ms *ModelSuite) TestNewChargebackReport() {
+	f := CreateLedgerFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 1, ItemsPerPolicy: 1})
+	ctx := CreateTestContext(CreateAdminUsers(ms.DB)[AppRoleSteward])
+
+	entry := f.LedgerEntries[0]
+	entry.PolicyType = api.PolicyTypeTeam
+	entry.EntityCode = CreateEntityFixture(ms.DB).Code
+	ms.NoError(ms.DB.UpdateColumns(&entry, "policy_type", "entity_code"))
+
+	now := time.Now().UTC()
+
+	tests := []struct {
+		name            string
+		start           time.Time
+		end             time.Time
+		entityCode      string
+		format          string
+		wantContentType string
+		wantErr         *api.AppError
+	}{
+		{
+			name:    "end before start",
+			start:   now,
+			end:     now.AddDate(0, 0, -1),
+			format:  api.ExportFormatCSV,
+			wantErr: &api.AppError{Key: api.ErrorInvalidDate, Category: api.CategoryUser},
+		},
+		{
+			name:    "invalid format",
+			start:   now,
+			end:     now,
+			format:  "doc",
+			wantErr: &api.AppError{Key: api.ErrorInvalidExportFormat, Category: api.CategoryUser},
+		},
+		{
+			name:       "unknown entity code",
+			start:      now,
+			end:        now,
+			entityCode: "nope",
+			format:     api.ExportFormatCSV,
+			wantErr:    &api.AppError{Key: api.ErrorNoRows, Category: api.CategoryUser},
+		},
+		{
+			name:            "csv",
+			start:           now,
+			end:             now,
+			format:          api.ExportFormatCSV,
+			wantContentType: domain.ContentCSV,
+		},
+		{
+			name:            "pdf",
+			start:           now,
+			end:             now,
+			format:          api.ExportFormatPDF,
+			wantContentType: domain.ContentPDF,
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			got, err := NewChargebackReport(ctx, tt.start, tt.end, tt.entityCode, tt.format)
+			if tt.wantErr != nil {
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+			ms.Equal(tt.wantContentType, got.ContentType, "incorrect content type")
+			ms.False(got.Linked, "report file should not be linked")
+			ms.Contains(got.Name, "chargeback")
+		})
+	}
+}
+
+func (ms *ModelSuite) Test_chargebackRows() {
+	f := CreateLedgerFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 3, ItemsPerPolicy: 1})
+
+	parent := CreateEntityFixture(ms.DB)
+	child := CreateEntityFixture(ms.DB)
+	child.ParentEntity = parent.Code
+	ms.NoError(child.Update(ms.DB))
+
+	// the third entry stays on a household policy and is not charged back
+	entityCodes := []string{child.Code, parent.Code}
+	for i, code := range entityCodes {
+		e := f.LedgerEntries[i]
+		e.PolicyType = api.PolicyTypeTeam
+		e.EntityCode = code
+		e.CostCenter = "CC" + code
+		ms.NoError(ms.DB.UpdateColumns(&e, "policy_type", "entity_code", "cost_center"))
+	}
+
+	itemIDs := map[string]nulls.UUID{child.Code: f.LedgerEntries[0].ItemID, parent.Code: f.LedgerEntries[1].ItemID}
+
+	now := time.Now().UTC()
+	rows, err := chargebackRows(ms.DB, now.AddDate(0, 0, -1), now, "")
+	ms.NoError(err)
+	ms.Len(rows, 2, "incorrect number of chargeback rows")
+
+	var net api.Currency
+	for i, r := range rows {
+		ms.Equal(parent.Code, r.ParentEntity, "entity should be rolled up to its parent")
+		ms.Equal("CC"+r.Entity, r.CostCenter)
+		ms.Equal(r.Premiums, r.Net)
+		ms.Equal(itemIDs[r.Entity], r.ItemID, "row should be for the item of the entry")
+		if i > 0 {
+			ms.True(rows[i-1].Entity <= r.Entity, "rows are not sorted by entity")
+		}
+		net += r.Net
+	}
+	ms.Equal(f.LedgerEntries[0].Amount+f.LedgerEntries[1].Amount, net)
+
+	rows, err = chargebackRows(ms.DB, now.AddDate(0, 0, -1), now, parent.Code)
+	ms.NoError(err)
+	ms.Len(rows, 2, "a parent entity should include its children")
+
+	rows, err = chargebackRows(ms.DB, now.AddDate(0, 0, -1), now, child.Code)
+	ms.NoError(err)
+	ms.Len(rows, 1, "a child entity should not include its parent")
+	ms.Equal(child.Code, rows[0].Entity)
+
+	rows, err = chargebackRows(ms.DB, now.AddDate(0, 0, 1), now.AddDate(0, 0, 2), "")
+	ms.NoError(err)
+	ms.Len(rows, 0, "entries outside the period should not be included")
+
+	csv, err := renderChargebackCSV(rows)
+	ms.NoError(err)
+	ms.Equal(strings.Join(chargebackHeader, ","), strings.TrimSpace(string(csv)))
+}
@@ -307,15 +307,16 @@ func (c *Claim) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permission
 		return false
 	}
 
-	policy.LoadMembers(tx, false)
-
-	for _, m := range policy.Members {
-		if m.ID == actor.ID {
-			return true
-		}
+	role, isMember := policy.memberRole(tx, actor.ID)
+	if !isMember {
+		return false
 	}
 
-	return false
+	// viewers may only view claims
+	if role == api.PolicyUserRoleViewer {
+		return perm == PermissionView
+	}
+	return true
 }
 
 func claimStatusTransitions() map[api.ClaimStatus][]api.ClaimStatus {
@@ -596,6 +597,9 @@ func (c *Claim) Approve(ctx context.Context) error {
 	emitEvent(e)
 
 	if c.Status == api.ClaimStatusApproved {
+		if err := c.applyStrikeRules(Tx(ctx)); err != nil {
+			return err
+		}
 		return c.CreateLedgerEntry(Tx(ctx))
 	}
 	return nil
@@ -1037,21 +1041,7 @@ func (c *Claim) GetDeductibleRate(tx *pop.Connection) float64 {
 	}
 
 	c.LoadPolicy(tx, false)
-	var strikes Strikes
-	err := strikes.RecentForPolicy(tx, c.PolicyID, cutOff)
-
-	if domain.IsOtherThanNoRows(err) {
-		log.Errorf("error retrieving recent strikes for claim %s: %s", c.ID.String(), err)
-		return domain.Env.DeductibleRate
-	}
-
-	extra := domain.Env.DeductibleIncrease * float64(len(strikes))
-
-	d := domain.Env.DeductibleRate + extra
-	if d >= domain.Env.DeductibleMaximum {
-		return domain.Env.DeductibleMaximum
-	}
-	return d
+	return c.Policy.DeductibleRate(tx, cutOff)
 }
 
 // StopItemCoverage sets the claim's items' statuses to `Inactive` and creates refund ledger entries for them.
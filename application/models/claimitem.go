@@ -425,7 +425,18 @@ func (c *ClaimItem) NewHistory(ctx context.Context, action string, fieldUpdate F
 }
 
 func (c *ClaimItem) updatePayoutAmount(ctx context.Context) error {
-	tx := Tx(ctx)
+	payout := c.calculatePayout(Tx(ctx), false)
+	if c.PayoutAmount == payout {
+		return nil
+	}
+
+	c.PayoutAmount = payout
+	return c.Update(ctx)
+}
+
+// calculatePayout returns the payout for the claim item, limited by the coverage amount of the item and reduced by
+// the deductible. If useCoverage is true, the coverage amount is used in place of a missing estimate.
+func (c *ClaimItem) calculatePayout(tx *pop.Connection, useCoverage bool) api.Currency {
 	c.LoadItem(tx, false)
 	c.LoadClaim(tx, false)
 
@@ -451,20 +462,16 @@ func (c *ClaimItem) updatePayoutAmount(ctx context.Context) error {
 		deductibleRate = domain.Env.EvacuationDeductible
 		maxValue = coverageAmount
 	}
+	if useCoverage && maxValue == 0 {
+		maxValue = coverageAmount
+	}
 
 	coverageAmount = math.Min(maxValue, coverageAmount)
 	deductibleAmount := math.Round(coverageAmount * deductibleRate)
 
-	c.LoadItem(tx, false)
 	c.Item.LoadCategory(tx, false)
 	minDeductibleAmount := float64(c.Item.Category.MinimumDeductible)
 	deductibleAmount = math.Max(deductibleAmount, minDeductibleAmount)
 
-	payout := api.Currency(math.Max(0, math.Round(coverageAmount-deductibleAmount)))
-	if c.PayoutAmount == payout {
-		return nil
-	}
-
-	c.PayoutAmount = payout
-	return c.Update(ctx)
+	return api.Currency(math.Max(0, math.Round(coverageAmount-deductibleAmount)))
 }
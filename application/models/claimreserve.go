@@ -0,0 +1,299 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+// ReserveClaimStatuses are the statuses of claims that may still result in a payout
+var ReserveClaimStatuses = []api.ClaimStatus{
+	api.ClaimStatusReview1,
+	api.ClaimStatusReview2,
+	api.ClaimStatusReview3,
+	api.ClaimStatusReceipt,
+	api.ClaimStatusRevision,
+	api.ClaimStatusApproved,
+}
+
+// reserveAges are the age groups of the claim reserves, by the maximum number of days since submission
+var reserveAges = []struct {
+	maxDays int
+	name    string
+}{
+	{30, "0-30 days"},
+	{60, "31-60 days"},
+	{90, "61-90 days"},
+	{180, "91-180 days"},
+	{-1, "over 180 days"},
+}
+
+var reserveHeader = []string{
+	"Claim", "Status", "Item", "Risk Category", "Submitted", "Age (days)", "Age", "Coverage Amount", "Reserve",
+}
+
+type ClaimReserveReports []ClaimReserveReport
+
+// ClaimReserveReport is a month-end snapshot of the claim reserves, with the CSV file that was archived for finance
+type ClaimReserveReport struct {
+	ID           uuid.UUID    `db:"id"`
+	ReportDate   time.Time    `db:"report_date" validate:"required"` // last day of the month
+	FileID       uuid.UUID    `db:"file_id" validate:"required"`
+	ClaimCount   int          `db:"claim_count" validate:"min=0"`
+	TotalReserve api.Currency `db:"total_reserve"`
+	CreatedAt    time.Time    `db:"created_at"`
+	UpdatedAt    time.Time    `db:"updated_at"`
+
+	File File `belongs_to:"files" validate:"-"`
+}
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (r *ClaimReserveReport) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validateModel(r), nil
+}
+
+// Create stores the report File and then the ClaimReserveReport record
+func (r *ClaimReserveReport) Create(tx *pop.Connection) error {
+	r.File.Linked = true
+	if err := r.File.Store(tx); err != nil {
+		return err
+	}
+	r.FileID = r.File.ID
+
+	return create(tx, r)
+}
+
+// LoadFile - a simple wrapper method for loading the file on the struct
+func (r *ClaimReserveReport) LoadFile(tx *pop.Connection, reload bool) {
+	if r.File.ID == uuid.Nil || reload {
+		if err := tx.Load(r, "File"); err != nil {
+			panic("database error loading ClaimReserveReport.File, " + err.Error())
+		}
+	}
+}
+
+// All loads all the month-end reserve reports, most recent first
+func (r *ClaimReserveReports) All(tx *pop.Connection) error {
+	return appErrorFromDB(tx.Order("report_date desc").All(r), api.ErrorQueryFailure)
+}
+
+// ClaimReserveReportExists returns true if there is a reserve report for the month that ends on the given date
+func ClaimReserveReportExists(tx *pop.Connection, reportDate time.Time) (bool, error) {
+	exists, err := tx.Where("report_date = ?", reportDate).Exists(&ClaimReserveReport{})
+	if err != nil {
+		return false, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	return exists, nil
+}
+
+// NewClaimReserveReport estimates the current claim reserves and archives them as the report of the month that
+// ends on the given date
+func NewClaimReserveReport(ctx context.Context, reportDate time.Time) (ClaimReserveReport, error) {
+	tx := Tx(ctx)
+
+	reserves, err := ClaimReserves(tx, time.Now().UTC())
+	if err != nil {
+		return ClaimReserveReport{}, err
+	}
+
+	content, err := renderReservesCSV(reserves)
+	if err != nil {
+		return ClaimReserveReport{}, api.NewAppError(err, api.ErrorUnableToStoreFile, api.CategoryInternal)
+	}
+
+	r := ClaimReserveReport{
+		ReportDate:   reportDate,
+		ClaimCount:   reserves.ClaimCount,
+		TotalReserve: reserves.TotalReserve,
+		File: File{
+			Name: fmt.Sprintf("%s_claim_reserves_%s.csv",
+				domain.Env.AppName, reportDate.Format(domain.DateFormat)),
+			Content:     content,
+			ContentType: domain.ContentCSV,
+			CreatedByID: CurrentUser(ctx).ID,
+		},
+	}
+	if err := r.Create(tx); err != nil {
+		return ClaimReserveReport{}, err
+	}
+	return r, nil
+}
+
+// ClaimReserves estimates the outstanding liability of each item of the claims that may still result in a payout.
+// An approved claim is reserved at its payout amount. Other claims are reserved at the payout calculated from the
+// estimates given so far, or from the coverage amount if there is no estimate.
+func ClaimReserves(tx *pop.Connection, asOf time.Time) (api.ClaimReserves, error) {
+	var claims Claims
+	if err := tx.Where("status IN (?)", ReserveClaimStatuses).All(&claims); err != nil {
+		return api.ClaimReserves{}, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	reserves := api.ClaimReserves{
+		AsOf:       asOf.Format(domain.DateFormat),
+		ClaimCount: len(claims),
+		Lines:      []api.ClaimReserve{},
+	}
+
+	for i := range claims {
+		c := &claims[i]
+		submitted := c.SubmittedAt(tx)
+		ageDays := int(asOf.Sub(submitted).Hours() / 24)
+		if ageDays < 0 {
+			ageDays = 0
+		}
+
+		c.LoadClaimItems(tx, false)
+		for j := range c.ClaimItems {
+			ci := &c.ClaimItems[j]
+			ci.Claim = *c
+			ci.LoadItem(tx, false)
+			ci.Item.LoadRiskCategory(tx, false)
+
+			reserve := ci.PayoutAmount
+			if c.Status != api.ClaimStatusApproved {
+				reserve = ci.calculatePayout(tx, true)
+			}
+
+			reserves.Lines = append(reserves.Lines, api.ClaimReserve{
+				ClaimID:         c.ID,
+				ClaimItemID:     ci.ID,
+				ReferenceNumber: c.ReferenceNumber,
+				Status:          c.Status,
+				ItemName:        ci.Item.Name,
+				RiskCategory:    ci.Item.RiskCategory.Name,
+				SubmittedDate:   submitted.Format(domain.DateFormat),
+				AgeDays:         ageDays,
+				Age:             reserveAge(ageDays),
+				CoverageAmount:  api.Currency(ci.Item.CoverageAmount),
+				Reserve:         reserve,
+			})
+			reserves.TotalReserve += reserve
+		}
+	}
+
+	sort.SliceStable(reserves.Lines, func(i, j int) bool {
+		return reserves.Lines[i].AgeDays > reserves.Lines[j].AgeDays
+	})
+
+	reserves.RiskCategories = reserveTotals(reserves.Lines, func(l api.ClaimReserve) string { return l.RiskCategory })
+	sort.Slice(reserves.RiskCategories, func(i, j int) bool {
+		return reserves.RiskCategories[i].Name < reserves.RiskCategories[j].Name
+	})
+
+	ages := reserveTotals(reserves.Lines, func(l api.ClaimReserve) string { return l.Age })
+	reserves.Ages = make([]api.ClaimReserveTotal, 0, len(ages))
+	for _, a := range reserveAges {
+		for _, t := range ages {
+			if t.Name == a.name {
+				reserves.Ages = append(reserves.Ages, t)
+			}
+		}
+	}
+
+	return reserves, nil
+}
+
+func reserveAge(days int) string {
+	for _, a := range reserveAges {
+		if a.maxDays < 0 || days <= a.maxDays {
+			return a.name
+		}
+	}
+	return ""
+}
+
+// reserveTotals sums the reserve lines by the group name returned by the given function. Each claim is counted once
+// per group.
+func reserveTotals(lines []api.ClaimReserve, group func(api.ClaimReserve) string) []api.ClaimReserveTotal {
+	totals := map[string]*api.ClaimReserveTotal{}
+	claims := map[string]map[uuid.UUID]struct{}{}
+	for _, l := range lines {
+		name := group(l)
+		t, ok := totals[name]
+		if !ok {
+			t = &api.ClaimReserveTotal{Name: name}
+			totals[name] = t
+			claims[name] = map[uuid.UUID]struct{}{}
+		}
+		t.Reserve += l.Reserve
+		claims[name][l.ClaimID] = struct{}{}
+	}
+
+	list := make([]api.ClaimReserveTotal, 0, len(totals))
+	for name, t := range totals {
+		t.ClaimCount = len(claims[name])
+		list = append(list, *t)
+	}
+	return list
+}
+
+// renderReservesCSV writes the reserve lines followed by the totals by risk category and by age
+func renderReservesCSV(reserves api.ClaimReserves) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	records := [][]string{reserveHeader}
+	for _, l := range reserves.Lines {
+		records = append(records, []string{
+			l.ReferenceNumber,
+			string(l.Status),
+			l.ItemName,
+			l.RiskCategory,
+			l.SubmittedDate,
+			strconv.Itoa(l.AgeDays),
+			l.Age,
+			l.CoverageAmount.String(),
+			l.Reserve.String(),
+		})
+	}
+
+	records = append(records, []string{}, []string{"Risk Category", "Claims", "Reserve"})
+	for _, t := range reserves.RiskCategories {
+		records = append(records, []string{t.Name, strconv.Itoa(t.ClaimCount), t.Reserve.String()})
+	}
+
+	records = append(records, []string{}, []string{"Age", "Claims", "Reserve"})
+	for _, t := range reserves.Ages {
+		records = append(records, []string{t.Name, strconv.Itoa(t.ClaimCount), t.Reserve.String()})
+	}
+
+	records = append(records, []string{},
+		[]string{"Total", strconv.Itoa(reserves.ClaimCount), reserves.TotalReserve.String()})
+
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (r *ClaimReserveReport) ConvertToAPI(tx *pop.Connection) api.ClaimReserveReport {
+	r.LoadFile(tx, false)
+
+	return api.ClaimReserveReport{
+		ID:           r.ID,
+		ReportDate:   r.ReportDate.Format(domain.DateFormat),
+		ClaimCount:   r.ClaimCount,
+		TotalReserve: r.TotalReserve,
+		File:         r.File.ConvertToAPI(tx),
+		CreatedAt:    r.CreatedAt,
+	}
+}
+
+func (r *ClaimReserveReports) ConvertToAPI(tx *pop.Connection) api.ClaimReserveReports {
+	reports := make(api.ClaimReserveReports, len(*r))
+	for i := range *r {
+		reports[i] = (*r)[i].ConvertToAPI(tx)
+	}
+	return reports
+}
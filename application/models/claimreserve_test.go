@@ -0,0 +1,95 @@
+package models
+
+import (
+	"time"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+func (ms *ModelSuite) TestClaimReserves() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{
+		NumberOfPolicies:   3,
+		ItemsPerPolicy:     1,
+		ClaimsPerPolicy:    1,
+		ClaimItemsPerClaim: 1,
+	})
+
+	inReview := UpdateClaimStatus(ms.DB, f.Claims[0], api.ClaimStatusReview1, "")
+	approved := UpdateClaimStatus(ms.DB, f.Claims[1], api.ClaimStatusApproved, "")
+	// f.Claims[2] is a draft and is not reserved
+
+	reviewItem := inReview.ClaimItems[0]
+	wantReviewReserve := reviewItem.calculatePayout(ms.DB, false)
+	wantApprovedReserve := approved.ClaimItems[0].PayoutAmount
+
+	got, err := ClaimReserves(ms.DB, time.Now().UTC().AddDate(0, 0, 45))
+	ms.NoError(err)
+
+	ms.Equal(2, got.ClaimCount)
+	ms.Len(got.Lines, 2)
+	ms.Equal(wantReviewReserve+wantApprovedReserve, got.TotalReserve)
+
+	for _, l := range got.Lines {
+		switch l.ClaimID {
+		case inReview.ID:
+			ms.Equal(wantReviewReserve, l.Reserve)
+		case approved.ID:
+			ms.Equal(wantApprovedReserve, l.Reserve)
+		default:
+			ms.Fail("unexpected claim in reserves", l.ReferenceNumber)
+		}
+		ms.Equal("31-60 days", l.Age)
+	}
+
+	ms.Equal([]api.ClaimReserveTotal{{Name: "31-60 days", ClaimCount: 2, Reserve: got.TotalReserve}}, got.Ages)
+
+	var riskCategoryTotal api.Currency
+	for _, t := range got.RiskCategories {
+		riskCategoryTotal += t.Reserve
+	}
+	ms.Equal(got.TotalReserve, riskCategoryTotal)
+}
+
+func (ms *ModelSuite) TestClaimItem_calculatePayout() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ClaimsPerPolicy: 1, ClaimItemsPerClaim: 1})
+	claimItem := f.Claims[0].ClaimItems[0]
+
+	claimItem.PayoutOption = api.PayoutOptionReplacement
+	claimItem.ReplaceEstimate = 0
+	claimItem.ReplaceActual = 0
+
+	ms.Equal(api.Currency(0), claimItem.calculatePayout(ms.DB, false), "payout without an estimate should be zero")
+
+	got := claimItem.calculatePayout(ms.DB, true)
+	ms.Greater(int(got), 0, "reserve without an estimate should be based on the coverage amount")
+	ms.LessOrEqual(int(got), claimItem.Item.CoverageAmount)
+}
+
+func (ms *ModelSuite) TestNewClaimReserveReport() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ClaimsPerPolicy: 1, ClaimItemsPerClaim: 1})
+	claim := UpdateClaimStatus(ms.DB, f.Claims[0], api.ClaimStatusReceipt, "")
+
+	ctx := CreateTestContext(CreateAdminUsers(ms.DB)[AppRoleSteward])
+	reportDate := domain.EndOfMonth(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC))
+
+	got, err := NewClaimReserveReport(ctx, reportDate)
+	ms.NoError(err)
+	ms.Equal(1, got.ClaimCount)
+	ms.True(got.File.Linked, "report file should be linked")
+	ms.Equal(domain.ContentCSV, got.File.ContentType)
+	ms.Contains(string(got.File.Content), claim.ReferenceNumber)
+
+	exists, err := ClaimReserveReportExists(ms.DB, reportDate)
+	ms.NoError(err)
+	ms.True(exists)
+
+	exists, err = ClaimReserveReportExists(ms.DB, reportDate.AddDate(0, 1, 0))
+	ms.NoError(err)
+	ms.False(exists)
+
+	var reports ClaimReserveReports
+	ms.NoError(reports.All(ms.DB))
+	ms.Len(reports, 1)
+	ms.Equal(got.TotalReserve, reports.ConvertToAPI(ms.DB)[0].TotalReserve)
+}
@@ -0,0 +1,211 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gobuffalo/events"
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+type CoverageLimits []CoverageLimit
+
+// CoverageLimit overrides the global PolicyMaxCoverage for a single policy or for all the policies with an
+// entity code, until its expiration date. Limits above CoverageLimitApprovalThreshold are not in force
+// until they are approved by a Signator.
+type CoverageLimit struct {
+	ID             uuid.UUID  `db:"id"`
+	PolicyID       nulls.UUID `db:"policy_id"`
+	EntityCodeID   nulls.UUID `db:"entity_code_id"`
+	MaxCoverage    int        `db:"max_coverage" validate:"min=0"`
+	ExpirationDate time.Time  `db:"expiration_date" validate:"required"`
+	Reason         string     `db:"reason"`
+	CreatedByID    uuid.UUID  `db:"created_by_id" validate:"required"`
+	ApprovedByID   nulls.UUID `db:"approved_by_id"`
+	ApprovedAt     nulls.Time `db:"approved_at"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+// Validate gets run every time you call pop.ValidateAndSave, pop.ValidateAndCreate, or pop.ValidateAndUpdate
+func (c *CoverageLimit) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validateModel(c), nil
+}
+
+// Create stores the data as a new record in the database.
+func (c *CoverageLimit) Create(tx *pop.Connection) error {
+	return create(tx, c)
+}
+
+func (c *CoverageLimit) Destroy(tx *pop.Connection) error {
+	return destroy(tx, c)
+}
+
+func (c *CoverageLimit) GetID() uuid.UUID {
+	return c.ID
+}
+
+func (c *CoverageLimit) FindByID(tx *pop.Connection, id uuid.UUID) error {
+	return tx.Find(c, id)
+}
+
+// IsActorAllowedTo ensures the actor is an admin. Only a Signator may approve a limit.
+func (c *CoverageLimit) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permission, sub SubResource, req *http.Request) bool {
+	if sub == api.ResourceApprove {
+		return actor.AppRole == AppRoleSignator
+	}
+	return actor.IsAdmin()
+}
+
+// NeedsApproval returns true if the limit is above the approval threshold and has not been approved
+func (c *CoverageLimit) NeedsApproval() bool {
+	return c.MaxCoverage > domain.Env.CoverageLimitApprovalThreshold && !c.ApprovedAt.Valid
+}
+
+// NewCoverageLimit creates a coverage limit override from the API input. If the limit is above the approval
+// threshold, the Signators are notified.
+func NewCoverageLimit(ctx context.Context, input api.CoverageLimitInput) (CoverageLimit, error) {
+	tx := Tx(ctx)
+
+	if (input.PolicyID == nil) == (input.EntityCodeID == nil) {
+		err := errors.New("a coverage limit must have either a policy ID or an entity code ID")
+		return CoverageLimit{}, api.NewAppError(err, api.ErrorCoverageLimitInvalid, api.CategoryUser)
+	}
+
+	expiration, err := time.Parse(domain.DateFormat, input.ExpirationDate)
+	if err != nil {
+		return CoverageLimit{}, api.NewAppError(err, api.ErrorInvalidDate, api.CategoryUser)
+	}
+	if expiration.Before(domain.BeginningOfDay(time.Now().UTC())) {
+		err := errors.New("coverage limit expiration date must not be in the past")
+		return CoverageLimit{}, api.NewAppError(err, api.ErrorInvalidDate, api.CategoryUser)
+	}
+
+	limit := CoverageLimit{
+		MaxCoverage:    int(input.MaxCoverage),
+		ExpirationDate: expiration,
+		Reason:         input.Reason,
+		CreatedByID:    CurrentUser(ctx).ID,
+	}
+
+	if input.PolicyID != nil {
+		var policy Policy
+		if err := policy.FindByID(tx, *input.PolicyID); err != nil {
+			return CoverageLimit{}, appErrorFromDB(err, api.ErrorQueryFailure)
+		}
+		limit.PolicyID = nulls.NewUUID(policy.ID)
+
+		history := policy.NewHistory(ctx, api.HistoryActionUpdate, FieldUpdate{
+			FieldName: FieldPolicyMaxCoverage,
+			OldValue:  api.Currency(policy.GetMaxCoverage(tx, time.Now().UTC())).String(),
+			NewValue:  input.MaxCoverage.String(),
+		})
+		if err := history.Create(tx); err != nil {
+			return CoverageLimit{}, err
+		}
+	} else {
+		var entityCode EntityCode
+		if err := entityCode.FindByID(tx, *input.EntityCodeID); err != nil {
+			return CoverageLimit{}, appErrorFromDB(err, api.ErrorQueryFailure)
+		}
+		limit.EntityCodeID = nulls.NewUUID(entityCode.ID)
+	}
+
+	if err := limit.Create(tx); err != nil {
+		return CoverageLimit{}, err
+	}
+
+	if limit.NeedsApproval() {
+		e := events.Event{
+			Kind:    domain.EventApiCoverageLimitPending,
+			Message: fmt.Sprintf("Coverage limit needs approval: %s", limit.ID),
+			Payload: events.Payload{domain.EventPayloadID: limit.ID},
+		}
+		emitEvent(e)
+	}
+
+	return limit, nil
+}
+
+// Approve puts a coverage limit above the approval threshold into force. A Signator may not approve a
+// limit they created.
+func (c *CoverageLimit) Approve(ctx context.Context) error {
+	actor := CurrentUser(ctx)
+
+	if !c.NeedsApproval() {
+		err := errors.New("coverage limit does not need approval")
+		return api.NewAppError(err, api.ErrorCoverageLimitApproval, api.CategoryUser)
+	}
+	if c.CreatedByID == actor.ID {
+		err := errors.New("a coverage limit must be approved by someone other than its creator")
+		return api.NewAppError(err, api.ErrorCoverageLimitApproval, api.CategoryUser)
+	}
+
+	c.ApprovedByID = nulls.NewUUID(actor.ID)
+	c.ApprovedAt = nulls.NewTime(time.Now().UTC())
+	return update(Tx(ctx), c)
+}
+
+// FindActive returns all the coverage limits that have not expired, most recent first
+func (c *CoverageLimits) FindActive(tx *pop.Connection) error {
+	today := domain.BeginningOfDay(time.Now().UTC())
+	err := tx.Where("expiration_date >= ?", today).Order("created_at desc").All(c)
+	return appErrorFromDB(err, api.ErrorQueryFailure)
+}
+
+func (c *CoverageLimit) ConvertToAPI() api.CoverageLimit {
+	return api.CoverageLimit{
+		ID:             c.ID,
+		PolicyID:       convertUUIDToAPI(c.PolicyID),
+		EntityCodeID:   convertUUIDToAPI(c.EntityCodeID),
+		MaxCoverage:    api.Currency(c.MaxCoverage),
+		ExpirationDate: c.ExpirationDate.Format(domain.DateFormat),
+		Reason:         c.Reason,
+		IsApproved:     !c.NeedsApproval(),
+		CreatedByID:    c.CreatedByID,
+		ApprovedByID:   convertUUIDToAPI(c.ApprovedByID),
+		ApprovedAt:     convertTimeToAPI(c.ApprovedAt),
+		CreatedAt:      c.CreatedAt,
+	}
+}
+
+func (c *CoverageLimits) ConvertToAPI() api.CoverageLimits {
+	limits := make(api.CoverageLimits, len(*c))
+	for i := range *c {
+		limits[i] = (*c)[i].ConvertToAPI()
+	}
+	return limits
+}
+
+// GetMaxCoverage returns the maximum total coverage of the policy's items as of the given date. A limit on the
+// policy itself takes precedence over a limit on its entity code, and either one over the global default.
+func (p *Policy) GetMaxCoverage(tx *pop.Connection, date time.Time) int {
+	var limits CoverageLimits
+	err := tx.Where("(policy_id = ? OR entity_code_id = ?)", p.ID, p.EntityCodeID).
+		Where("expiration_date >= ?", domain.BeginningOfDay(date)).
+		Where("(max_coverage <= ? OR approved_at IS NOT NULL)", domain.Env.CoverageLimitApprovalThreshold).
+		Order("created_at desc").
+		All(&limits)
+	if err != nil {
+		panic("database error finding coverage limits for policy, " + err.Error())
+	}
+
+	for _, l := range limits {
+		if l.PolicyID.Valid {
+			return l.MaxCoverage
+		}
+	}
+	if len(limits) > 0 {
+		return limits[0].MaxCoverage
+	}
+	return domain.Env.PolicyMaxCoverage
+}
@@ -0,0 +1,193 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+func (ms *ModelSuite) TestNewCoverageLimit() {
+	f := CreatePolicyFixtures(ms.DB, FixturesConfig{NumberOfEntityCodes: 1})
+	steward := CreateAdminUsers(ms.DB)[AppRoleSteward]
+	ctx := CreateTestContext(steward)
+
+	policyID := f.Policies[0].ID
+	entityCodeID := f.EntityCodes[0].ID
+	nextYear := time.Now().UTC().AddDate(1, 0, 0).Format(domain.DateFormat)
+
+	tests := []struct {
+		name    string
+		input   api.CoverageLimitInput
+		wantErr *api.AppError
+	}{
+		{
+			name: "neither policy nor entity code",
+			input: api.CoverageLimitInput{
+				MaxCoverage:    1000,
+				ExpirationDate: nextYear,
+			},
+			wantErr: &api.AppError{Key: api.ErrorCoverageLimitInvalid, Category: api.CategoryUser},
+		},
+		{
+			name: "both policy and entity code",
+			input: api.CoverageLimitInput{
+				PolicyID:       &policyID,
+				EntityCodeID:   &entityCodeID,
+				MaxCoverage:    1000,
+				ExpirationDate: nextYear,
+			},
+			wantErr: &api.AppError{Key: api.ErrorCoverageLimitInvalid, Category: api.CategoryUser},
+		},
+		{
+			name: "past expiration date",
+			input: api.CoverageLimitInput{
+				PolicyID:       &policyID,
+				MaxCoverage:    1000,
+				ExpirationDate: "2020-01-01",
+			},
+			wantErr: &api.AppError{Key: api.ErrorInvalidDate, Category: api.CategoryUser},
+		},
+		{
+			name: "policy",
+			input: api.CoverageLimitInput{
+				PolicyID:       &policyID,
+				MaxCoverage:    1000,
+				ExpirationDate: nextYear,
+			},
+		},
+		{
+			name: "entity code",
+			input: api.CoverageLimitInput{
+				EntityCodeID:   &entityCodeID,
+				MaxCoverage:    1000,
+				ExpirationDate: nextYear,
+			},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			got, err := NewCoverageLimit(ctx, tt.input)
+			if tt.wantErr != nil {
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+
+			ms.Equal(steward.ID, got.CreatedByID, "incorrect CreatedByID")
+			ms.Equal(int(tt.input.MaxCoverage), got.MaxCoverage, "incorrect MaxCoverage")
+			ms.Equal(tt.input.ExpirationDate, got.ExpirationDate.Format(domain.DateFormat), "incorrect ExpirationDate")
+			ms.False(got.NeedsApproval(), "limit below the threshold should not need approval")
+
+			if tt.input.PolicyID != nil {
+				var h PolicyHistory
+				ms.NoError(ms.DB.Where("policy_id = ? AND field_name = ?", policyID, FieldPolicyMaxCoverage).First(&h))
+				ms.Equal(tt.input.MaxCoverage.String(), h.NewValue, "incorrect history")
+			}
+		})
+	}
+}
+
+func (ms *ModelSuite) TestCoverageLimit_Approve() {
+	admins := CreateAdminUsers(ms.DB)
+	otherSignator := createAdminUserWithRole(ms.DB, AppRoleSignator)
+	f := CreatePolicyFixtures(ms.DB, FixturesConfig{})
+
+	limit := CoverageLimit{
+		PolicyID:       nulls.NewUUID(f.Policies[0].ID),
+		MaxCoverage:    domain.Env.CoverageLimitApprovalThreshold + 1,
+		ExpirationDate: time.Now().UTC().AddDate(1, 0, 0),
+		CreatedByID:    admins[AppRoleSignator].ID,
+	}
+	ms.NoError(limit.Create(ms.DB))
+	ms.True(limit.NeedsApproval(), "limit above the threshold should need approval")
+
+	tests := []struct {
+		name    string
+		actor   User
+		wantErr *api.AppError
+	}{
+		{
+			name:    "creator",
+			actor:   admins[AppRoleSignator],
+			wantErr: &api.AppError{Key: api.ErrorCoverageLimitApproval, Category: api.CategoryUser},
+		},
+		{
+			name:  "good",
+			actor: otherSignator,
+		},
+		{
+			name:    "already approved",
+			actor:   otherSignator,
+			wantErr: &api.AppError{Key: api.ErrorCoverageLimitApproval, Category: api.CategoryUser},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			err := limit.Approve(CreateTestContext(tt.actor))
+			if tt.wantErr != nil {
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+
+			var dbLimit CoverageLimit
+			ms.NoError(dbLimit.FindByID(ms.DB, limit.ID))
+			ms.Equal(tt.actor.ID, dbLimit.ApprovedByID.UUID, "incorrect ApprovedByID")
+			ms.False(dbLimit.NeedsApproval(), "approved limit should not need approval")
+		})
+	}
+}
+
+func (ms *ModelSuite) TestPolicy_GetMaxCoverage() {
+	f := CreatePolicyFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 2})
+	steward := CreateAdminUsers(ms.DB)[AppRoleSteward]
+	now := time.Now().UTC()
+
+	policy := f.Policies[0]
+	otherPolicy := f.Policies[1]
+
+	createLimit := func(policyID, entityCodeID nulls.UUID, amount int, expiration time.Time) {
+		limit := CoverageLimit{
+			PolicyID:       policyID,
+			EntityCodeID:   entityCodeID,
+			MaxCoverage:    amount,
+			ExpirationDate: expiration,
+			CreatedByID:    steward.ID,
+		}
+		ms.NoError(limit.Create(ms.DB))
+	}
+
+	ms.Equal(domain.Env.PolicyMaxCoverage, policy.GetMaxCoverage(ms.DB, now), "expected the default")
+
+	createLimit(nulls.UUID{}, nulls.NewUUID(policy.EntityCodeID), 2000, now.AddDate(0, 1, 0))
+	ms.Equal(2000, policy.GetMaxCoverage(ms.DB, now), "entity code limit should apply")
+	ms.Equal(2000, otherPolicy.GetMaxCoverage(ms.DB, now), "entity code limit should apply to all its policies")
+
+	createLimit(nulls.NewUUID(policy.ID), nulls.UUID{}, 3000, now.AddDate(0, 1, 0))
+	ms.Equal(3000, policy.GetMaxCoverage(ms.DB, now), "policy limit should take precedence")
+	ms.Equal(2000, otherPolicy.GetMaxCoverage(ms.DB, now), "policy limit should not apply to other policies")
+
+	createLimit(nulls.NewUUID(policy.ID), nulls.UUID{}, domain.Env.CoverageLimitApprovalThreshold+1, now.AddDate(0, 1, 0))
+	ms.Equal(3000, policy.GetMaxCoverage(ms.DB, now), "unapproved limit should not apply")
+
+	ms.Equal(domain.Env.PolicyMaxCoverage, policy.GetMaxCoverage(ms.DB, now.AddDate(0, 2, 0)),
+		"expired limits should not apply")
+
+	approved := CoverageLimit{
+		PolicyID:       nulls.NewUUID(otherPolicy.ID),
+		MaxCoverage:    domain.Env.CoverageLimitApprovalThreshold + 2,
+		ExpirationDate: now.AddDate(0, 3, 0),
+		CreatedByID:    steward.ID,
+		ApprovedByID:   nulls.NewUUID(steward.ID),
+		ApprovedAt:     nulls.NewTime(now),
+	}
+	ms.NoError(approved.Create(ms.DB))
+	ms.Equal(approved.MaxCoverage, otherPolicy.GetMaxCoverage(ms.DB, now), "approved limit should apply")
+	ms.Equal(3000, policy.GetMaxCoverage(ms.DB, now), "approved limit should not apply to other policies")
+	ms.Equal(domain.Env.PolicyMaxCoverage, policy.GetMaxCoverage(ms.DB, now.AddDate(0, 2, 0)),
+		"approved limit of another policy should not apply")
+}
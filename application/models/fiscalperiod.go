@@ -0,0 +1,243 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/fin"
+)
+
+// maxOpenPeriodSearch limits the search for an open fiscal period following a closed one
+const maxOpenPeriodSearch = 24
+
+var ValidFiscalPeriodStatuses = map[api.FiscalPeriodStatus]struct{}{
+	api.FiscalPeriodStatusOpen:   {},
+	api.FiscalPeriodStatusClosed: {},
+}
+
+type FiscalPeriods []FiscalPeriod
+
+// FiscalPeriod is one month of the fiscal year. A period is open until it is closed, whether or not it has a
+// record. Ledger entries dated in a closed period are moved to the next open period.
+type FiscalPeriod struct {
+	ID         uuid.UUID              `db:"id"`
+	Year       int                    `db:"year" validate:"required"`
+	Period     int                    `db:"period" validate:"min=1,max=12"`
+	StartDate  time.Time              `db:"start_date"`
+	EndDate    time.Time              `db:"end_date"`
+	Status     api.FiscalPeriodStatus `db:"status" validate:"fiscalPeriodStatus"`
+	ClosedAt   nulls.Time             `db:"closed_at"`
+	ClosedByID nulls.UUID             `db:"closed_by_id"`
+	CloseNote  string                 `db:"close_note"`
+	CreatedAt  time.Time              `db:"created_at"`
+	UpdatedAt  time.Time              `db:"updated_at"`
+}
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (p *FiscalPeriod) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validateModel(p), nil
+}
+
+func (p *FiscalPeriod) Create(tx *pop.Connection) error {
+	return create(tx, p)
+}
+
+func (p *FiscalPeriod) Update(tx *pop.Connection) error {
+	return update(tx, p)
+}
+
+func (p *FiscalPeriod) GetID() uuid.UUID {
+	return p.ID
+}
+
+func (p *FiscalPeriod) FindByID(tx *pop.Connection, id uuid.UUID) error {
+	return tx.Find(p, id)
+}
+
+// IsActorAllowedTo ensures the actor is an admin
+func (p *FiscalPeriod) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permission, sub SubResource, req *http.Request) bool {
+	return actor.IsAdmin()
+}
+
+// IsClosed returns true if ledger entries can no longer be added to or reconciled in the period
+func (p *FiscalPeriod) IsClosed() bool {
+	return p.Status == api.FiscalPeriodStatusClosed
+}
+
+// All loads all the fiscal periods that have a record, most recent first
+func (p *FiscalPeriods) All(tx *pop.Connection) error {
+	return appErrorFromDB(tx.Order("start_date desc").All(p), api.ErrorQueryFailure)
+}
+
+// FindByDate loads the fiscal period that includes the given date. If the period has no record, an open period is
+// returned without saving it.
+func (p *FiscalPeriod) FindByDate(tx *pop.Connection, date time.Time) error {
+	year, period := fin.FiscalPeriod(date)
+
+	err := tx.Where("year = ? AND period = ?", year, period).First(p)
+	if domain.IsOtherThanNoRows(err) {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	if err == nil {
+		return nil
+	}
+
+	start := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	*p = FiscalPeriod{
+		Year:      year,
+		Period:    period,
+		StartDate: start,
+		EndDate:   domain.EndOfMonth(start),
+		Status:    api.FiscalPeriodStatusOpen,
+	}
+	return nil
+}
+
+// findOpenFiscalPeriod finds the first open fiscal period that includes or follows the given date
+func findOpenFiscalPeriod(tx *pop.Connection, date time.Time) (FiscalPeriod, error) {
+	start := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < maxOpenPeriodSearch; i++ {
+		var p FiscalPeriod
+		if err := p.FindByDate(tx, start.AddDate(0, i, 0)); err != nil {
+			return FiscalPeriod{}, err
+		}
+		if !p.IsClosed() {
+			return p, nil
+		}
+	}
+	err := fmt.Errorf("no open fiscal period found within %d months of %s", maxOpenPeriodSearch,
+		date.Format(domain.DateFormat))
+	return FiscalPeriod{}, api.NewAppError(err, api.ErrorFiscalPeriodClosed, api.CategoryInternal)
+}
+
+// openPeriodDate returns the given date if its fiscal period is open. Otherwise, it returns the first day of the
+// next open period and a note explaining the change. The note begins with the given label, e.g. "submission".
+func openPeriodDate(tx *pop.Connection, date time.Time, label string) (time.Time, string, error) {
+	p, err := findOpenFiscalPeriod(tx, date)
+	if err != nil {
+		return date, "", err
+	}
+	if !date.Before(p.StartDate) {
+		return date, "", nil
+	}
+
+	year, period := fin.FiscalPeriod(date)
+	note := fmt.Sprintf("%s dated %s in closed fiscal period %d-%02d, moved to %d-%02d",
+		label, date.Format(domain.DateFormat), year, period, p.Year, p.Period)
+	return p.StartDate, note, nil
+}
+
+// CloseFiscalPeriod closes the fiscal period that includes the given date. The period must have ended.
+func CloseFiscalPeriod(ctx context.Context, date time.Time, note string) (FiscalPeriod, error) {
+	tx := Tx(ctx)
+
+	var p FiscalPeriod
+	if err := p.FindByDate(tx, date); err != nil {
+		return p, err
+	}
+
+	if p.IsClosed() {
+		err := fmt.Errorf("fiscal period %d-%02d is already closed", p.Year, p.Period)
+		return p, api.NewAppError(err, api.ErrorFiscalPeriodClose, api.CategoryUser)
+	}
+
+	if time.Now().UTC().Before(p.EndDate.AddDate(0, 0, 1)) {
+		err := fmt.Errorf("fiscal period %d-%02d has not ended", p.Year, p.Period)
+		return p, api.NewAppError(err, api.ErrorFiscalPeriodClose, api.CategoryUser)
+	}
+
+	p.Status = api.FiscalPeriodStatusClosed
+	p.ClosedAt = nulls.NewTime(time.Now().UTC())
+	p.ClosedByID = nulls.NewUUID(CurrentUser(ctx).ID)
+	p.CloseNote = strings.TrimSpace(note)
+
+	if p.ID == uuid.Nil {
+		return p, p.Create(tx)
+	}
+	return p, p.Update(tx)
+}
+
+// Report summarizes the ledger entries submitted in the period
+func (p *FiscalPeriod) Report(tx *pop.Connection) (api.FiscalPeriodReport, error) {
+	report := api.FiscalPeriodReport{FiscalPeriod: p.ConvertToAPI()}
+
+	var entries LedgerEntries
+	err := tx.Where("date_submitted >= ? AND date_submitted <= ?", p.StartDate, p.EndDate).All(&entries)
+	if err != nil {
+		return report, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	types := map[LedgerEntryType]*api.FiscalPeriodTypeTotal{}
+	for _, e := range entries {
+		report.EntryCount++
+		if e.DateEntered.Valid {
+			report.EnteredCount++
+		} else {
+			report.NotEnteredCount++
+		}
+		if e.PeriodNote != "" {
+			report.RedirectedCount++
+		}
+
+		// reimbursements/reductions are positive and charges are negative
+		if e.Amount < 0 {
+			report.Charges += e.Amount
+		} else {
+			report.Credits += e.Amount
+		}
+		report.Net += e.Amount
+
+		t, ok := types[e.Type]
+		if !ok {
+			t = &api.FiscalPeriodTypeTotal{Type: api.LedgerEntryType(e.Type)}
+			types[e.Type] = t
+		}
+		t.Count++
+		t.Amount += e.Amount
+	}
+
+	report.Types = make([]api.FiscalPeriodTypeTotal, 0, len(types))
+	for _, t := range types {
+		report.Types = append(report.Types, *t)
+	}
+	sort.Slice(report.Types, func(i, j int) bool {
+		return report.Types[i].Type < report.Types[j].Type
+	})
+
+	return report, nil
+}
+
+func (p *FiscalPeriod) ConvertToAPI() api.FiscalPeriod {
+	return api.FiscalPeriod{
+		ID:         p.ID,
+		Year:       p.Year,
+		Period:     p.Period,
+		StartDate:  p.StartDate.Format(domain.DateFormat),
+		EndDate:    p.EndDate.Format(domain.DateFormat),
+		Status:     p.Status,
+		ClosedAt:   convertTimeToAPI(p.ClosedAt),
+		ClosedByID: convertUUIDToAPI(p.ClosedByID),
+		CloseNote:  p.CloseNote,
+		CreatedAt:  p.CreatedAt,
+		UpdatedAt:  p.UpdatedAt,
+	}
+}
+
+func (p *FiscalPeriods) ConvertToAPI() api.FiscalPeriods {
+	periods := make(api.FiscalPeriods, len(*p))
+	for i := range *p {
+		periods[i] = (*p)[i].ConvertToAPI()
+	}
+	return periods
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+func (ms *ModelSuite) TestCloseFiscalPeriod() {
+	signator := CreateAdminUsers(ms.DB)[AppRoleSignator]
+	ctx := CreateTestContext(signator)
+
+	now := time.Now().UTC()
+	lastMonth := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		date    time.Time
+		wantErr *api.AppError
+	}{
+		{
+			name:    "current period",
+			date:    now,
+			wantErr: &api.AppError{Key: api.ErrorFiscalPeriodClose, Category: api.CategoryUser},
+		},
+		{
+			name: "last month",
+			date: lastMonth,
+		},
+		{
+			name:    "already closed",
+			date:    lastMonth,
+			wantErr: &api.AppError{Key: api.ErrorFiscalPeriodClose, Category: api.CategoryUser},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			got, err := CloseFiscalPeriod(ctx, tt.date, " posted ")
+			if tt.wantErr != nil {
+				ms.Error(err)
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+
+			ms.Equal(api.FiscalPeriodStatusClosed, got.Status)
+			ms.Equal(signator.ID, got.ClosedByID.UUID)
+			ms.Equal("posted", got.CloseNote)
+			ms.Equal(lastMonth, got.StartDate)
+			ms.Equal(domain.EndOfMonth(lastMonth), got.EndDate)
+
+			var p FiscalPeriod
+			ms.NoError(p.FindByDate(ms.DB, tt.date))
+			ms.Equal(got.ID, p.ID)
+			ms.True(p.IsClosed())
+		})
+	}
+}
+
+func (ms *ModelSuite) TestLedgerEntry_CreateInClosedPeriod() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{})
+	ctx := CreateTestContext(CreateAdminUsers(ms.DB)[AppRoleSignator])
+
+	now := time.Now().UTC()
+	lastMonth := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := CloseFiscalPeriod(ctx, lastMonth, "")
+	ms.NoError(err)
+
+	item := f.Items[0]
+	ms.NoError(item.CreateLedgerEntry(ms.DB, LedgerEntryTypeCoverageChange, 1000, lastMonth.AddDate(0, 0, 5)))
+
+	var entry LedgerEntry
+	ms.NoError(ms.DB.Where("item_id = ?", item.ID).First(&entry))
+	ms.Equal(time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), entry.DateSubmitted,
+		"entry should be moved to the current period")
+	ms.Contains(entry.PeriodNote, "submission dated "+lastMonth.AddDate(0, 0, 5).Format(domain.DateFormat))
+
+	var current FiscalPeriod
+	ms.NoError(current.FindByDate(ms.DB, now))
+	report, err := current.Report(ms.DB)
+	ms.NoError(err)
+	ms.Equal(1, report.EntryCount)
+	ms.Equal(1, report.NotEnteredCount)
+	ms.Equal(1, report.RedirectedCount)
+	ms.Equal(api.Currency(-1000), report.Charges)
+	ms.Equal(api.Currency(-1000), report.Net)
+	ms.Equal([]api.FiscalPeriodTypeTotal{
+		{Type: api.LedgerEntryType(LedgerEntryTypeCoverageChange), Count: 1, Amount: -1000},
+	}, report.Types)
+}
@@ -0,0 +1,207 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/log"
+)
+
+// HouseholdIDLookup finds the household ID of a staff member
+type HouseholdIDLookup interface {
+	// LookupHouseholdID returns the household ID of the staff member, or an empty string if the staff ID is not
+	// known to the lookup service, e.g. because the staff member was terminated
+	LookupHouseholdID(staffID string) (string, error)
+}
+
+// HouseholdIDService is the household ID lookup used by the application. Tests replace it with a
+// StubHouseholdIDLookup.
+var HouseholdIDService HouseholdIDLookup = householdIDAPI{}
+
+// householdIDAPI looks up household IDs with the service configured by HOUSEHOLD_ID_LOOKUP_URL
+type householdIDAPI struct{}
+
+func (householdIDAPI) LookupHouseholdID(staffID string) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, domain.Env.HouseholdIDLookupURL+staffID, nil)
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(domain.Env.HouseholdIDLookupUsername, domain.Env.HouseholdIDLookupPassword)
+
+	client := &http.Client{Timeout: time.Second * 30}
+	response, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			log.Error("HHID API error closing response body,", err)
+		}
+	}()
+
+	// the lookup only reports an unknown staff ID with an empty household ID, so any other response is a failure
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("household ID lookup for staff ID %s returned status %s", staffID, response.Status)
+	}
+
+	dec := json.NewDecoder(response.Body)
+	var v struct {
+		ID string `json:"householdIdOut"`
+	}
+	if err = dec.Decode(&v); err != nil {
+		return "", err
+	}
+	return v.ID, nil
+}
+
+// HouseholdIDMismatch is a policy member whose staff record belongs to a different household than the policy
+type HouseholdIDMismatch struct {
+	Policy           Policy
+	User             User
+	FoundHouseholdID string
+}
+
+// HouseholdIDValidation is the result of checking household and staff IDs against the lookup service
+type HouseholdIDValidation struct {
+	UsersChecked    int
+	PoliciesChecked int
+
+	// number of staff IDs that could not be looked up. Policies with such a member are left unchanged.
+	LookupFailures int
+
+	Mismatches      []HouseholdIDMismatch
+	TerminatedStaff Users
+
+	// household policies whose household ID does not match any member's staff record
+	InvalidPolicies Policies
+
+	// policies that were invalid before but now match a member's staff record
+	CorrectedPolicies Policies
+}
+
+// HasFindings returns true if there is anything the stewards should be told about
+func (v HouseholdIDValidation) HasFindings() bool {
+	return len(v.Mismatches) > 0 || len(v.TerminatedStaff) > 0 || len(v.InvalidPolicies) > 0 ||
+		len(v.CorrectedPolicies) > 0
+}
+
+// ValidateHouseholdIDs checks the staff ID of every active user and the household ID of every open household policy
+// against the household ID lookup service. A policy is invalid if none of its members' staff records belong to its
+// household. Its HouseholdIDInvalidDate is set until a later validation finds it to be correct again.
+func ValidateHouseholdIDs(ctx context.Context) (HouseholdIDValidation, error) {
+	tx := Tx(ctx)
+
+	var v HouseholdIDValidation
+
+	found := map[string]string{}
+	failed := map[string]bool{}
+	lookup := func(staffID string) (string, bool) {
+		if householdID, ok := found[staffID]; ok {
+			return householdID, true
+		}
+		if failed[staffID] {
+			return "", false
+		}
+		householdID, err := HouseholdIDService.LookupHouseholdID(staffID)
+		if err != nil {
+			log.Errorf("error looking up household ID for staff ID %s: %s", staffID, err)
+			failed[staffID] = true
+			return "", false
+		}
+		found[staffID] = householdID
+		return householdID, true
+	}
+
+	var users Users
+	if err := tx.Where("staff_id IS NOT NULL AND staff_id != '' AND NOT is_blocked").
+		Order("last_name asc, first_name asc").All(&users); err != nil {
+		return v, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	for _, u := range users {
+		householdID, ok := lookup(u.StaffID.String)
+		if !ok {
+			continue
+		}
+		v.UsersChecked++
+		if householdID == "" {
+			v.TerminatedStaff = append(v.TerminatedStaff, u)
+		}
+	}
+
+	var policies Policies
+	if err := tx.Where("type = ? AND household_id IS NOT NULL AND household_id != '' AND closed_date IS NULL",
+		api.PolicyTypeHousehold).Order("name asc").All(&policies); err != nil {
+		return v, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	now := time.Now().UTC()
+	for i := range policies {
+		p := &policies[i]
+
+		valid, ok := p.checkHouseholdID(tx, lookup, &v)
+		if !ok {
+			continue
+		}
+		v.PoliciesChecked++
+
+		switch {
+		case !valid && !p.HouseholdIDInvalidDate.Valid:
+			p.HouseholdIDInvalidDate = nulls.NewTime(now)
+			if err := update(tx, p); err != nil {
+				return v, err
+			}
+		case valid && p.HouseholdIDInvalidDate.Valid:
+			p.HouseholdIDInvalidDate = nulls.Time{}
+			if err := update(tx, p); err != nil {
+				return v, err
+			}
+			v.CorrectedPolicies = append(v.CorrectedPolicies, *p)
+		}
+
+		if !valid {
+			v.InvalidPolicies = append(v.InvalidPolicies, *p)
+		}
+	}
+
+	v.LookupFailures = len(failed)
+
+	return v, nil
+}
+
+// checkHouseholdID returns true if at least one member's staff record belongs to the policy's household. Members
+// whose staff record belongs to another household are added to the mismatches. If a lookup fails or no member has
+// a staff ID, the household ID cannot be checked and `ok` is false.
+func (p *Policy) checkHouseholdID(tx *pop.Connection, lookup func(string) (string, bool),
+	v *HouseholdIDValidation,
+) (valid, ok bool) {
+	p.LoadMembers(tx, false)
+
+	var checked int
+	for _, m := range p.Members {
+		if !m.StaffID.Valid || m.StaffID.String == "" {
+			continue
+		}
+
+		householdID, found := lookup(m.StaffID.String)
+		if !found {
+			return false, false
+		}
+		checked++
+
+		if householdID == p.HouseholdID.String {
+			valid = true
+		} else if householdID != "" {
+			v.Mismatches = append(v.Mismatches, HouseholdIDMismatch{Policy: *p, User: m, FoundHouseholdID: householdID})
+		}
+	}
+	return valid, checked > 0
+}
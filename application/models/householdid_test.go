@@ -0,0 +1,139 @@
+package models
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+
+	"github.com/silinternational/cover-api/domain"
+)
+
+func (ms *ModelSuite) TestValidateHouseholdIDs() {
+	f := CreatePolicyFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 4})
+	valid, mismatched, terminated, corrected := f.Policies[0], f.Policies[1], f.Policies[2], f.Policies[3]
+
+	corrected.HouseholdIDInvalidDate = nulls.NewTime(time.Now().UTC().AddDate(0, 0, -7))
+	ms.NoError(ms.DB.Update(&corrected))
+
+	unreachable := CreateUserFixtures(ms.DB, 1).Users[0]
+
+	stub := StubHouseholdIDLookup{
+		valid.Members[0].StaffID.String:      valid.HouseholdID.String,
+		mismatched.Members[0].StaffID.String: "other-household",
+		corrected.Members[0].StaffID.String:  corrected.HouseholdID.String,
+		unreachable.StaffID.String:           "error",
+	}
+	defer func(s HouseholdIDLookup) { HouseholdIDService = s }(HouseholdIDService)
+	HouseholdIDService = stub
+
+	got, err := ValidateHouseholdIDs(CreateTestContext(GetServiceUser(ms.DB)))
+	ms.NoError(err)
+
+	ms.Equal(1, got.LookupFailures, "incorrect LookupFailures")
+	ms.True(got.HasFindings(), "validation should have findings")
+
+	ms.Len(got.Mismatches, 1, "incorrect number of mismatches")
+	ms.Equal(mismatched.ID, got.Mismatches[0].Policy.ID, "incorrect mismatched policy")
+	ms.Equal(mismatched.Members[0].ID, got.Mismatches[0].User.ID, "incorrect mismatched user")
+	ms.Equal("other-household", got.Mismatches[0].FoundHouseholdID, "incorrect FoundHouseholdID")
+
+	terminatedIDs := map[string]bool{}
+	for _, u := range got.TerminatedStaff {
+		terminatedIDs[u.ID.String()] = true
+	}
+	ms.True(terminatedIDs[terminated.Members[0].ID.String()], "staff not found by the lookup should be terminated")
+	ms.False(terminatedIDs[unreachable.ID.String()], "a failed lookup should not be reported as terminated")
+
+	invalidIDs := map[string]bool{}
+	for _, p := range got.InvalidPolicies {
+		invalidIDs[p.ID.String()] = true
+	}
+	ms.Len(got.InvalidPolicies, 2, "incorrect number of invalid policies")
+	ms.True(invalidIDs[mismatched.ID.String()], "mismatched policy should be invalid")
+	ms.True(invalidIDs[terminated.ID.String()], "policy with only terminated staff should be invalid")
+
+	ms.Len(got.CorrectedPolicies, 1, "incorrect number of corrected policies")
+	ms.Equal(corrected.ID, got.CorrectedPolicies[0].ID, "incorrect corrected policy")
+
+	for _, p := range (Policies{valid, mismatched, terminated, corrected}) {
+		ms.NoError(p.FindByID(ms.DB, p.ID))
+		ms.Equal(invalidIDs[p.ID.String()], p.HouseholdIDInvalidDate.Valid,
+			"incorrect HouseholdIDInvalidDate on policy %s", p.Name)
+	}
+}
+
+func (ms *ModelSuite) TestHouseholdIDAPI_LookupHouseholdID() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/found":
+			_, _ = w.Write([]byte(`{"householdIdOut":"household1"}`))
+		case "/terminated":
+			_, _ = w.Write([]byte(`{"householdIdOut":""}`))
+		case "/unauthorized":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"householdIdOut":""}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	defer func(url string) { domain.Env.HouseholdIDLookupURL = url }(domain.Env.HouseholdIDLookupURL)
+	domain.Env.HouseholdIDLookupURL = server.URL + "/"
+
+	tests := []struct {
+		name    string
+		staffID string
+		want    string
+		wantErr bool
+	}{
+		{name: "found", staffID: "found", want: "household1"},
+		{name: "terminated", staffID: "terminated", want: ""},
+		{name: "unauthorized", staffID: "unauthorized", wantErr: true},
+		{name: "server error", staffID: "error", wantErr: true},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			got, err := householdIDAPI{}.LookupHouseholdID(tt.staffID)
+			if tt.wantErr {
+				ms.Error(err)
+				return
+			}
+			ms.NoError(err)
+			ms.Equal(tt.want, got)
+		})
+	}
+}
+
+func (ms *ModelSuite) TestLedgerEntries_AllNotEntered_HouseholdIDBlockBilling() {
+	f := CreateLedgerFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 2})
+	invalid := f.Policies[0]
+	invalid.HouseholdIDInvalidDate = nulls.NewTime(time.Now().UTC())
+	ms.NoError(ms.DB.Update(&invalid))
+
+	cutoff := time.Now().UTC().AddDate(0, 0, 1)
+
+	defer func(b bool) { domain.Env.HouseholdIDBlockBilling = b }(domain.Env.HouseholdIDBlockBilling)
+
+	domain.Env.HouseholdIDBlockBilling = false
+	var entries LedgerEntries
+	ms.NoError(entries.AllNotEntered(ms.DB, cutoff))
+	ms.Len(entries, 2, "billing should not be blocked")
+
+	domain.Env.HouseholdIDBlockBilling = true
+	entries = LedgerEntries{}
+	ms.NoError(entries.AllNotEntered(ms.DB, cutoff))
+	ms.Len(entries, 1, "billing should be blocked for the invalid policy")
+	ms.Equal(f.Policies[1].ID, entries[0].PolicyID, "incorrect policy billed")
+
+	// correcting the household ID resumes billing
+	invalid.HouseholdID = nulls.NewString(invalid.HouseholdID.String + "1")
+	ms.NoError(invalid.Update(CreateTestContext(f.Users[0])))
+	entries = LedgerEntries{}
+	ms.NoError(entries.AllNotEntered(ms.DB, cutoff))
+	ms.Len(entries, 2, "billing should resume after the household ID is corrected")
+}
@@ -26,12 +26,13 @@ import (
 const MonthlyCutoffDay = 20
 
 var ValidItemCoverageStatuses = map[api.ItemCoverageStatus]struct{}{
-	api.ItemCoverageStatusDraft:    {},
-	api.ItemCoverageStatusPending:  {},
-	api.ItemCoverageStatusRevision: {},
-	api.ItemCoverageStatusApproved: {},
-	api.ItemCoverageStatusDenied:   {},
-	api.ItemCoverageStatusInactive: {},
+	api.ItemCoverageStatusDraft:         {},
+	api.ItemCoverageStatusPendingBudget: {},
+	api.ItemCoverageStatusPending:       {},
+	api.ItemCoverageStatusRevision:      {},
+	api.ItemCoverageStatusApproved:      {},
+	api.ItemCoverageStatusDenied:        {},
+	api.ItemCoverageStatusInactive:      {},
 }
 
 // Items is a slice of Item objects
@@ -102,6 +103,10 @@ func (i *Item) Create(tx *pop.Connection) error {
 		err := errors.New("policy does not have a household ID")
 		return api.NewAppError(err, api.ErrorPolicyHasNoHouseholdID, api.CategoryUser)
 	}
+	if i.Policy.IsClosed() {
+		err := errors.New("items cannot be added to a closed policy")
+		return api.NewAppError(err, api.ErrorPolicyClosed, api.CategoryUser)
+	}
 	return create(tx, i)
 }
 
@@ -123,6 +128,18 @@ func (i *Item) Update(ctx context.Context) error {
 		return api.NewAppError(err, api.ErrorItemHasActiveClaim, api.CategoryUser)
 	}
 
+	// a coverage increase after budget approval has to be accepted by an approver again
+	needsBudgetApproval := false
+	if oldItem.CoverageStatus == api.ItemCoverageStatusPending && i.CoverageStatus == api.ItemCoverageStatusPending &&
+		oldItem.CoverageAmount < i.CoverageAmount {
+		i.LoadPolicy(tx, false)
+		if i.Policy.needsBudgetApproval(tx) {
+			i.CoverageStatus = api.ItemCoverageStatusPendingBudget
+			i.StatusChange = ItemStatusChangeBudgetSubmitted
+			needsBudgetApproval = true
+		}
+	}
+
 	updates := i.Compare(oldItem)
 	for ii := range updates {
 		history := i.NewHistory(ctx, api.HistoryActionUpdate, updates[ii])
@@ -146,7 +163,14 @@ func (i *Item) Update(ctx context.Context) error {
 		}
 	}
 
-	return update(tx, i)
+	if err := update(tx, i); err != nil {
+		return err
+	}
+
+	if needsBudgetApproval {
+		emitPendingBudgetEvent(*i)
+	}
+	return nil
 }
 
 func (i *Item) Destroy(tx *pop.Connection) error {
@@ -317,7 +341,8 @@ func (i *Item) SafeDeleteOrInactivate(ctx context.Context, now time.Time) error
 		return nil
 	case api.ItemCoverageStatusApproved:
 		return i.ScheduleInactivation(ctx, now)
-	case api.ItemCoverageStatusDraft, api.ItemCoverageStatusRevision, api.ItemCoverageStatusPending:
+	case api.ItemCoverageStatusDraft, api.ItemCoverageStatusRevision, api.ItemCoverageStatusPending,
+		api.ItemCoverageStatusPendingBudget:
 		if i.isNewEnough() && i.canBeDeleted(tx) {
 			return i.Destroy(tx)
 		}
@@ -339,7 +364,10 @@ func (i *Item) CreateCancellationCredit(tx *pop.Connection, now time.Time) error
 		return nil
 	}
 
-	creditAmount := i.calculateCancellationCredit(tx, now)
+	creditAmount, err := i.calculateCancellationCredit(tx, now)
+	if err != nil {
+		return err
+	}
 
 	if err := i.CreateLedgerEntry(tx, LedgerEntryTypeCoverageRefund, creditAmount, now); err != nil {
 		return err
@@ -460,31 +488,51 @@ func (i *Item) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permission,
 		return true
 	}
 
-	i.LoadPolicyMembers(tx, false)
+	i.LoadPolicy(tx, false)
 
-	for _, m := range i.Policy.Members {
-		if m.ID == actor.ID {
-			return true
-		}
+	// budget approvers need not be policy members
+	if sub == api.ResourceBudgetApprove || sub == api.ResourceBudgetReject {
+		return i.Policy.isApprover(tx, actor.ID)
 	}
 
-	return false
+	role, isMember := i.Policy.memberRole(tx, actor.ID)
+	if !isMember {
+		return false
+	}
+
+	switch role {
+	case api.PolicyUserRoleOwner:
+		return true
+	case api.PolicyUserRoleMember:
+		// only an owner may submit an item for coverage
+		return sub != api.ResourceSubmit
+	}
+	return perm == PermissionView || perm == PermissionList
 }
 
 func itemStatusTransitions() map[api.ItemCoverageStatus][]api.ItemCoverageStatus {
 	return map[api.ItemCoverageStatus][]api.ItemCoverageStatus{
 		api.ItemCoverageStatusDraft: {
+			api.ItemCoverageStatusPendingBudget,
+			api.ItemCoverageStatusPending,
+			api.ItemCoverageStatusApproved,
+			api.ItemCoverageStatusInactive,
+		},
+		api.ItemCoverageStatusPendingBudget: {
 			api.ItemCoverageStatusPending,
+			api.ItemCoverageStatusRevision,
 			api.ItemCoverageStatusApproved,
 			api.ItemCoverageStatusInactive,
 		},
 		api.ItemCoverageStatusPending: {
+			api.ItemCoverageStatusPendingBudget,
 			api.ItemCoverageStatusRevision,
 			api.ItemCoverageStatusApproved,
 			api.ItemCoverageStatusDenied,
 			api.ItemCoverageStatusInactive,
 		},
 		api.ItemCoverageStatusRevision: {
+			api.ItemCoverageStatusPendingBudget,
 			api.ItemCoverageStatusPending,
 			api.ItemCoverageStatusApproved,
 			api.ItemCoverageStatusDenied,
@@ -532,8 +580,16 @@ func isItemActionAllowed(actorIsAdmin bool, oldStatus api.ItemCoverageStatus, pe
 
 		return sub == api.ResourceSubmit && perm == PermissionCreate
 
+	// An item with PendingBudget status can have a create done on it for budget-approve or budget-reject. Whether
+	// the actor is an approver is checked by the caller. A non-admin can also delete/inactivate it.
+	case api.ItemCoverageStatusPendingBudget:
+		if sub == api.ResourceBudgetApprove || sub == api.ResourceBudgetReject {
+			return perm == PermissionCreate
+		}
+		return !actorIsAdmin && sub == "" && perm == PermissionDelete
+
 	// An item with Pending status can have a create done on it by an admin for revision, approve, deny
-	// A non-admin can delete/inactivate it or update it
+	// A non-admin can delete/inactivate it or update it. Update sends a coverage increase back for budget approval.
 	case api.ItemCoverageStatusPending:
 		if perm == PermissionUpdate {
 			return true
@@ -544,8 +600,11 @@ func isItemActionAllowed(actorIsAdmin bool, oldStatus api.ItemCoverageStatus, pe
 		}
 		return perm == PermissionCreate && (sub == api.ResourceApprove || sub == api.ResourceRevision || sub == api.ResourceDeny)
 
-	// An item with approved status can only be deleted/inactivated or updated
+	// An item with approved status can only be deleted/inactivated or updated, or have a certificate created
 	case api.ItemCoverageStatusApproved:
+		if sub == api.ResourceCertificate {
+			return perm == PermissionCreate
+		}
 		return sub == "" && (perm == PermissionDelete || perm == PermissionUpdate)
 	}
 
@@ -564,13 +623,23 @@ func (i *Item) SubmitForApproval(ctx context.Context) error {
 		return api.NewAppError(err, api.ErrorItemCoverageAmountTooLow, api.CategoryUser)
 	}
 
+	i.LoadPolicy(tx, false)
+	if i.Policy.needsBudgetApproval(tx) {
+		return i.submitForBudgetApproval(ctx)
+	}
+
+	i.StatusChange = ItemStatusChangeSubmitted
+	return i.submitForReview(ctx)
+}
+
+// submitForReview takes the item to Pending status for steward review, or auto-approves it if possible
+func (i *Item) submitForReview(ctx context.Context) error {
 	i.CoverageStatus = api.ItemCoverageStatusPending
 
-	if i.canAutoApprove(tx) {
+	if i.canAutoApprove(Tx(ctx)) {
 		return i.AutoApprove(ctx)
 	}
 
-	i.StatusChange = ItemStatusChangeSubmitted
 	if err := i.Update(ctx); err != nil {
 		return err
 	}
@@ -621,7 +690,7 @@ func (i *Item) canAutoApprove(tx *pop.Connection) bool {
 
 	policyTotal := totals[i.PolicyID]
 
-	if policyTotal+i.CoverageAmount > domain.Env.PolicyMaxCoverage {
+	if policyTotal+i.CoverageAmount > i.Policy.GetMaxCoverage(tx, time.Now().UTC()) {
 		return false
 	}
 
@@ -634,6 +703,61 @@ func (i *Item) canAutoApprove(tx *pop.Connection) bool {
 	return depTotal+i.CoverageAmount <= domain.Env.DependentAutoApproveMax
 }
 
+func (i *Item) submitForBudgetApproval(ctx context.Context) error {
+	i.CoverageStatus = api.ItemCoverageStatusPendingBudget
+	i.StatusChange = ItemStatusChangeBudgetSubmitted
+	if err := i.Update(ctx); err != nil {
+		return err
+	}
+
+	emitPendingBudgetEvent(*i)
+	return nil
+}
+
+func emitPendingBudgetEvent(i Item) {
+	emitEvent(events.Event{
+		Kind:    domain.EventApiItemPendingBudget,
+		Message: fmt.Sprintf("Item Pending Budget Approval: %s  ID: %s", i.Name, i.ID.String()),
+		Payload: events.Payload{domain.EventPayloadID: i.ID},
+	})
+}
+
+// BudgetApprove takes the item from PendingBudget coverage status to the usual steward review.
+// It assumes that the item's current status has already been validated.
+func (i *Item) BudgetApprove(ctx context.Context) error {
+	user := CurrentUser(ctx)
+	i.StatusChange = ItemStatusChangeBudgetApproved + user.Name()
+	return i.submitForReview(ctx)
+}
+
+// BudgetReject takes the item from PendingBudget coverage status to Revision, so the members can change or
+// remove it. It assumes that the item's current status has already been validated.
+func (i *Item) BudgetReject(ctx context.Context, reason string) error {
+	i.CoverageStatus = api.ItemCoverageStatusRevision
+	i.StatusReason = reason
+	user := CurrentUser(ctx)
+	i.StatusChange = ItemStatusChangeBudgetRejected + user.Name()
+
+	if err := i.Update(ctx); err != nil {
+		return err
+	}
+
+	emitEvent(events.Event{
+		Kind:    domain.EventApiItemBudgetRejected,
+		Message: fmt.Sprintf("Item Budget Rejected: %s  ID: %s", i.Name, i.ID.String()),
+		Payload: events.Payload{domain.EventPayloadID: i.ID},
+	})
+	return nil
+}
+
+// FindPendingBudgetForApprover loads the items awaiting budget approval on the policies the user approves
+func (i *Items) FindPendingBudgetForApprover(tx *pop.Connection, userID uuid.UUID) error {
+	err := tx.Where("coverage_status = ?", api.ItemCoverageStatusPendingBudget).
+		Where("policy_id IN (SELECT policy_id FROM policy_approvers WHERE user_id = ?)", userID).
+		Order("updated_at asc").All(i)
+	return appErrorFromDB(err, api.ErrorQueryFailure)
+}
+
 // Revision takes the item from Pending coverage status to Revision.
 // It assumes that the item's current status has already been validated.
 func (i *Item) Revision(ctx context.Context, reason string) error {
@@ -964,23 +1088,30 @@ func (i *Item) shouldGiveFullYearRefund(t time.Time) bool {
 	return i.CoverageStartDate.Year() < t.Year() && t.Month() == 1
 }
 
-func (i *Item) calculateCancellationCredit(tx *pop.Connection, t time.Time) api.Currency {
+// calculateCancellationCredit returns the credit for the unused annual premium, net of any no-claims discount that
+// was given on the premium
+func (i *Item) calculateCancellationCredit(tx *pop.Connection, t time.Time) (api.Currency, error) {
 	// If we're in December already, then no credit
 	if t.Month() == 12 {
-		return 0
+		return 0, nil
 	}
 
-	premium := int(i.CalculateAnnualPremium(tx))
+	discountRate, err := i.renewalDiscountRate(tx, t)
+	if err != nil {
+		return 0, err
+	}
+	annualPremium := i.CalculateAnnualPremium(tx)
+	premium := int(annualPremium - calculateNoClaimsDiscount(annualPremium, discountRate))
 
 	// If the coverage was from a previous year and today is still in January,
 	//   give a full year's refund.
 	if i.shouldGiveFullYearRefund(t) {
-		return api.Currency(-1 * premium)
+		return api.Currency(-1 * premium), nil
 	}
 
 	// Otherwise, give credit for the following calendar months
 	credit := domain.CalculateMonthlyRefundValue(premium, t)
-	return api.Currency(-1 * credit)
+	return api.Currency(-1 * credit), nil
 }
 
 func (i *Item) calculatePremiumChange(t time.Time, oldPremium, newPremium api.Currency) api.Currency {
@@ -1248,8 +1379,7 @@ func (i *Items) RepairItemsIncorrectlyRenewed(c buffalo.Context, date time.Time)
 		annualPremium := item.CalculateAnnualPremium(tx)                                          // TODO: get the amount from the ledger entry, in case the coverage amount has changed
 		refund := annualPremium * api.Currency(incorrectDate.Sub(correctDate)/(time.Hour*24*365)) // TODO: use CalculatePartialYearValue?
 
-		now := time.Now().UTC()
-		if err := item.CreateLedgerEntry(tx, LedgerEntryTypeCoverageRefund, -refund, now); err != nil {
+		if err := item.correctRenewalEntry(c, incorrectDate.Year(), refund); err != nil {
 			return err
 		}
 
@@ -1260,6 +1390,67 @@ func (i *Items) RepairItemsIncorrectlyRenewed(c buffalo.Context, date time.Time)
 	return nil
 }
 
+// correctRenewalEntry removes the refund from the policy's renewal entry for the item's risk category. The
+// renewal entry is reversed and replaced rather than edited, and so is the no-claims discount entry, in proportion
+// to the refund. If there is no renewal entry, a refund entry is added instead.
+func (i *Item) correctRenewalEntry(ctx context.Context, year int, refund api.Currency) error {
+	tx := Tx(ctx)
+
+	renewal, err := i.findRenewalEntry(tx, LedgerEntryTypeCoverageRenewal, year)
+	if domain.IsOtherThanNoRows(err) {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	if err != nil || -renewal.Amount < refund {
+		return i.CreateLedgerEntry(tx, LedgerEntryTypeCoverageRefund, -refund, time.Now().UTC())
+	}
+
+	discount, err := i.findRenewalEntry(tx, LedgerEntryTypeNoClaimsDiscount, year)
+	if domain.IsOtherThanNoRows(err) {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	var discountRefund api.Currency
+	if err == nil {
+		discountRefund = calculateNoClaimsDiscount(refund, float64(discount.Amount)/float64(-renewal.Amount))
+	}
+
+	reason := fmt.Sprintf("item %s was renewed after its coverage ended", i.ID)
+	if err := correctOrReverse(ctx, renewal, renewal.Amount+refund, reason); err != nil {
+		return err
+	}
+	if discountRefund == 0 {
+		return nil
+	}
+	return correctOrReverse(ctx, discount, discount.Amount-discountRefund, reason)
+}
+
+// findRenewalEntry finds the current renewal entry of the given type for the item's policy and risk category,
+// submitted in the given year
+func (i *Item) findRenewalEntry(tx *pop.Connection, entryType LedgerEntryType, year int) (LedgerEntry, error) {
+	i.LoadRiskCategory(tx, false)
+
+	var entry LedgerEntry
+	err := tx.Where("policy_id = ?", i.PolicyID).
+		Where("type = ?", entryType).
+		Where("risk_category_name = ?", i.RiskCategory.Name).
+		Where("EXTRACT(YEAR FROM date_submitted) = ?", year).
+		Where("reversal_of_id IS NULL").
+		Where("id NOT IN (SELECT reversal_of_id FROM ledger_entries WHERE reversal_of_id IS NOT NULL)").
+		Order("date_submitted desc").
+		First(&entry)
+	return entry, err
+}
+
+// correctOrReverse replaces the entry with one of the given amount, or reverses it if the amount is zero
+func correctOrReverse(ctx context.Context, entry LedgerEntry, amount api.Currency, reason string) error {
+	if amount == 0 {
+		_, err := entry.Reverse(ctx, reason)
+		return err
+	}
+	_, _, err := entry.Correct(ctx, reason, amount)
+	return err
+}
+
 func CountItemsToRenew(tx *pop.Connection, date time.Time, billingPeriod int) (int, error) {
 	var items Items
 	count, err := tx.Where("coverage_status = ?", api.ItemCoverageStatusApproved).
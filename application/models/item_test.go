@@ -232,6 +232,54 @@ func (ms *ModelSuite) Test_isItemActionAllowed() {
 			subRes:       api.ResourceDeny,
 			want:         false,
 		},
+		{
+			name:         "approved with create and certificate sub resource - YES",
+			actorIsAdmin: false,
+			startStatus:  api.ItemCoverageStatusApproved,
+			permission:   PermissionCreate,
+			subRes:       api.ResourceCertificate,
+			want:         true,
+		},
+		{
+			name:         "pending with create and certificate sub resource - NO",
+			actorIsAdmin: false,
+			startStatus:  api.ItemCoverageStatusPending,
+			permission:   PermissionCreate,
+			subRes:       api.ResourceCertificate,
+			want:         false,
+		},
+		{
+			name:         "pending budget with create and budget-approve sub resource - YES",
+			actorIsAdmin: false,
+			startStatus:  api.ItemCoverageStatusPendingBudget,
+			permission:   PermissionCreate,
+			subRes:       api.ResourceBudgetApprove,
+			want:         true,
+		},
+		{
+			name:         "pending budget with update and no sub resource - NO",
+			actorIsAdmin: false,
+			startStatus:  api.ItemCoverageStatusPendingBudget,
+			permission:   PermissionUpdate,
+			subRes:       "",
+			want:         false,
+		},
+		{
+			name:         "pending budget with create and approve sub resource - NO",
+			actorIsAdmin: true,
+			startStatus:  api.ItemCoverageStatusPendingBudget,
+			permission:   PermissionCreate,
+			subRes:       api.ResourceApprove,
+			want:         false,
+		},
+		{
+			name:         "pending with create and budget-approve sub resource - NO",
+			actorIsAdmin: true,
+			startStatus:  api.ItemCoverageStatusPending,
+			permission:   PermissionCreate,
+			subRes:       api.ResourceBudgetApprove,
+			want:         false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -828,6 +876,32 @@ func (ms *ModelSuite) TestItem_CreateCancellationCredit() {
 	}
 }
 
+func (ms *ModelSuite) TestItem_CreateCancellationCredit_Discounted() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 1})
+	cancelDate := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	// claim-free for NoClaimsDiscountYears at the 2019 renewal
+	Must(ms.DB.RawQuery("UPDATE policies SET created_at = ? WHERE id = ?",
+		time.Date(2019-domain.Env.NoClaimsDiscountYears, 1, 1, 0, 0, 0, 0, time.UTC), f.Policies[0].ID).Exec())
+
+	item := f.Items[0]
+	item.CoverageAmount = 600000
+	item.CoverageStartDate = time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC)
+	item.PaidThroughDate = domain.EndOfYear(2019)
+	Must(ms.DB.Update(&item))
+
+	ms.NoError(item.CreateCancellationCredit(ms.DB, cancelDate))
+
+	premium := item.CalculateAnnualPremium(ms.DB)
+	discount := calculateNoClaimsDiscount(premium, domain.Env.NoClaimsDiscountRate)
+	ms.Greater(int(discount), 0, "test should be revised, premium is too small for a discount")
+	want := domain.CalculateMonthlyRefundValue(int(premium-discount), cancelDate) // refunds are positive
+
+	var refund LedgerEntry
+	Must(ms.DB.Where("item_id = ? AND type = ?", item.ID, LedgerEntryTypeCoverageRefund).First(&refund))
+	ms.Equal(api.Currency(want), refund.Amount, "refund should be net of the no-claims discount")
+}
+
 func (ms *ModelSuite) TestItem_InactivateApprovedButEnded() {
 	fixConfig := FixturesConfig{
 		NumberOfPolicies: 1,
@@ -1062,8 +1136,17 @@ func (ms *ModelSuite) TestItem_calculateCancellationCredit() {
 	earlyJanuary := time.Date(2019, 1, 1, 1, 1, 1, 1, time.UTC)
 	midJanuary := time.Date(2019, 1, 11, 1, 1, 1, 1, time.UTC)
 
+	f := CreatePolicyFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 2})
+	policy := f.Policies[0]
+
+	// claim-free for NoClaimsDiscountYears at the 2019 renewal
+	discounted := f.Policies[1]
+	Must(ms.DB.RawQuery("UPDATE policies SET created_at = ? WHERE id = ?",
+		time.Date(2019-domain.Env.NoClaimsDiscountYears, 1, 1, 0, 0, 0, 0, time.UTC), discounted.ID).Exec())
+
 	tests := []struct {
 		name              string
+		policy            Policy
 		coverage          int
 		coverageStartDate time.Time
 		testTime          time.Time
@@ -1110,14 +1193,46 @@ func (ms *ModelSuite) TestItem_calculateCancellationCredit() {
 			testTime:          time.Date(2019, 12, 1, 1, 1, 1, 1, time.UTC),
 			want:              0,
 		},
+		{
+			name: "Now January and renewed with a discount",
+			//  Premium = 12,000 less 5% discount = 11,400
+			policy:            discounted,
+			coverage:          600000,
+			coverageStartDate: time.Date(2018, 12, 1, 1, 1, 1, 1, time.UTC),
+			testTime:          midJanuary,
+			want:              -11400,
+		},
+		{
+			name: "Now February and renewed with a discount",
+			//  Monthly premium = 11,400 / 12 = 950
+			//  10 months credit = 9,500
+			policy:            discounted,
+			coverage:          600000,
+			coverageStartDate: time.Date(2018, 12, 1, 1, 1, 1, 1, time.UTC),
+			testTime:          time.Date(2019, 2, 1, 1, 1, 1, 1, time.UTC),
+			want:              -9500,
+		},
+		{
+			name:              "Now February and created after the discounted renewal",
+			policy:            discounted,
+			coverage:          600000,
+			coverageStartDate: midJanuary,
+			testTime:          time.Date(2019, 2, 1, 1, 1, 1, 1, time.UTC),
+			want:              -10000,
+		},
 	}
 	for _, tt := range tests {
 		ms.T().Run(tt.name, func(t *testing.T) {
+			if tt.policy.ID == uuid.Nil {
+				tt.policy = policy
+			}
 			item := Item{
+				PolicyID:          tt.policy.ID,
 				CoverageAmount:    tt.coverage,
 				CoverageStartDate: tt.coverageStartDate,
 			}
-			got := item.calculateCancellationCredit(ms.DB, tt.testTime)
+			got, err := item.calculateCancellationCredit(ms.DB, tt.testTime)
+			ms.NoError(err)
 			ms.Equal(api.Currency(tt.want), got)
 		})
 	}
@@ -1835,6 +1950,74 @@ func (ms *ModelSuite) TestItem_RepairItemsIncorrectlyRenewed() {
 	}
 }
 
+func (ms *ModelSuite) TestItem_correctRenewalEntry() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 2})
+	policy := f.Policies[0]
+	items := policy.Items
+	ctx := CreateTestContext(CreateAdminUsers(ms.DB)[AppRoleSteward])
+
+	Must(policy.CreateRenewalLedgerEntry(ms.DB, items[0].RiskCategoryID, 10000))
+	var renewal LedgerEntry
+	Must(ms.DB.Where("policy_id = ? AND type = ?", policy.ID, LedgerEntryTypeCoverageRenewal).First(&renewal))
+	renewal.DateSubmitted = time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	Must(ms.DB.Update(&renewal))
+
+	findCorrections := func(id uuid.UUID) (reversal, replacement LedgerEntry) {
+		Must(ms.DB.Where("reversal_of_id = ?", id).First(&reversal))
+		_ = ms.DB.Where("replacement_of_id = ?", id).First(&replacement)
+		return
+	}
+
+	// part of the renewal is refunded
+	ms.NoError(items[0].correctRenewalEntry(ctx, 2021, 4000))
+	reversal, replacement := findCorrections(renewal.ID)
+	ms.Equal(api.Currency(10000), reversal.Amount)
+	ms.Equal(api.Currency(-6000), replacement.Amount)
+
+	// the rest of the renewal is refunded
+	ms.NoError(items[1].correctRenewalEntry(ctx, 2021, 6000))
+	reversal, replacement = findCorrections(replacement.ID)
+	ms.Equal(api.Currency(6000), reversal.Amount)
+	ms.Equal(uuid.Nil, replacement.ID, "no replacement expected")
+
+	// no renewal entry, so a refund is added
+	ms.NoError(items[0].correctRenewalEntry(ctx, 2022, 4000))
+	var refund LedgerEntry
+	Must(ms.DB.Where("item_id = ? AND type = ?", items[0].ID, LedgerEntryTypeCoverageRefund).First(&refund))
+	ms.Equal(api.Currency(4000), refund.Amount)
+}
+
+func (ms *ModelSuite) TestItem_correctRenewalEntry_Discount() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 2})
+	policy := f.Policies[0]
+	items := policy.Items
+	ctx := CreateTestContext(CreateAdminUsers(ms.DB)[AppRoleSteward])
+
+	Must(policy.CreateRenewalLedgerEntry(ms.DB, items[0].RiskCategoryID, 10000))
+	Must(policy.createPremiumLedgerEntry(ms.DB, items[0].RiskCategoryID, LedgerEntryTypeNoClaimsDiscount, 500))
+	Must(ms.DB.RawQuery("UPDATE ledger_entries SET date_submitted = ? WHERE policy_id = ?",
+		time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), policy.ID).Exec())
+
+	var discount LedgerEntry
+	Must(ms.DB.Where("policy_id = ? AND type = ?", policy.ID, LedgerEntryTypeNoClaimsDiscount).First(&discount))
+
+	// part of the renewal is refunded, so the discount is reduced in proportion
+	ms.NoError(items[0].correctRenewalEntry(ctx, 2021, 4000))
+	var reversal, replacement LedgerEntry
+	Must(ms.DB.Where("reversal_of_id = ?", discount.ID).First(&reversal))
+	Must(ms.DB.Where("replacement_of_id = ?", discount.ID).First(&replacement))
+	ms.Equal(api.Currency(-500), reversal.Amount)
+	ms.Equal(api.Currency(300), replacement.Amount)
+
+	// the rest of the renewal is refunded, so the discount is reversed
+	ms.NoError(items[1].correctRenewalEntry(ctx, 2021, 6000))
+	Must(ms.DB.Where("reversal_of_id = ?", replacement.ID).First(&reversal))
+	ms.Equal(api.Currency(-300), reversal.Amount)
+	n, err := ms.DB.Where("replacement_of_id = ?", replacement.ID).Count(&LedgerEntry{})
+	ms.NoError(err)
+	ms.Equal(0, n, "no replacement expected")
+}
+
 func (ms *ModelSuite) Test_CountItemsToRenew() {
 	now := time.Now().UTC()
 	year := now.Year()
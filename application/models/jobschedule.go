@@ -0,0 +1,274 @@
+package models
+
+import (
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+// MaxJobRuns limits the number of job runs returned in one list
+const MaxJobRuns = 500
+
+var ValidJobRunTriggers = map[api.JobRunTrigger]struct{}{
+	api.JobRunTriggerSchedule: {},
+	api.JobRunTriggerManual:   {},
+	api.JobRunTriggerSubmit:   {},
+}
+
+var ValidJobRunResults = map[api.JobRunResult]struct{}{
+	api.JobRunResultQueued:  {},
+	api.JobRunResultRunning: {},
+	api.JobRunResultSuccess: {},
+	api.JobRunResultFailure: {},
+}
+
+type JobSchedules []JobSchedule
+
+// JobSchedule is the cron schedule of a background job. An instance of the app must hold the lock of the schedule
+// to run the job, so a job is only run by one instance at a time.
+type JobSchedule struct {
+	ID          uuid.UUID  `db:"id"`
+	JobType     string     `db:"job_type" validate:"required"`
+	Schedule    string     `db:"schedule" validate:"required"`
+	Enabled     bool       `db:"enabled"`
+	LastRunAt   nulls.Time `db:"last_run_at"`
+	NextRunAt   nulls.Time `db:"next_run_at"` // not set until the scheduler first sees the schedule
+	LockedBy    string     `db:"locked_by"`   // the instance of the app that holds the lock
+	LockedUntil nulls.Time `db:"locked_until"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
+}
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (s *JobSchedule) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validateModel(s), nil
+}
+
+func (s *JobSchedule) Create(tx *pop.Connection) error {
+	return create(tx, s)
+}
+
+// All loads all the job schedules, in order of the job type
+func (s *JobSchedules) All(tx *pop.Connection) error {
+	return appErrorFromDB(tx.Order("job_type").All(s), api.ErrorQueryFailure)
+}
+
+// FindDue loads the enabled schedules that are due to run, or that have not been given a next run time
+func (s *JobSchedules) FindDue(tx *pop.Connection, now time.Time) error {
+	err := tx.Where("enabled = true AND (next_run_at IS NULL OR next_run_at <= ?)", now).All(s)
+	return appErrorFromDB(err, api.ErrorQueryFailure)
+}
+
+// FindByJobType loads the schedule of the given job type
+func (s *JobSchedule) FindByJobType(tx *pop.Connection, jobType string) error {
+	return appErrorFromDB(tx.Where("job_type = ?", jobType).First(s), api.ErrorQueryFailure)
+}
+
+// IsLocked returns true if an instance of the app holds the lock of the schedule
+func (s *JobSchedule) IsLocked(now time.Time) bool {
+	return s.LockedUntil.Valid && s.LockedUntil.Time.After(now)
+}
+
+// SetNextRun sets the next run time of the schedule
+func (s *JobSchedule) SetNextRun(tx *pop.Connection, next time.Time) error {
+	s.NextRunAt = nulls.NewTime(next)
+	return appErrorFromDB(tx.UpdateColumns(s, "next_run_at", "updated_at"), api.ErrorUpdateFailure)
+}
+
+// ClaimDue locks the schedule for the given instance if the job is due and not locked, and advances the next run
+// time. Only one instance can claim a due job, since the update is conditional on the job still being due.
+func (s *JobSchedule) ClaimDue(tx *pop.Connection, instance string, now, next, lockUntil time.Time) (bool, error) {
+	n, err := tx.RawQuery(`UPDATE job_schedules
+		SET next_run_at = ?, locked_by = ?, locked_until = ?, updated_at = ?
+		WHERE id = ? AND enabled = true AND next_run_at <= ? AND (locked_until IS NULL OR locked_until < ?)`,
+		next, instance, lockUntil, now, s.ID, now, now).ExecWithCount()
+	if err != nil {
+		return false, appErrorFromDB(err, api.ErrorUpdateFailure)
+	}
+	if n == 0 {
+		return false, nil
+	}
+
+	s.NextRunAt = nulls.NewTime(next)
+	s.LockedBy = instance
+	s.LockedUntil = nulls.NewTime(lockUntil)
+	return true, nil
+}
+
+// Lock locks the schedule for the given instance if it is not locked
+func (s *JobSchedule) Lock(tx *pop.Connection, instance string, now, lockUntil time.Time) (bool, error) {
+	n, err := tx.RawQuery(`UPDATE job_schedules
+		SET locked_by = ?, locked_until = ?, updated_at = ?
+		WHERE id = ? AND (locked_until IS NULL OR locked_until < ?)`,
+		instance, lockUntil, now, s.ID, now).ExecWithCount()
+	if err != nil {
+		return false, appErrorFromDB(err, api.ErrorUpdateFailure)
+	}
+	if n == 0 {
+		return false, nil
+	}
+
+	s.LockedBy = instance
+	s.LockedUntil = nulls.NewTime(lockUntil)
+	return true, nil
+}
+
+// Unlock releases the lock of the schedule if it is held by the given instance
+func (s *JobSchedule) Unlock(tx *pop.Connection, instance string) error {
+	err := tx.RawQuery(`UPDATE job_schedules
+		SET locked_by = '', locked_until = NULL, updated_at = ?
+		WHERE id = ? AND locked_by = ?`,
+		time.Now().UTC(), s.ID, instance).Exec()
+	if err != nil {
+		return appErrorFromDB(err, api.ErrorUpdateFailure)
+	}
+
+	s.LockedBy = ""
+	s.LockedUntil = nulls.Time{}
+	return nil
+}
+
+func (s *JobSchedule) ConvertToAPI() api.JobSchedule {
+	now := time.Now().UTC()
+
+	schedule := api.JobSchedule{
+		ID:        s.ID,
+		JobType:   s.JobType,
+		Schedule:  s.Schedule,
+		Enabled:   s.Enabled,
+		LastRunAt: convertTimeToAPI(s.LastRunAt),
+		NextRunAt: convertTimeToAPI(s.NextRunAt),
+		IsRunning: s.IsLocked(now),
+	}
+	if schedule.IsRunning {
+		schedule.RunningOn = s.LockedBy
+	}
+	return schedule
+}
+
+func (s *JobSchedules) ConvertToAPI() api.JobSchedules {
+	schedules := make(api.JobSchedules, len(*s))
+	for i := range *s {
+		schedules[i] = (*s)[i].ConvertToAPI()
+	}
+	return schedules
+}
+
+type JobRuns []JobRun
+
+// JobRun is the record of one run of a background job
+type JobRun struct {
+	ID            uuid.UUID         `db:"id"`
+	JobScheduleID uuid.UUID         `db:"job_schedule_id" validate:"required"`
+	Trigger       api.JobRunTrigger `db:"trigger" validate:"jobRunTrigger"`
+	TriggeredByID nulls.UUID        `db:"triggered_by_id"`
+	Instance      string            `db:"instance"`
+	StartedAt     nulls.Time        `db:"started_at"`
+	FinishedAt    nulls.Time        `db:"finished_at"`
+	DurationMS    int               `db:"duration_ms"`
+	Result        api.JobRunResult  `db:"result" validate:"jobRunResult"`
+	Error         string            `db:"error"`
+	CreatedAt     time.Time         `db:"created_at"`
+	UpdatedAt     time.Time         `db:"updated_at"`
+
+	JobSchedule JobSchedule `belongs_to:"job_schedules" validate:"-"`
+}
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (r *JobRun) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validateModel(r), nil
+}
+
+func (r *JobRun) Create(tx *pop.Connection) error {
+	return create(tx, r)
+}
+
+func (r *JobRun) Update(tx *pop.Connection) error {
+	return update(tx, r)
+}
+
+func (r *JobRun) FindByID(tx *pop.Connection, id uuid.UUID) error {
+	return appErrorFromDB(tx.Find(r, id), api.ErrorQueryFailure)
+}
+
+// LoadJobSchedule - a simple wrapper method for loading the schedule on the struct
+func (r *JobRun) LoadJobSchedule(tx *pop.Connection, reload bool) {
+	if r.JobSchedule.ID == uuid.Nil || reload {
+		if err := tx.Load(r, "JobSchedule"); err != nil {
+			panic("database error loading JobRun.JobSchedule, " + err.Error())
+		}
+	}
+}
+
+// Start records the start of the run on the given instance, and the last run time of the schedule
+func (r *JobRun) Start(tx *pop.Connection, instance string, now time.Time) error {
+	r.Instance = instance
+	r.StartedAt = nulls.NewTime(now)
+	r.Result = api.JobRunResultRunning
+	if err := r.Update(tx); err != nil {
+		return err
+	}
+
+	r.LoadJobSchedule(tx, false)
+	r.JobSchedule.LastRunAt = nulls.NewTime(now)
+	return appErrorFromDB(tx.UpdateColumns(&r.JobSchedule, "last_run_at", "updated_at"), api.ErrorUpdateFailure)
+}
+
+// Finish records the result of the run. A run that returns an error has failed.
+func (r *JobRun) Finish(tx *pop.Connection, now time.Time, runErr error) error {
+	r.FinishedAt = nulls.NewTime(now)
+	if r.StartedAt.Valid {
+		r.DurationMS = int(now.Sub(r.StartedAt.Time).Milliseconds())
+	}
+
+	r.Result = api.JobRunResultSuccess
+	if runErr != nil {
+		r.Result = api.JobRunResultFailure
+		r.Error = runErr.Error()
+	}
+	return r.Update(tx)
+}
+
+// Recent loads the most recent runs, of all jobs or of the given job type
+func (r *JobRuns) Recent(tx *pop.Connection, jobType string, limit int) error {
+	if limit <= 0 || limit > MaxJobRuns {
+		limit = MaxJobRuns
+	}
+
+	q := tx.Order("job_runs.created_at desc").Limit(limit)
+	if jobType != "" {
+		q = q.Join("job_schedules s", "s.id = job_runs.job_schedule_id").Where("s.job_type = ?", jobType)
+	}
+	return appErrorFromDB(q.All(r), api.ErrorQueryFailure)
+}
+
+func (r *JobRun) ConvertToAPI(tx *pop.Connection) api.JobRun {
+	r.LoadJobSchedule(tx, false)
+
+	return api.JobRun{
+		ID:            r.ID,
+		JobType:       r.JobSchedule.JobType,
+		Trigger:       r.Trigger,
+		TriggeredByID: convertUUIDToAPI(r.TriggeredByID),
+		Instance:      r.Instance,
+		StartedAt:     convertTimeToAPI(r.StartedAt),
+		FinishedAt:    convertTimeToAPI(r.FinishedAt),
+		DurationMS:    r.DurationMS,
+		Result:        r.Result,
+		Error:         r.Error,
+		CreatedAt:     r.CreatedAt,
+	}
+}
+
+func (r *JobRuns) ConvertToAPI(tx *pop.Connection) api.JobRuns {
+	runs := make(api.JobRuns, len(*r))
+	for i := range *r {
+		runs[i] = (*r)[i].ConvertToAPI(tx)
+	}
+	return runs
+}
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+func createJobSchedule(ms *ModelSuite, jobType string, nextRunAt nulls.Time) JobSchedule {
+	s := JobSchedule{
+		JobType:   jobType,
+		Schedule:  "0 1 * * *",
+		Enabled:   true,
+		NextRunAt: nextRunAt,
+	}
+	ms.NoError(s.Create(ms.DB))
+	return s
+}
+
+func (ms *ModelSuite) TestJobSchedules_FindDue() {
+	now := time.Now().UTC()
+	due := createJobSchedule(ms, "due", nulls.NewTime(now.Add(-time.Minute)))
+	unset := createJobSchedule(ms, "unset", nulls.Time{})
+	createJobSchedule(ms, "later", nulls.NewTime(now.Add(time.Hour)))
+
+	disabled := createJobSchedule(ms, "disabled", nulls.NewTime(now.Add(-time.Minute)))
+	disabled.Enabled = false
+	ms.NoError(ms.DB.Update(&disabled))
+
+	var schedules JobSchedules
+	ms.NoError(schedules.FindDue(ms.DB, now))
+
+	ids := map[string]bool{}
+	for _, s := range schedules {
+		ids[s.JobType] = true
+	}
+	ms.Equal(map[string]bool{due.JobType: true, unset.JobType: true}, ids)
+}
+
+func (ms *ModelSuite) TestJobSchedule_ClaimDue() {
+	now := time.Now().UTC()
+	next := now.Add(time.Hour)
+	s := createJobSchedule(ms, "claim", nulls.NewTime(now.Add(-time.Minute)))
+	other := s
+
+	claimed, err := s.ClaimDue(ms.DB, "one", now, next, now.Add(time.Hour))
+	ms.NoError(err)
+	ms.True(claimed, "a due job should be claimed")
+
+	claimed, err = other.ClaimDue(ms.DB, "two", now, next, now.Add(time.Hour))
+	ms.NoError(err)
+	ms.False(claimed, "a job should only be claimed once")
+
+	ms.NoError(ms.DB.Reload(&s))
+	ms.Equal("one", s.LockedBy)
+	ms.WithinDuration(next, s.NextRunAt.Time, time.Second)
+	ms.True(s.IsLocked(now))
+}
+
+func (ms *ModelSuite) TestJobSchedule_Lock() {
+	now := time.Now().UTC()
+	s := createJobSchedule(ms, "lock", nulls.Time{})
+
+	locked, err := s.Lock(ms.DB, "one", now, now.Add(time.Hour))
+	ms.NoError(err)
+	ms.True(locked)
+
+	locked, err = s.Lock(ms.DB, "two", now, now.Add(time.Hour))
+	ms.NoError(err)
+	ms.False(locked, "a locked schedule should not be locked by another instance")
+
+	ms.NoError(s.Unlock(ms.DB, "two"))
+	ms.NoError(ms.DB.Reload(&s))
+	ms.Equal("one", s.LockedBy, "only the instance that holds the lock should release it")
+
+	locked, err = s.Lock(ms.DB, "two", now.Add(2*time.Hour), now.Add(3*time.Hour))
+	ms.NoError(err)
+	ms.True(locked, "an expired lock should be taken over")
+
+	ms.NoError(s.Unlock(ms.DB, "two"))
+	ms.NoError(ms.DB.Reload(&s))
+	ms.False(s.IsLocked(now))
+}
+
+func (ms *ModelSuite) TestJobRun_Finish() {
+	s := createJobSchedule(ms, "finish", nulls.Time{})
+	start := time.Now().UTC().Add(-time.Minute)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantResult api.JobRunResult
+	}{
+		{name: "success", wantResult: api.JobRunResultSuccess},
+		{name: "failure", err: errors.New("boom"), wantResult: api.JobRunResultFailure},
+	}
+	for _, tt := range tests {
+		ms.Run(tt.name, func() {
+			run := JobRun{JobScheduleID: s.ID, Trigger: api.JobRunTriggerManual, Result: api.JobRunResultQueued}
+			ms.NoError(run.Create(ms.DB))
+			ms.NoError(run.Start(ms.DB, "one", start))
+			ms.NoError(run.Finish(ms.DB, start.Add(time.Second*2), tt.err))
+
+			var got JobRun
+			ms.NoError(got.FindByID(ms.DB, run.ID))
+			ms.Equal(tt.wantResult, got.Result)
+			ms.Equal(2000, got.DurationMS)
+			ms.Equal("one", got.Instance)
+			if tt.err != nil {
+				ms.Equal(tt.err.Error(), got.Error)
+			}
+
+			got.LoadJobSchedule(ms.DB, false)
+			ms.WithinDuration(start, got.JobSchedule.LastRunAt.Time, time.Second)
+		})
+	}
+}
+
+func (ms *ModelSuite) TestJobRuns_Recent() {
+	a := createJobSchedule(ms, "a", nulls.Time{})
+	b := createJobSchedule(ms, "b", nulls.Time{})
+	for _, s := range []JobSchedule{a, a, b} {
+		run := JobRun{JobScheduleID: s.ID, Trigger: api.JobRunTriggerSchedule, Result: api.JobRunResultQueued}
+		ms.NoError(run.Create(ms.DB))
+	}
+
+	var runs JobRuns
+	ms.NoError(runs.Recent(ms.DB, "", 0))
+	ms.Len(runs, 3)
+
+	ms.NoError(runs.Recent(ms.DB, a.JobType, 0))
+	ms.Len(runs, 2)
+	for _, r := range runs.ConvertToAPI(ms.DB) {
+		ms.Equal(a.JobType, r.JobType)
+	}
+
+	ms.NoError(runs.Recent(ms.DB, "", 1))
+	ms.Len(runs, 1)
+}
@@ -0,0 +1,135 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/fin"
+)
+
+// JournalValidation is the result of checking each block of a ledger report as it is rendered: the debits and
+// credits must match, every ledger entry of the block must be in the report, and every entry must have the accounts
+// needed to post it. Amounts are counted as they appear in the report, i.e. a charge to a policy is a debit.
+type JournalValidation struct {
+	Imbalances []JournalImbalance
+}
+
+// JournalImbalance is a block of a ledger report that cannot be posted as rendered
+type JournalImbalance struct {
+	Block   string
+	Account string // the credit account of the block as rendered
+	Debits  api.Currency
+	Credits api.Currency
+
+	// Missing is the net amount of the ledger entries of the block that are not in the report
+	Missing api.Currency
+
+	// Entries are the entries of the block that cannot be posted because an account is missing
+	Entries LedgerEntries
+}
+
+// IsBalanced returns true if every block balances
+func (v JournalValidation) IsBalanced() bool {
+	return len(v.Imbalances) == 0
+}
+
+// String describes each imbalance on a line of its own, or returns an empty string if every block balances
+func (v JournalValidation) String() string {
+	lines := make([]string, len(v.Imbalances))
+	for i, b := range v.Imbalances {
+		lines[i] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (b JournalImbalance) String() string {
+	entries := make([]string, len(b.Entries))
+	for i, e := range b.Entries {
+		entries[i] = fmt.Sprintf("%s (%s %s)", e.ID, e.getDescription(), e.Amount.String())
+	}
+	return fmt.Sprintf("%s, account %q: debits %s, credits %s, missing from the report %s; "+
+		"entries that cannot be posted: %s",
+		b.Block, b.Account, b.Debits.String(), b.Credits.String(), b.Missing.String(), strings.Join(entries, ", "))
+}
+
+// validateJournal checks the blocks of a report as rendered against the ledger entries of each block
+func validateJournal(blocks []fin.RenderedBlock, entries map[string]LedgerEntries) JournalValidation {
+	var v JournalValidation
+
+	rendered := map[string]bool{}
+	for _, b := range blocks {
+		rendered[b.Name] = true
+		v.checkBlock(b, entries[b.Name])
+	}
+
+	// a block is left out of the report if all of its transactions were dropped
+	for name, e := range entries {
+		if !rendered[name] && e.hasAmount() {
+			v.checkBlock(fin.RenderedBlock{Name: name}, e)
+		}
+	}
+
+	sort.Slice(v.Imbalances, func(i, j int) bool {
+		return v.Imbalances[i].Block < v.Imbalances[j].Block
+	})
+	return v
+}
+
+// checkBlock adds the block to the imbalances if its debits and credits do not match, it has no credit account,
+// some of its ledger entries are not in it, or some of the entries are missing an account
+func (v *JournalValidation) checkBlock(block fin.RenderedBlock, entries LedgerEntries) {
+	imbalance := JournalImbalance{Block: block.Name, Account: block.CreditAccount}
+
+	add := func(amount api.Currency) {
+		// the report shows the negated ledger amount
+		if amount < 0 {
+			imbalance.Debits -= amount
+		} else {
+			imbalance.Credits += amount
+		}
+	}
+
+	for _, t := range block.Transactions {
+		add(t.Amount)
+		imbalance.Missing -= t.Amount
+	}
+	add(block.Balance)
+
+	for _, e := range entries {
+		imbalance.Missing += e.Amount
+		if e.Amount == 0 {
+			continue
+		}
+		if e.IncomeAccount == "" || !e.hasDebitAccount() {
+			imbalance.Entries = append(imbalance.Entries, e)
+		}
+	}
+
+	if imbalance.Debits == imbalance.Credits && imbalance.Missing == 0 && block.CreditAccount != "" &&
+		len(imbalance.Entries) == 0 {
+		return
+	}
+
+	v.Imbalances = append(v.Imbalances, imbalance)
+}
+
+// hasAmount returns true if any of the entries has a non-zero amount
+func (le LedgerEntries) hasAmount() bool {
+	for _, e := range le {
+		if e.Amount != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDebitAccount returns true if the entry has the information needed to charge it to an account: the
+// household ID for household policies or the entity code for other policies
+func (le *LedgerEntry) hasDebitAccount() bool {
+	if le.PolicyType == api.PolicyTypeHousehold {
+		return le.HouseholdID != ""
+	}
+	return le.EntityCode != ""
+}
@@ -0,0 +1,199 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/fin"
+)
+
+func (ms *ModelSuite) TestLedgerEntries_prepareReportValidation() {
+	date := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	householdEntry := LedgerEntry{
+		ID:               domain.GetUUID(),
+		PolicyType:       api.PolicyTypeHousehold,
+		Type:             LedgerEntryTypeNewCoverage,
+		EntityCode:       "MMB",
+		RiskCategoryName: "Mobile",
+		RiskCategoryCC:   "MPR",
+		PolicyName:       "OurPolicy",
+		Amount:           -100,
+		DateSubmitted:    date,
+		IncomeAccount:    "40200",
+		HouseholdID:      "1234",
+	}
+	noHouseholdID := householdEntry
+	noHouseholdID.ID = domain.GetUUID()
+	noHouseholdID.HouseholdID = ""
+
+	teamEntry := householdEntry
+	teamEntry.ID = domain.GetUUID()
+	teamEntry.PolicyType = api.PolicyTypeTeam
+	teamEntry.EntityCode = "ABC"
+	teamEntry.HouseholdID = ""
+	teamEntry.IncomeAccount = "40300"
+
+	reversal := householdEntry
+	reversal.ID = domain.GetUUID()
+	reversal.Amount = -householdEntry.Amount
+	reversal.ReversalOfID = nulls.NewUUID(householdEntry.ID)
+
+	noIncomeAccount := teamEntry
+	noIncomeAccount.ID = domain.GetUUID()
+	noIncomeAccount.IncomeAccount = ""
+
+	tests := []struct {
+		name          string
+		format        string
+		entries       LedgerEntries
+		wantAccounts  []string
+		wantEntries   []LedgerEntries
+		wantDebits    []api.Currency
+		wantCredits   []api.Currency
+		wantImbalance bool
+	}{
+		{
+			name:    "balanced",
+			entries: LedgerEntries{householdEntry, teamEntry},
+		},
+		{
+			name:    "block that nets to zero",
+			entries: LedgerEntries{householdEntry, reversal, teamEntry},
+		},
+		{
+			name:    "block that nets to zero, NetSuite",
+			format:  fin.ReportFormatNetSuite,
+			entries: LedgerEntries{householdEntry, reversal, teamEntry},
+		},
+		{
+			name:    "balanced NetSuite",
+			format:  fin.ReportFormatNetSuite,
+			entries: LedgerEntries{householdEntry, teamEntry},
+		},
+		{
+			name:          "missing household ID",
+			entries:       LedgerEntries{householdEntry, noHouseholdID, teamEntry},
+			wantImbalance: true,
+			wantAccounts:  []string{"40200MPR"},
+			wantEntries:   []LedgerEntries{{noHouseholdID}},
+			wantDebits:    []api.Currency{200},
+			wantCredits:   []api.Currency{200},
+		},
+		{
+			name:          "missing income account",
+			entries:       LedgerEntries{householdEntry, noIncomeAccount},
+			wantImbalance: true,
+			wantAccounts:  []string{"MPR"},
+			wantEntries:   []LedgerEntries{{noIncomeAccount}},
+			wantDebits:    []api.Currency{100},
+			wantCredits:   []api.Currency{100},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			format := tt.format
+			if format == "" {
+				format = fin.ReportFormatSage
+			}
+			_, got, err := tt.entries.prepareReport(format, ReportTypeMonthly, date)
+			ms.NoError(err)
+
+			if !tt.wantImbalance {
+				ms.True(got.IsBalanced(), "unexpected imbalance: %s", got.String())
+				ms.Equal("", got.String())
+				return
+			}
+
+			ms.False(got.IsBalanced())
+			ms.Equal(len(tt.wantAccounts), len(got.Imbalances))
+			for i, b := range got.Imbalances {
+				ms.Equal(tt.wantAccounts[i], b.Account, "incorrect account")
+				ms.Equal(tt.wantDebits[i], b.Debits, "incorrect debits")
+				ms.Equal(tt.wantCredits[i], b.Credits, "incorrect credits")
+				ms.Equal(len(tt.wantEntries[i]), len(b.Entries), "incorrect number of entries")
+				for j := range b.Entries {
+					ms.Equal(tt.wantEntries[i][j].ID, b.Entries[j].ID)
+					ms.Contains(got.String(), b.Entries[j].ID.String())
+				}
+			}
+		})
+	}
+}
+
+func (ms *ModelSuite) TestJournalValidation_checkBlock() {
+	entry := LedgerEntry{
+		ID:            domain.GetUUID(),
+		PolicyType:    api.PolicyTypeTeam,
+		EntityCode:    "ABC",
+		Amount:        -100,
+		IncomeAccount: "40300",
+	}
+	other := entry
+	other.ID = domain.GetUUID()
+	other.Amount = -50
+
+	t1 := fin.Transaction{Amount: entry.Amount}
+	t2 := fin.Transaction{Amount: other.Amount}
+
+	tests := []struct {
+		name        string
+		block       fin.RenderedBlock
+		wantOK      bool
+		wantDebits  api.Currency
+		wantCredits api.Currency
+		wantMissing api.Currency
+	}{
+		{
+			name:   "balanced",
+			block:  fin.RenderedBlock{Transactions: fin.Transactions{t1, t2}, CreditAccount: "40300", Balance: 150},
+			wantOK: true,
+		},
+		{
+			name:        "entry missing from the report",
+			block:       fin.RenderedBlock{Transactions: fin.Transactions{t1}, CreditAccount: "40300", Balance: 100},
+			wantDebits:  100,
+			wantCredits: 100,
+			wantMissing: -50,
+		},
+		{
+			name:        "credits do not match",
+			block:       fin.RenderedBlock{Transactions: fin.Transactions{t1, t2}, CreditAccount: "40300", Balance: 100},
+			wantDebits:  150,
+			wantCredits: 100,
+		},
+		{
+			name:        "no credit account",
+			block:       fin.RenderedBlock{Transactions: fin.Transactions{t1, t2}, Balance: 150},
+			wantDebits:  150,
+			wantCredits: 150,
+		},
+		{
+			name:        "block not in the report",
+			block:       fin.RenderedBlock{},
+			wantMissing: -150,
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			var v JournalValidation
+			v.checkBlock(tt.block, LedgerEntries{entry, other})
+
+			if tt.wantOK {
+				ms.True(v.IsBalanced(), "unexpected imbalance: %s", v.String())
+				return
+			}
+
+			ms.False(v.IsBalanced())
+			ms.Len(v.Imbalances, 1)
+			ms.Equal(tt.wantDebits, v.Imbalances[0].Debits, "incorrect debits")
+			ms.Equal(tt.wantCredits, v.Imbalances[0].Credits, "incorrect credits")
+			ms.Equal(tt.wantMissing, v.Imbalances[0].Missing, "incorrect missing amount")
+			ms.Empty(v.Imbalances[0].Entries)
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+// ValidLedgerAdjustmentTypes are the ledger entry types that may be entered manually. Claim entries are only
+// created by the claim process.
+var ValidLedgerAdjustmentTypes = map[LedgerEntryType]struct{}{
+	LedgerEntryTypeNewCoverage:      {},
+	LedgerEntryTypeCoverageChange:   {},
+	LedgerEntryTypeCoverageRefund:   {},
+	LedgerEntryTypePolicyAdjustment: {},
+}
+
+var ValidLedgerAdjustmentStatuses = map[api.LedgerAdjustmentStatus]struct{}{
+	api.LedgerAdjustmentStatusProposed: {},
+	api.LedgerAdjustmentStatusApproved: {},
+	api.LedgerAdjustmentStatusRejected: {},
+}
+
+type LedgerAdjustments []LedgerAdjustment
+
+// LedgerAdjustment is a manual correction to the ledger. It is proposed by a steward and a ledger entry is created
+// only when a signator other than the proposer approves it.
+type LedgerAdjustment struct {
+	ID            uuid.UUID                  `db:"id"`
+	PolicyID      uuid.UUID                  `db:"policy_id" validate:"required"`
+	ItemID        nulls.UUID                 `db:"item_id"`
+	Type          LedgerEntryType            `db:"type" validate:"required"`
+	Amount        api.Currency               `db:"amount"` // reimbursements/reductions are positive and charges are negative
+	Reason        string                     `db:"reason" validate:"required"`
+	Status        api.LedgerAdjustmentStatus `db:"status" validate:"ledgerAdjustmentStatus"`
+	ProposedByID  uuid.UUID                  `db:"proposed_by_id" validate:"required"`
+	ReviewedByID  nulls.UUID                 `db:"reviewed_by_id"`
+	ReviewedAt    nulls.Time                 `db:"reviewed_at"`
+	ReviewNote    string                     `db:"review_note"`
+	LedgerEntryID nulls.UUID                 `db:"ledger_entry_id"`
+	CreatedAt     time.Time                  `db:"created_at"`
+	UpdatedAt     time.Time                  `db:"updated_at"`
+
+	Policy Policy `belongs_to:"policies" validate:"-"`
+}
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (a *LedgerAdjustment) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validateModel(a), nil
+}
+
+func (a *LedgerAdjustment) Create(tx *pop.Connection) error {
+	return create(tx, a)
+}
+
+func (a *LedgerAdjustment) Update(tx *pop.Connection) error {
+	return update(tx, a)
+}
+
+func (a *LedgerAdjustment) GetID() uuid.UUID {
+	return a.ID
+}
+
+func (a *LedgerAdjustment) FindByID(tx *pop.Connection, id uuid.UUID) error {
+	return tx.Find(a, id)
+}
+
+// IsActorAllowedTo ensures the actor is an admin. Only signators may approve or reject an adjustment.
+func (a *LedgerAdjustment) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permission, sub SubResource, req *http.Request) bool {
+	if !actor.IsAdmin() {
+		return false
+	}
+
+	switch sub {
+	case "":
+		return true
+	case api.ResourceApprove, api.ResourceReject:
+		return actor.AppRole == AppRoleSignator
+	}
+	return false
+}
+
+// FindAll loads all the adjustments, most recent first. If a status is given, only adjustments with that status
+// are loaded.
+func (a *LedgerAdjustments) FindAll(tx *pop.Connection, status api.LedgerAdjustmentStatus) error {
+	q := tx.Order("created_at desc")
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+	return appErrorFromDB(q.All(a), api.ErrorQueryFailure)
+}
+
+// NewLedgerAdjustment proposes a ledger adjustment. It does not affect the ledger until it is approved.
+func NewLedgerAdjustment(ctx context.Context, input api.LedgerAdjustmentInput) (LedgerAdjustment, error) {
+	tx := Tx(ctx)
+
+	a := LedgerAdjustment{
+		PolicyID:     input.PolicyID,
+		Type:         LedgerEntryType(input.Type),
+		Amount:       input.Amount,
+		Reason:       strings.TrimSpace(input.Reason),
+		Status:       api.LedgerAdjustmentStatusProposed,
+		ProposedByID: CurrentUser(ctx).ID,
+	}
+	if input.ItemID != nil {
+		a.ItemID = nulls.NewUUID(*input.ItemID)
+	}
+
+	if err := a.validateInput(tx); err != nil {
+		return LedgerAdjustment{}, err
+	}
+
+	if err := a.Create(tx); err != nil {
+		return LedgerAdjustment{}, err
+	}
+
+	if err := a.createHistory(ctx, api.HistoryActionCreate, "", a.summary()); err != nil {
+		return LedgerAdjustment{}, err
+	}
+
+	return a, nil
+}
+
+func (a *LedgerAdjustment) validateInput(tx *pop.Connection) error {
+	if _, ok := ValidLedgerAdjustmentTypes[a.Type]; !ok {
+		err := fmt.Errorf("invalid ledger adjustment type: %s", a.Type)
+		return api.NewAppError(err, api.ErrorLedgerAdjustmentInvalid, api.CategoryUser)
+	}
+	if a.Amount == 0 {
+		err := errors.New("a ledger adjustment must have a non-zero amount")
+		return api.NewAppError(err, api.ErrorLedgerAdjustmentInvalid, api.CategoryUser)
+	}
+	if a.Type == LedgerEntryTypeNewCoverage && a.Amount > 0 || a.Type == LedgerEntryTypeCoverageRefund && a.Amount < 0 {
+		err := fmt.Errorf("invalid amount for ledger adjustment type %s: %s", a.Type, a.Amount.String())
+		return api.NewAppError(err, api.ErrorLedgerAdjustmentInvalid, api.CategoryUser)
+	}
+	if a.Reason == "" {
+		err := errors.New("a ledger adjustment must have a reason")
+		return api.NewAppError(err, api.ErrorLedgerAdjustmentInvalid, api.CategoryUser)
+	}
+
+	if err := a.Policy.FindByID(tx, a.PolicyID); err != nil {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	if a.ItemID.Valid {
+		var item Item
+		if err := item.FindByID(tx, a.ItemID.UUID); err != nil {
+			return appErrorFromDB(err, api.ErrorQueryFailure)
+		}
+		if item.PolicyID != a.PolicyID {
+			err := fmt.Errorf("item %s is not on policy %s", item.ID, a.PolicyID)
+			return api.NewAppError(err, api.ErrorLedgerAdjustmentInvalid, api.CategoryUser)
+		}
+	}
+	return nil
+}
+
+// Approve creates the ledger entry for a proposed adjustment. It is included in the next monthly ledger report.
+// The proposer of the adjustment cannot approve it.
+func (a *LedgerAdjustment) Approve(ctx context.Context) error {
+	tx := Tx(ctx)
+	actor := CurrentUser(ctx)
+
+	if err := a.checkReview(actor); err != nil {
+		return err
+	}
+
+	a.LoadPolicy(tx, false)
+	a.Policy.LoadEntityCode(tx, false)
+
+	var item *Item
+	name := ""
+	if a.ItemID.Valid {
+		item = &Item{}
+		if err := item.FindByID(tx, a.ItemID.UUID); err != nil {
+			return appErrorFromDB(err, api.ErrorQueryFailure)
+		}
+		item.LoadRiskCategory(tx, false)
+		name = item.GetAccountablePersonName(tx).String()
+	}
+
+	now := time.Now().UTC()
+
+	entry := NewLedgerEntry(name, a.Policy, item, nil, now)
+	entry.Type = a.Type
+	entry.Amount = a.Amount
+	if err := entry.Create(tx); err != nil {
+		return err
+	}
+
+	a.Status = api.LedgerAdjustmentStatusApproved
+	a.ReviewedByID = nulls.NewUUID(actor.ID)
+	a.ReviewedAt = nulls.NewTime(now)
+	a.LedgerEntryID = nulls.NewUUID(entry.ID)
+	if err := a.Update(tx); err != nil {
+		return err
+	}
+
+	return a.createHistory(ctx, api.HistoryActionUpdate,
+		string(api.LedgerAdjustmentStatusProposed), string(api.LedgerAdjustmentStatusApproved)+": "+a.summary())
+}
+
+// Reject closes a proposed adjustment without changing the ledger
+func (a *LedgerAdjustment) Reject(ctx context.Context, note string) error {
+	actor := CurrentUser(ctx)
+
+	if err := a.checkReview(actor); err != nil {
+		return err
+	}
+
+	a.Status = api.LedgerAdjustmentStatusRejected
+	a.ReviewedByID = nulls.NewUUID(actor.ID)
+	a.ReviewedAt = nulls.NewTime(time.Now().UTC())
+	a.ReviewNote = strings.TrimSpace(note)
+	if err := a.Update(Tx(ctx)); err != nil {
+		return err
+	}
+
+	return a.createHistory(ctx, api.HistoryActionUpdate,
+		string(api.LedgerAdjustmentStatusProposed), string(api.LedgerAdjustmentStatusRejected)+": "+a.summary())
+}
+
+// checkReview ensures that the adjustment is waiting for review and that the actor did not propose it
+func (a *LedgerAdjustment) checkReview(actor User) error {
+	if a.Status != api.LedgerAdjustmentStatusProposed {
+		err := fmt.Errorf("cannot review a ledger adjustment with status %s", a.Status)
+		return api.NewAppError(err, api.ErrorLedgerAdjustmentStatus, api.CategoryUser)
+	}
+	if a.ProposedByID == actor.ID {
+		err := fmt.Errorf("user %s cannot review their own ledger adjustment", actor.ID)
+		return api.NewAppError(err, api.ErrorLedgerAdjustmentReviewer, api.CategoryUser)
+	}
+	return nil
+}
+
+// createHistory records a step of the adjustment in the policy history
+func (a *LedgerAdjustment) createHistory(ctx context.Context, action, oldValue, newValue string) error {
+	tx := Tx(ctx)
+	a.LoadPolicy(tx, false)
+
+	history := a.Policy.NewHistory(ctx, action, FieldUpdate{
+		FieldName: FieldPolicyLedgerAdjustment,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+	})
+	history.ItemID = a.ItemID
+	return history.Create(tx)
+}
+
+func (a *LedgerAdjustment) summary() string {
+	return fmt.Sprintf("%s %s (%s)", a.Type, a.Amount.String(), a.Reason)
+}
+
+// LoadPolicy - a simple wrapper method for loading the policy on the struct
+func (a *LedgerAdjustment) LoadPolicy(tx *pop.Connection, reload bool) {
+	if a.Policy.ID == uuid.Nil || reload {
+		if err := tx.Load(a, "Policy"); err != nil {
+			panic("database error loading LedgerAdjustment.Policy, " + err.Error())
+		}
+	}
+}
+
+func (a *LedgerAdjustment) ConvertToAPI(tx *pop.Connection) api.LedgerAdjustment {
+	var proposedBy User
+	if err := proposedBy.FindByID(tx, a.ProposedByID); err != nil {
+		panic("database error loading LedgerAdjustment proposer, " + err.Error())
+	}
+
+	reviewedByName := ""
+	if a.ReviewedByID.Valid {
+		var reviewedBy User
+		if err := reviewedBy.FindByID(tx, a.ReviewedByID.UUID); err != nil {
+			panic("database error loading LedgerAdjustment reviewer, " + err.Error())
+		}
+		reviewedByName = reviewedBy.Name()
+	}
+
+	return api.LedgerAdjustment{
+		ID:             a.ID,
+		PolicyID:       a.PolicyID,
+		ItemID:         convertUUIDToAPI(a.ItemID),
+		Type:           api.LedgerEntryType(a.Type),
+		Amount:         a.Amount,
+		Reason:         a.Reason,
+		Status:         a.Status,
+		ProposedByID:   a.ProposedByID,
+		ProposedByName: proposedBy.Name(),
+		ReviewedByID:   convertUUIDToAPI(a.ReviewedByID),
+		ReviewedByName: reviewedByName,
+		ReviewedAt:     convertTimeToAPI(a.ReviewedAt),
+		ReviewNote:     a.ReviewNote,
+		LedgerEntryID:  convertUUIDToAPI(a.LedgerEntryID),
+		CreatedAt:      a.CreatedAt,
+		UpdatedAt:      a.UpdatedAt,
+	}
+}
+
+func (a *LedgerAdjustments) ConvertToAPI(tx *pop.Connection) api.LedgerAdjustments {
+	adjustments := make(api.LedgerAdjustments, len(*a))
+	for i := range *a {
+		adjustments[i] = (*a)[i].ConvertToAPI(tx)
+	}
+	return adjustments
+}
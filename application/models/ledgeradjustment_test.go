@@ -0,0 +1,148 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+func (ms *ModelSuite) TestNewLedgerAdjustment() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 2, ItemsPerPolicy: 1})
+	policy := f.Policies[0]
+	ctx := CreateTestContext(CreateAdminUsers(ms.DB)[AppRoleSteward])
+
+	good := api.LedgerAdjustmentInput{
+		PolicyID: policy.ID,
+		ItemID:   &policy.Items[0].ID,
+		Type:     api.LedgerEntryType(LedgerEntryTypePolicyAdjustment),
+		Amount:   2500,
+		Reason:   " goodwill credit ",
+	}
+
+	tests := []struct {
+		name    string
+		input   func(api.LedgerAdjustmentInput) api.LedgerAdjustmentInput
+		wantErr *api.AppError
+	}{
+		{
+			name: "claim type",
+			input: func(in api.LedgerAdjustmentInput) api.LedgerAdjustmentInput {
+				in.Type = api.LedgerEntryType(LedgerEntryTypeClaim)
+				return in
+			},
+			wantErr: &api.AppError{Key: api.ErrorLedgerAdjustmentInvalid, Category: api.CategoryUser},
+		},
+		{
+			name: "zero amount",
+			input: func(in api.LedgerAdjustmentInput) api.LedgerAdjustmentInput {
+				in.Amount = 0
+				return in
+			},
+			wantErr: &api.AppError{Key: api.ErrorLedgerAdjustmentInvalid, Category: api.CategoryUser},
+		},
+		{
+			name: "premium credited",
+			input: func(in api.LedgerAdjustmentInput) api.LedgerAdjustmentInput {
+				in.Type = api.LedgerEntryType(LedgerEntryTypeNewCoverage)
+				return in
+			},
+			wantErr: &api.AppError{Key: api.ErrorLedgerAdjustmentInvalid, Category: api.CategoryUser},
+		},
+		{
+			name: "no reason",
+			input: func(in api.LedgerAdjustmentInput) api.LedgerAdjustmentInput {
+				in.Reason = " "
+				return in
+			},
+			wantErr: &api.AppError{Key: api.ErrorLedgerAdjustmentInvalid, Category: api.CategoryUser},
+		},
+		{
+			name: "item on another policy",
+			input: func(in api.LedgerAdjustmentInput) api.LedgerAdjustmentInput {
+				in.ItemID = &f.Policies[1].Items[0].ID
+				return in
+			},
+			wantErr: &api.AppError{Key: api.ErrorLedgerAdjustmentInvalid, Category: api.CategoryUser},
+		},
+		{
+			name: "good",
+			input: func(in api.LedgerAdjustmentInput) api.LedgerAdjustmentInput {
+				return in
+			},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			got, err := NewLedgerAdjustment(ctx, tt.input(good))
+			if tt.wantErr != nil {
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+			ms.Equal(api.LedgerAdjustmentStatusProposed, got.Status)
+			ms.Equal("goodwill credit", got.Reason)
+			ms.Equal(CurrentUser(ctx).ID, got.ProposedByID)
+
+			n, err := ms.DB.Where("policy_id = ? AND field_name = ?", policy.ID, FieldPolicyLedgerAdjustment).
+				Count(PolicyHistory{})
+			ms.NoError(err)
+			ms.Equal(1, n, "incorrect number of policy history records")
+
+			n, err = ms.DB.Where("policy_id = ? AND type = ?", policy.ID, LedgerEntryTypePolicyAdjustment).
+				Count(LedgerEntry{})
+			ms.NoError(err)
+			ms.Equal(0, n, "a proposed adjustment should not create a ledger entry")
+		})
+	}
+}
+
+func (ms *ModelSuite) TestLedgerAdjustment_Approve() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 1, ItemsPerPolicy: 1})
+	policy := f.Policies[0]
+	admins := CreateAdminUsers(ms.DB)
+	signator := admins[AppRoleSignator]
+	otherSignator := createAdminUserWithRole(ms.DB, AppRoleSignator)
+
+	proposed, err := NewLedgerAdjustment(CreateTestContext(signator), api.LedgerAdjustmentInput{
+		PolicyID: policy.ID,
+		ItemID:   &policy.Items[0].ID,
+		Type:     api.LedgerEntryType(LedgerEntryTypeCoverageChange),
+		Amount:   -1500,
+		Reason:   "mis-billed premium",
+	})
+	ms.NoError(err)
+
+	ms.EqualAppError(api.AppError{Key: api.ErrorLedgerAdjustmentReviewer, Category: api.CategoryUser},
+		proposed.Approve(CreateTestContext(signator)))
+
+	ms.NoError(proposed.Approve(CreateTestContext(otherSignator)))
+	ms.Equal(api.LedgerAdjustmentStatusApproved, proposed.Status)
+	ms.Equal(otherSignator.ID, proposed.ReviewedByID.UUID)
+	ms.True(proposed.LedgerEntryID.Valid)
+
+	var entry LedgerEntry
+	ms.NoError(ms.DB.Find(&entry, proposed.LedgerEntryID.UUID))
+	ms.Equal(api.Currency(-1500), entry.Amount)
+	ms.Equal(LedgerEntryTypeCoverageChange, entry.Type)
+	ms.Equal(policy.Items[0].ID, entry.ItemID.UUID)
+	ms.False(entry.DateEntered.Valid)
+
+	var notEntered LedgerEntries
+	ms.NoError(notEntered.AllNotEntered(ms.DB, time.Now().UTC().Add(time.Minute)))
+	found := false
+	for _, e := range notEntered {
+		if e.ID == entry.ID {
+			found = true
+		}
+	}
+	ms.True(found, "approved adjustment should be included in the next monthly report")
+
+	ms.EqualAppError(api.AppError{Key: api.ErrorLedgerAdjustmentStatus, Category: api.CategoryUser},
+		proposed.Reject(CreateTestContext(otherSignator), "too late"))
+
+	n, err := ms.DB.Where("policy_id = ? AND field_name = ?", policy.ID, FieldPolicyLedgerAdjustment).
+		Count(PolicyHistory{})
+	ms.NoError(err)
+	ms.Equal(2, n, "incorrect number of policy history records")
+}
@@ -2,7 +2,9 @@ package models
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -40,6 +42,7 @@ const (
 	LedgerEntryTypeLegacy5          = LedgerEntryType("5")
 	LedgerEntryTypeClaimAdjustment  = LedgerEntryType("ClaimAdjustment")
 	LedgerEntryTypeLegacy20         = LedgerEntryType("20")
+	LedgerEntryTypeNoClaimsDiscount = LedgerEntryType("NoClaimsDiscount")
 )
 
 var ValidLedgerEntryTypes = map[LedgerEntryType]struct{}{
@@ -52,6 +55,7 @@ var ValidLedgerEntryTypes = map[LedgerEntryType]struct{}{
 	LedgerEntryTypeLegacy5:          {},
 	LedgerEntryTypeClaimAdjustment:  {},
 	LedgerEntryTypeLegacy20:         {},
+	LedgerEntryTypeNoClaimsDiscount: {},
 }
 
 func (t LedgerEntryType) Description(claimPayoutOption string, amount api.Currency) string {
@@ -62,6 +66,8 @@ func (t LedgerEntryType) Description(claimPayoutOption string, amount api.Curren
 		return "Coverage premium: Renew"
 	case LedgerEntryTypeCoverageRefund:
 		return "Coverage reimbursement: Remove"
+	case LedgerEntryTypeNoClaimsDiscount:
+		return "Coverage discount: No claims"
 	case LedgerEntryTypeCoverageChange, LedgerEntryTypePolicyAdjustment:
 		if amount >= 0 { // reimbursements/reductions are positive and charges are negative
 			return "Coverage reimbursement: Reduce"
@@ -106,6 +112,10 @@ type LedgerEntry struct {
 	DateSubmitted     time.Time       `db:"date_submitted"` // date added to ledger
 	DateEntered       nulls.Time      `db:"date_entered"`   // date entered into accounting system
 	LegacyID          nulls.Int       `db:"legacy_id"`
+	ReversalOfID      nulls.UUID      `db:"reversal_of_id"`    // the entry that this entry reverses
+	ReplacementOfID   nulls.UUID      `db:"replacement_of_id"` // the entry that this entry replaces
+	CorrectionReason  string          `db:"correction_reason"`
+	PeriodNote        string          `db:"period_note"` // explains a date moved out of a closed fiscal period
 
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
@@ -114,18 +124,60 @@ type LedgerEntry struct {
 	Item  *Item  `belongs_to:"items" validate:"-"`
 }
 
+// Create saves a new entry. If the entry is dated in a closed fiscal period, it is moved to the next open period.
 func (le *LedgerEntry) Create(tx *pop.Connection) error {
+	date, note, err := openPeriodDate(tx, le.DateSubmitted, "submission")
+	if err != nil {
+		return err
+	}
+	le.DateSubmitted = date
+	le.addPeriodNote(note)
+
 	return create(tx, le)
 }
 
+// Update saves the reconciliation metadata of the entry. Ledger entries are otherwise immutable. Use Reverse or
+// Correct to change the amount of an entry.
 func (le *LedgerEntry) Update(tx *pop.Connection) error {
-	return update(tx, le)
+	if err := tx.UpdateColumns(le, "date_entered", "period_note", "updated_at"); err != nil {
+		return appErrorFromDB(err, api.ErrorUpdateFailure)
+	}
+	return nil
+}
+
+func (le *LedgerEntry) GetID() uuid.UUID {
+	return le.ID
+}
+
+func (le *LedgerEntry) FindByID(tx *pop.Connection, id uuid.UUID) error {
+	return tx.Find(le, id)
+}
+
+// IsActorAllowedTo ensures the actor is an admin. Only signators may reverse an entry.
+func (le *LedgerEntry) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permission, sub SubResource, req *http.Request) bool {
+	if !actor.IsAdmin() {
+		return false
+	}
+
+	switch sub {
+	case "":
+		return true
+	case api.ResourceReverse:
+		return actor.AppRole == AppRoleSignator
+	}
+	return false
 }
 
 // AllNotEntered returns all the non-entered entries (date_entered is null) up to the given cutoff time.
 func (le *LedgerEntries) AllNotEntered(tx *pop.Connection, cutoff time.Time) error {
-	if err := tx.Where("date_submitted < ? ", cutoff).
-		Where("date_entered IS NULL").All(le); err != nil {
+	q := tx.Where("date_submitted < ? ", cutoff).Where("date_entered IS NULL")
+
+	// billing resumes in the first report after the household ID is corrected
+	if domain.Env.HouseholdIDBlockBilling {
+		q = q.Where("policy_id NOT IN (SELECT id FROM policies WHERE household_id_invalid_date IS NOT NULL)")
+	}
+
+	if err := q.All(le); err != nil {
 		return appErrorFromDB(err, api.ErrorQueryFailure)
 	}
 
@@ -137,68 +189,96 @@ func (le *LedgerEntries) AllNotEntered(tx *pop.Connection, cutoff time.Time) err
 	return nil
 }
 
-func (le *LedgerEntries) ExportForPolicy() ([]byte, string) {
-	report := fin.NewBatch(fin.ReportFormatPolicy, "", time.Now())
+// ExportForPolicy renders the entries in the policy report format, as CSV by default or as an XLSX workbook if the
+// export format is api.ExportFormatXLSX
+func (le *LedgerEntries) ExportForPolicy(exportFormat string) ([]byte, string, error) {
+	reportFormat := fin.ReportFormatPolicy
+	switch exportFormat {
+	case "", api.ExportFormatCSV:
+	case api.ExportFormatXLSX:
+		reportFormat += fin.SpreadsheetSuffix
+	default:
+		err := errors.New("invalid policy report format: " + exportFormat)
+		return nil, "", api.NewAppError(err, api.ErrorInvalidExportFormat, api.CategoryUser)
+	}
+
+	report, err := fin.NewBatch(reportFormat, "", time.Now())
+	if err != nil {
+		return nil, "", api.NewAppError(err, api.ErrorInvalidReportFormat, api.CategoryInternal)
+	}
 	for _, l := range *le {
-		report.AppendToBatch("", fin.Transaction{
-			EntityCode:        l.EntityCode,
-			RiskCategoryName:  l.RiskCategoryName,
-			RiskCategoryCC:    l.RiskCategoryCC,
-			Type:              string(l.Type),
-			PolicyType:        l.PolicyType,
-			HouseholdID:       l.HouseholdID,
-			CostCenter:        l.CostCenter,
-			AccountNumber:     l.AccountNumber,
-			IncomeAccount:     l.IncomeAccount,
-			Name:              l.Name,
-			PolicyName:        l.PolicyName,
-			ClaimPayoutOption: l.ClaimPayoutOption,
-			Amount:            l.Amount,
-			Date:              l.DateSubmitted,
-			Description:       l.getDescription(),
-		})
+		report.AppendToBatch("", l.transaction())
 	}
 
-	return report.RenderBatch()
+	content, contentType := report.RenderBatch()
+	return content, contentType, nil
 }
 
 type TransactionBlocks map[string]LedgerEntries // keyed by account
 
-// NewReport creates a new LedgerReport with the current LedgerEntries
-func (le *LedgerEntries) NewReport(ctx context.Context, reportFormat, reportType string, date time.Time) LedgerReport {
+// NewReport creates a new LedgerReport with the current LedgerEntries. The result of the balance validation is
+// recorded on the report.
+func (le *LedgerEntries) NewReport(ctx context.Context, reportFormat, reportType string, date time.Time) (LedgerReport, error) {
 	report := LedgerReport{
-		Date: date,
-		Type: reportType,
+		Date:   date,
+		Type:   reportType,
+		Format: reportFormat,
+	}
+
+	format, err := fin.GetReportFormat(reportFormat)
+	if err != nil {
+		return LedgerReport{}, api.NewAppError(err, api.ErrorInvalidReportFormat, api.CategoryUser)
 	}
 
-	content, contentType := le.ExportReport(reportFormat, reportType, report.Date)
-	ext := "csv"
-	if contentType == domain.ContentZip {
-		ext = "zip"
+	batch, validation, err := le.prepareReport(reportFormat, reportType, report.Date)
+	if err != nil {
+		return LedgerReport{}, err
+	}
+	content, contentType := batch.RenderBatch()
+
+	if !validation.IsBalanced() {
+		log.WithFields(map[string]any{
+			"format":     reportFormat,
+			"type":       reportType,
+			"date":       date,
+			"imbalances": validation.String(),
+		}).Warning("ledger report does not balance")
 	}
 
 	report.File = File{
 		Name: fmt.Sprintf("%s_%s_%s_%s.%s",
-			domain.Env.AppName, reportFormat, reportType, report.Date.Format(domain.DateFormat), ext),
+			domain.Env.AppName, reportFormat, reportType, report.Date.Format(domain.DateFormat), format.FileExtension),
 		Content:     content,
 		ContentType: contentType,
 		CreatedByID: CurrentUser(ctx).ID,
 	}
 	report.LedgerEntries = *le
+	report.IsBalanced = validation.IsBalanced()
+	report.Imbalance = validation.String()
 
-	return report
+	return report, nil
 }
 
-func (le *LedgerEntries) ExportReport(reportFormat, reportType string, date time.Time) ([]byte, string) {
-	report := le.prepareReport(reportFormat, reportType, date)
-	return report.RenderBatch()
+func (le *LedgerEntries) ExportReport(reportFormat, reportType string, date time.Time) ([]byte, string, error) {
+	report, _, err := le.prepareReport(reportFormat, reportType, date)
+	if err != nil {
+		return nil, "", err
+	}
+	content, contentType := report.RenderBatch()
+	return content, contentType, nil
 }
 
-func (le *LedgerEntries) prepareReport(reportFormat, reportType string, date time.Time) fin.Report {
-	report := fin.NewBatch(reportFormat, reportType, date)
+func (le *LedgerEntries) prepareReport(reportFormat, reportType string, date time.Time) (fin.Report, JournalValidation, error) {
+	format, err := fin.GetReportFormat(reportFormat)
+	if err != nil {
+		return nil, JournalValidation{}, api.NewAppError(err, api.ErrorInvalidReportFormat, api.CategoryUser)
+	}
+
+	report := format.NewBatch(reportType, date)
 	ref := ""
 
 	blocks := le.MakeBlocks()
+	entriesByBlock := map[string]LedgerEntries{}
 	for account, ledgerEntries := range blocks {
 		if len(ledgerEntries) == 0 {
 			continue
@@ -213,41 +293,23 @@ func (le *LedgerEntries) prepareReport(reportFormat, reportType string, date tim
 		blockName = strings.ReplaceAll(blockName, " ", "_")
 
 		for _, l := range ledgerEntries {
-			report.AppendToBatch(blockName, fin.Transaction{
-				EntityCode:        l.EntityCode,
-				RiskCategoryName:  l.RiskCategoryName,
-				RiskCategoryCC:    l.RiskCategoryCC,
-				Type:              string(l.Type),
-				PolicyType:        l.PolicyType,
-				HouseholdID:       l.HouseholdID,
-				CostCenter:        l.CostCenter,
-				AccountNumber:     l.AccountNumber,
-				IncomeAccount:     l.IncomeAccount,
-				Name:              l.Name,
-				PolicyName:        l.PolicyName,
-				ClaimPayoutOption: l.ClaimPayoutOption,
-				Amount:            l.Amount,
-				Date:              l.DateSubmitted,
-				Description:       l.getDescription(),
-			})
+			report.AppendToBatch(blockName, l.transaction())
 
 			balance -= int(l.Amount)
 		}
+		entriesByBlock[blockName] = append(entriesByBlock[blockName], ledgerEntries...)
 
-		// don't include totals for Netsuite report
-		if reportFormat == fin.ReportFormatNetSuite {
-			continue
-		}
-
+		// NetSuite doesn't write the balancing transaction, but takes the credit account from it
 		report.AppendToBatch(blockName, fin.Transaction{
 			Account:     account,
 			Amount:      api.Currency(balance),
 			Description: desc,
 			Reference:   &ref,
 			Date:        date,
+			IsBalance:   true,
 		})
 	}
-	return report
+	return report, validateJournal(report.RenderedBlocks(), entriesByBlock), nil
 }
 
 func (le *LedgerEntries) MakeBlocks() TransactionBlocks {
@@ -282,11 +344,22 @@ func (le *LedgerEntries) Reconcile(ctx context.Context) error {
 func (le *LedgerEntry) Reconcile(ctx context.Context, now time.Time) error {
 	tx := Tx(ctx)
 
-	le.DateEntered = nulls.NewTime(now)
+	date, note, err := openPeriodDate(tx, now, "reconciliation")
+	if err != nil {
+		return err
+	}
+
+	le.DateEntered = nulls.NewTime(date)
+	le.addPeriodNote(note)
 	if err := le.Update(tx); err != nil {
 		return err
 	}
 
+	// reversing a claim payout doesn't pay the claim
+	if le.ReversalOfID.Valid {
+		return nil
+	}
+
 	le.LoadClaim(tx)
 	if le.Claim != nil {
 		le.Claim.Status = api.ClaimStatusPaid
@@ -298,12 +371,165 @@ func (le *LedgerEntry) Reconcile(ctx context.Context, now time.Time) error {
 	return nil
 }
 
+// Reverse adds an entry that cancels this entry. The reversal is included in the next monthly ledger report.
+// An entry can only be reversed once and a reversal cannot itself be reversed.
+func (le *LedgerEntry) Reverse(ctx context.Context, reason string) (LedgerEntry, error) {
+	tx := Tx(ctx)
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		err := errors.New("a reason is required to reverse a ledger entry")
+		return LedgerEntry{}, api.NewAppError(err, api.ErrorLedgerEntryCorrection, api.CategoryUser)
+	}
+
+	if le.ReversalOfID.Valid {
+		err := fmt.Errorf("ledger entry %s is a reversal and cannot be reversed", le.ID)
+		return LedgerEntry{}, api.NewAppError(err, api.ErrorLedgerEntryNotReversible, api.CategoryUser)
+	}
+
+	reversed, err := tx.Where("reversal_of_id = ?", le.ID).Exists(&LedgerEntry{})
+	if err != nil {
+		return LedgerEntry{}, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	if reversed {
+		err := fmt.Errorf("ledger entry %s has already been reversed", le.ID)
+		return LedgerEntry{}, api.NewAppError(err, api.ErrorLedgerEntryNotReversible, api.CategoryUser)
+	}
+
+	reversal := le.newCorrection(reason, -le.Amount)
+	reversal.ReversalOfID = nulls.NewUUID(le.ID)
+	if err := reversal.Create(tx); err != nil {
+		return LedgerEntry{}, err
+	}
+
+	if err := le.createHistory(ctx, le.summary(), "reversed: "+reason); err != nil {
+		return LedgerEntry{}, err
+	}
+	return reversal, nil
+}
+
+// Correct reverses this entry and adds a replacement entry with the given amount. Both are included in the next
+// monthly ledger report.
+func (le *LedgerEntry) Correct(ctx context.Context, reason string, amount api.Currency) (LedgerEntry, LedgerEntry, error) {
+	if amount == 0 {
+		err := fmt.Errorf("a replacement for ledger entry %s must have a non-zero amount", le.ID)
+		return LedgerEntry{}, LedgerEntry{}, api.NewAppError(err, api.ErrorLedgerEntryCorrection, api.CategoryUser)
+	}
+
+	reversal, err := le.Reverse(ctx, reason)
+	if err != nil {
+		return LedgerEntry{}, LedgerEntry{}, err
+	}
+
+	replacement := le.newCorrection(reversal.CorrectionReason, amount)
+	replacement.ReplacementOfID = nulls.NewUUID(le.ID)
+	if err := replacement.Create(Tx(ctx)); err != nil {
+		return LedgerEntry{}, LedgerEntry{}, err
+	}
+
+	if err := le.createHistory(ctx, le.summary(), "replaced: "+replacement.summary()); err != nil {
+		return LedgerEntry{}, LedgerEntry{}, err
+	}
+	return reversal, replacement, nil
+}
+
+// newCorrection returns a copy of the entry, with the given amount, to be submitted now
+func (le *LedgerEntry) newCorrection(reason string, amount api.Currency) LedgerEntry {
+	return LedgerEntry{
+		PolicyID:          le.PolicyID,
+		ItemID:            le.ItemID,
+		ClaimID:           le.ClaimID,
+		EntityCode:        le.EntityCode,
+		RiskCategoryName:  le.RiskCategoryName,
+		RiskCategoryCC:    le.RiskCategoryCC,
+		Type:              le.Type,
+		PolicyType:        le.PolicyType,
+		HouseholdID:       le.HouseholdID,
+		CostCenter:        le.CostCenter,
+		AccountNumber:     le.AccountNumber,
+		IncomeAccount:     le.IncomeAccount,
+		Name:              le.Name,
+		PolicyName:        le.PolicyName,
+		ClaimPayoutOption: le.ClaimPayoutOption,
+		Amount:            amount,
+		DateSubmitted:     time.Now().UTC(),
+		CorrectionReason:  reason,
+	}
+}
+
+// createHistory records a correction of the entry in the policy history
+func (le *LedgerEntry) createHistory(ctx context.Context, oldValue, newValue string) error {
+	tx := Tx(ctx)
+
+	var policy Policy
+	if err := policy.FindByID(tx, le.PolicyID); err != nil {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	history := policy.NewHistory(ctx, api.HistoryActionUpdate, FieldUpdate{
+		FieldName: FieldPolicyLedgerCorrection,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+	})
+	history.ItemID = le.ItemID
+	return history.Create(tx)
+}
+
+func (le *LedgerEntry) summary() string {
+	return fmt.Sprintf("%s %s %s (%s)", le.ID, le.Type, le.Amount.String(), le.DateSubmitted.Format(domain.DateFormat))
+}
+
+// Unreconcile marks each LedgerEntry as not "entered" into the accounting system, and reverts the changes
+// made to the referenced objects by Reconcile.
+func (le *LedgerEntries) Unreconcile(ctx context.Context) error {
+	for i := range *le {
+		if err := (*le)[i].Unreconcile(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unreconcile marks the LedgerEntry as not "entered" into the accounting system. A paid claim is returned
+// to Approved.
+func (le *LedgerEntry) Unreconcile(ctx context.Context) error {
+	tx := Tx(ctx)
+
+	le.DateEntered = nulls.Time{}
+	if err := le.Update(tx); err != nil {
+		return err
+	}
+
+	if le.ReversalOfID.Valid {
+		return nil
+	}
+
+	le.LoadClaim(tx)
+	if le.Claim != nil && le.Claim.Status == api.ClaimStatusPaid {
+		return le.Claim.UpdateStatus(ctx, api.ClaimStatusApproved)
+	}
+	return nil
+}
+
+func (le *LedgerEntry) addPeriodNote(note string) {
+	if note == "" {
+		return
+	}
+	if le.PeriodNote != "" {
+		le.PeriodNote += "; "
+	}
+	le.PeriodNote += note
+}
+
 // getDescription returns text that is base on other fields of the LedgerEntry
 // For household-type entries this returns `<entry.Type.Description> / <Policy.Name>`.
 // For other entries this returns `<entry.Type.Description> / <Policy.Name> (<accountable person name>)`,
 // not including `<` and `>`
 func (le *LedgerEntry) getDescription() string {
 	description := le.Type.Description(le.ClaimPayoutOption, le.Amount)
+	if le.ReversalOfID.Valid {
+		description = "Reversal of " + le.Type.Description(le.ClaimPayoutOption, -le.Amount)
+	}
 
 	if le.PolicyName == "" {
 		return description + " " + le.RiskCategoryName
@@ -318,6 +544,27 @@ func (le *LedgerEntry) getDescription() string {
 	return fmt.Sprintf(`%s (%s) %s`, description, le.Name, le.RiskCategoryName)
 }
 
+// transaction converts the entry to a transaction for a financial report
+func (le *LedgerEntry) transaction() fin.Transaction {
+	return fin.Transaction{
+		EntityCode:        le.EntityCode,
+		RiskCategoryName:  le.RiskCategoryName,
+		RiskCategoryCC:    le.RiskCategoryCC,
+		Type:              string(le.Type),
+		PolicyType:        le.PolicyType,
+		HouseholdID:       le.HouseholdID,
+		CostCenter:        le.CostCenter,
+		AccountNumber:     le.AccountNumber,
+		IncomeAccount:     le.IncomeAccount,
+		Name:              le.Name,
+		PolicyName:        le.PolicyName,
+		ClaimPayoutOption: le.ClaimPayoutOption,
+		Amount:            le.Amount,
+		Date:              le.DateSubmitted,
+		Description:       le.getDescription(),
+	}
+}
+
 func (le *LedgerEntry) getItemName(tx *pop.Connection) string {
 	le.LoadItem(tx, false)
 	if le.Item != nil {
@@ -427,9 +674,9 @@ func (le *LedgerEntry) LoadItem(tx *pop.Connection, reload bool) {
 	}
 }
 
-// FindRenewals finds the coverage renewal ledger entries for the given year
+// FindRenewals finds the coverage renewal and no-claims discount ledger entries for the given year
 func (le *LedgerEntries) FindRenewals(tx *pop.Connection, year int) error {
-	if err := tx.Where("type = ?", LedgerEntryTypeCoverageRenewal).
+	if err := tx.Where("type IN (?, ?)", LedgerEntryTypeCoverageRenewal, LedgerEntryTypeNoClaimsDiscount).
 		Where("EXTRACT(YEAR FROM date_submitted) = ?", year).
 		Where("date_entered IS NULL").
 		All(le); err != nil {
@@ -466,6 +713,10 @@ func (le *LedgerEntry) ConvertToAPI(tx *pop.Connection) api.LedgerEntry {
 		Amount:           le.Amount,
 		DateSubmitted:    le.DateSubmitted,
 		DateEntered:      convertTimeToAPI(le.DateEntered),
+		ReversalOfID:     convertUUIDToAPI(le.ReversalOfID),
+		ReplacementOfID:  convertUUIDToAPI(le.ReplacementOfID),
+		CorrectionReason: le.CorrectionReason,
+		PeriodNote:       le.PeriodNote,
 		CreatedAt:        le.CreatedAt,
 		UpdatedAt:        le.UpdatedAt,
 	}
@@ -493,6 +744,8 @@ func adjustLedgerAmount(amount api.Currency, entryType LedgerEntryType) (api.Cur
 		}
 	case LedgerEntryTypeClaim, LedgerEntryTypeClaimAdjustment:
 		// no adjustments on claims
+	case LedgerEntryTypePolicyAdjustment:
+		// no adjustments on transfers between policies
 	default:
 		return adjusted, fmt.Errorf("invalid LedgerEntryType specified in adjustLedgerAmount: %s", entryType)
 	}
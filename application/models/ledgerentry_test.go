@@ -113,7 +113,8 @@ func (ms *ModelSuite) TestLedgerEntries_ExportForPolicy() {
 	}
 	for _, tt := range tests {
 		ms.T().Run(tt.name, func(t *testing.T) {
-			got, _ := tt.entries.ExportForPolicy()
+			got, _, err := tt.entries.ExportForPolicy("")
+			ms.NoError(err)
 			for _, w := range tt.want {
 				ms.Contains(string(got), w)
 			}
@@ -121,6 +122,23 @@ func (ms *ModelSuite) TestLedgerEntries_ExportForPolicy() {
 	}
 }
 
+func (ms *ModelSuite) TestLedgerEntries_ExportForPolicy_Format() {
+	entries := LedgerEntries{{
+		EntityCode:    "EntityCode",
+		Type:          LedgerEntryTypeNewCoverage,
+		Amount:        -100,
+		DateSubmitted: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+	}}
+
+	content, contentType, err := entries.ExportForPolicy(api.ExportFormatXLSX)
+	ms.NoError(err)
+	ms.Equal(domain.ContentXLSX, contentType)
+	ms.Equal("PK", string(content[:2]), "content should be an XLSX (zip) file")
+
+	_, _, err = entries.ExportForPolicy(api.ExportFormatPDF)
+	ms.EqualAppError(api.AppError{Key: api.ErrorInvalidExportFormat, Category: api.CategoryUser}, err)
+}
+
 func (ms *ModelSuite) TestLedgerEntries_Export() {
 	date := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
 
@@ -231,7 +249,8 @@ func (ms *ModelSuite) TestLedgerEntries_Export() {
 	}
 	for _, tt := range tests {
 		ms.T().Run(tt.name, func(t *testing.T) {
-			got, _ := tt.entries.ExportReport(fin.ReportFormatSage, "", tt.batchDate)
+			got, _, err := tt.entries.ExportReport(fin.ReportFormatSage, "", tt.batchDate)
+			ms.NoError(err)
 			for _, w := range tt.want {
 				ms.Contains(string(got), w, "line %q not found in report output", w)
 			}
@@ -623,6 +642,120 @@ func (ms *ModelSuite) TestLedgerEntries_Reconcile() {
 	}
 }
 
+func (ms *ModelSuite) TestLedgerEntry_Update() {
+	f := CreateLedgerFixtures(ms.DB, FixturesConfig{})
+	entry := f.LedgerEntries[0]
+	amount := entry.Amount
+
+	entry.Amount = amount - 100
+	entry.DateEntered = nulls.NewTime(time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC))
+	ms.NoError(entry.Update(ms.DB))
+
+	var after LedgerEntry
+	ms.NoError(ms.DB.Find(&after, entry.ID))
+	ms.True(after.DateEntered.Valid, "DateEntered was not saved")
+	ms.Equal(amount, after.Amount, "Amount should not be changed by Update")
+}
+
+func (ms *ModelSuite) TestLedgerEntry_Reverse() {
+	f := CreateLedgerFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 2})
+	ctx := CreateTestContext(CreateAdminUsers(ms.DB)[AppRoleSignator])
+
+	entered := f.LedgerEntries[1]
+	entered.DateEntered = nulls.NewTime(time.Now().UTC())
+	ms.NoError(entered.Update(ms.DB))
+
+	alreadyReversed := f.LedgerEntries[0]
+	reversal, err := alreadyReversed.Reverse(ctx, "first reversal")
+	ms.NoError(err)
+
+	tests := []struct {
+		name    string
+		entry   LedgerEntry
+		reason  string
+		wantErr *api.AppError
+	}{
+		{
+			name:    "no reason",
+			entry:   entered,
+			reason:  " ",
+			wantErr: &api.AppError{Key: api.ErrorLedgerEntryCorrection, Category: api.CategoryUser},
+		},
+		{
+			name:    "already reversed",
+			entry:   alreadyReversed,
+			reason:  "duplicate",
+			wantErr: &api.AppError{Key: api.ErrorLedgerEntryNotReversible, Category: api.CategoryUser},
+		},
+		{
+			name:    "a reversal",
+			entry:   reversal,
+			reason:  "duplicate",
+			wantErr: &api.AppError{Key: api.ErrorLedgerEntryNotReversible, Category: api.CategoryUser},
+		},
+		{
+			name:   "reconciled entry",
+			entry:  entered,
+			reason: "duplicate",
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			got, err := tt.entry.Reverse(ctx, tt.reason)
+			if tt.wantErr != nil {
+				ms.Error(err)
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+
+			ms.Equal(-tt.entry.Amount, got.Amount, "incorrect reversal amount")
+			ms.Equal(tt.entry.ID, got.ReversalOfID.UUID, "reversal is not linked to the original")
+			ms.Equal(tt.reason, got.CorrectionReason, "incorrect reason")
+			ms.False(got.DateEntered.Valid, "reversal should not be entered yet")
+
+			var original LedgerEntry
+			ms.NoError(ms.DB.Find(&original, tt.entry.ID))
+			ms.Equal(tt.entry.Amount, original.Amount, "original entry should not be changed")
+
+			var history PolicyHistory
+			ms.NoError(ms.DB.Where("policy_id = ? AND field_name = ?", tt.entry.PolicyID, FieldPolicyLedgerCorrection).
+				Order("created_at desc").First(&history))
+			ms.Equal("reversed: "+tt.reason, history.NewValue)
+		})
+	}
+}
+
+func (ms *ModelSuite) TestLedgerEntry_Correct() {
+	f := CreateLedgerFixtures(ms.DB, FixturesConfig{})
+	ctx := CreateTestContext(CreateAdminUsers(ms.DB)[AppRoleSignator])
+	entry := f.LedgerEntries[0]
+
+	_, _, err := entry.Correct(ctx, "wrong amount", 0)
+	ms.EqualAppError(api.AppError{Key: api.ErrorLedgerEntryCorrection, Category: api.CategoryUser}, err)
+
+	reversal, replacement, err := entry.Correct(ctx, "wrong amount", entry.Amount-500)
+	ms.NoError(err)
+
+	ms.Equal(-entry.Amount, reversal.Amount, "incorrect reversal amount")
+	ms.Equal(entry.ID, reversal.ReversalOfID.UUID, "reversal is not linked to the original")
+
+	ms.Equal(entry.Amount-500, replacement.Amount, "incorrect replacement amount")
+	ms.Equal(entry.ID, replacement.ReplacementOfID.UUID, "replacement is not linked to the original")
+	ms.False(replacement.ReversalOfID.Valid, "replacement should not be a reversal")
+	ms.Equal(entry.Type, replacement.Type)
+	ms.Equal(entry.ItemID, replacement.ItemID)
+	ms.Equal("wrong amount", replacement.CorrectionReason)
+
+	var entries LedgerEntries
+	ms.NoError(ms.DB.Where("item_id = ?", entry.ItemID).All(&entries))
+	var total api.Currency
+	for _, e := range entries {
+		total += e.Amount
+	}
+	ms.Equal(entry.Amount-500, total, "ledger total for the item is incorrect")
+}
+
 func (ms *ModelSuite) Test_AdjustLedgerAmount() {
 	tests := []struct {
 		name       string
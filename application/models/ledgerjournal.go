@@ -0,0 +1,283 @@
+package models
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/fin"
+)
+
+// Column names, in lower case, that are recognized in the header of a journal exported from the accounting system.
+// A journal has either an amount column, or debit and credit columns.
+var (
+	journalReferenceColumns   = []string{"reference", "transref", "ref"}
+	journalAmountColumns      = []string{"amount", "transamt"}
+	journalDebitColumns       = []string{"debit", "debit amount"}
+	journalCreditColumns      = []string{"credit", "credit amount"}
+	journalDescriptionColumns = []string{"description", "transdesc", "memo"}
+)
+
+// journalColumns are the positions of the recognized columns of a journal, or -1 if not present
+type journalColumns struct {
+	reference, amount, debit, credit, description int
+}
+
+// journalKey identifies the report entries that a journal line can match
+type journalKey struct {
+	reference string
+	amount    api.Currency
+}
+
+// ReconcileJournal matches the lines of a journal exported from the accounting system (CSV) to the entries of the
+// report by reference and amount, and marks only the matched entries reconciled. The references are those of the
+// report's own format. The lines that match no entry, or the reference but not the amount of an entry, are returned
+// for follow-up, along with the entries that were not found in the journal. A report that is void or does not
+// balance cannot be reconciled.
+func (lr *LedgerReport) ReconcileJournal(ctx context.Context, journal io.Reader) (api.LedgerJournalReconciliation, error) {
+	var result api.LedgerJournalReconciliation
+
+	if err := lr.checkReconcilable(); err != nil {
+		return result, err
+	}
+
+	format, err := fin.GetReportFormat(lr.Format)
+	if err != nil {
+		err = fmt.Errorf("ledger report %s has no journal format: %w", lr.ID, err)
+		return result, api.NewAppError(err, api.ErrorInvalidReportFormat, api.CategoryUser)
+	}
+
+	lines, linesRead, err := readJournal(journal)
+	if err != nil {
+		return result, api.NewAppError(err, api.ErrorLedgerJournalInvalid, api.CategoryUser)
+	}
+
+	tx := Tx(ctx)
+	lr.LoadLedgerEntries(tx, false)
+
+	result, matched := matchJournal(lines, lr.LedgerEntries, format)
+	result.LinesRead = linesRead
+	result.LinesIgnored = linesRead - len(lines)
+
+	now := time.Now().UTC()
+	var missing LedgerEntries
+	for i := range lr.LedgerEntries {
+		e := &lr.LedgerEntries[i]
+		if e.DateEntered.Valid {
+			continue
+		}
+		if !matched[i] {
+			if e.Amount != 0 {
+				missing = append(missing, *e)
+			}
+			continue
+		}
+		if err := e.Reconcile(ctx, now); err != nil {
+			return result, api.NewAppError(err, api.ErrorReconcileError, api.CategoryInternal)
+		}
+		result.Reconciled++
+	}
+	result.Missing = missing.ConvertToAPI(tx)
+
+	if result.Reconciled > 0 {
+		history := lr.NewHistory(ctx, api.HistoryActionUpdate, FieldUpdate{
+			FieldName: FieldLedgerReportDateEntered,
+			NewValue: fmt.Sprintf("%s (%d of %d entries matched in journal)",
+				now.Format(domain.DateFormat), result.Reconciled, len(lr.LedgerEntries)),
+		})
+		if err := history.Create(tx); err != nil {
+			return result, err
+		}
+	}
+
+	lr.LoadLedgerEntries(tx, true)
+	result.Report = lr.ConvertToAPI(tx)
+
+	return result, nil
+}
+
+// matchJournal matches each journal line to an unmatched entry with the same reference, as given by the format, and
+// amount. The positions of the matched entries are returned along with the result.
+func matchJournal(lines []api.JournalLine, entries LedgerEntries, format fin.Format,
+) (api.LedgerJournalReconciliation, map[int]bool) {
+	candidates := map[journalKey][]int{}
+	byReference := map[string][]int{}
+	for i, e := range entries {
+		if e.Amount == 0 {
+			continue // zero amounts are not exported
+		}
+		ref := format.Reference(e.transaction())
+		// journal amounts are debit-positive, like the exported reports
+		key := journalKey{reference: ref, amount: -e.Amount}
+		candidates[key] = append(candidates[key], i)
+		byReference[ref] = append(byReference[ref], i)
+	}
+
+	result := api.LedgerJournalReconciliation{
+		Unmatched:  []api.JournalLine{},
+		Mismatched: []api.JournalLine{},
+	}
+	matched := map[int]bool{}
+
+	for _, l := range lines {
+		if i, ok := firstUnmatched(candidates[journalKey{reference: l.Reference, amount: l.Amount}], matched); ok {
+			matched[i] = true
+			result.Matched++
+			continue
+		}
+
+		refEntries := byReference[l.Reference]
+		if len(refEntries) == 0 {
+			result.Unmatched = append(result.Unmatched, l)
+			continue
+		}
+
+		l.ExpectedAmounts = []api.Currency{}
+		for _, i := range refEntries {
+			if !matched[i] {
+				l.ExpectedAmounts = append(l.ExpectedAmounts, -entries[i].Amount)
+			}
+		}
+		result.Mismatched = append(result.Mismatched, l)
+	}
+	return result, matched
+}
+
+func firstUnmatched(indexes []int, matched map[int]bool) (int, bool) {
+	for _, i := range indexes {
+		if !matched[i] {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// readJournal reads the lines of a journal that have a reference and an amount. Rows before the header row are
+// skipped. The number of rows read after the header is also returned.
+func readJournal(file io.Reader) ([]api.JournalLine, int, error) {
+	r := csv.NewReader(file)
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	var cols *journalColumns
+	var lines []api.JournalLine
+	linesRead := 0
+	for n := 1; ; n++ {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read journal CSV file on row %d: %w", n, err)
+		}
+
+		if cols == nil {
+			cols = findJournalColumns(row)
+			continue
+		}
+		linesRead++
+
+		line, err := cols.line(row, n)
+		if err != nil {
+			return nil, 0, err
+		}
+		if line.Reference == "" || line.Amount == 0 {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if cols == nil {
+		return nil, 0, errors.New("journal CSV file has no header with a reference and an amount column")
+	}
+	return lines, linesRead, nil
+}
+
+// findJournalColumns returns the positions of the recognized columns if the row is the header of a journal, or nil
+func findJournalColumns(row []string) *journalColumns {
+	cols := journalColumns{reference: -1, amount: -1, debit: -1, credit: -1, description: -1}
+	for i, name := range row {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch {
+		case domain.IsStringInSlice(name, journalReferenceColumns):
+			cols.reference = i
+		case domain.IsStringInSlice(name, journalAmountColumns):
+			cols.amount = i
+		case domain.IsStringInSlice(name, journalDebitColumns):
+			cols.debit = i
+		case domain.IsStringInSlice(name, journalCreditColumns):
+			cols.credit = i
+		case domain.IsStringInSlice(name, journalDescriptionColumns):
+			cols.description = i
+		}
+	}
+
+	if cols.reference < 0 || (cols.amount < 0 && cols.debit < 0 && cols.credit < 0) {
+		return nil
+	}
+	return &cols
+}
+
+func (c journalColumns) line(row []string, n int) (api.JournalLine, error) {
+	field := func(i int) string {
+		if i < 0 || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	line := api.JournalLine{
+		Line:        n,
+		Reference:   field(c.reference),
+		Description: field(c.description),
+	}
+
+	if c.amount >= 0 {
+		amount, err := parseJournalAmount(field(c.amount))
+		if err != nil {
+			return line, fmt.Errorf("invalid amount on row %d of journal CSV file: %w", n, err)
+		}
+		line.Amount = amount
+		return line, nil
+	}
+
+	debit, err := parseJournalAmount(field(c.debit))
+	if err != nil {
+		return line, fmt.Errorf("invalid debit on row %d of journal CSV file: %w", n, err)
+	}
+	credit, err := parseJournalAmount(field(c.credit))
+	if err != nil {
+		return line, fmt.Errorf("invalid credit on row %d of journal CSV file: %w", n, err)
+	}
+	line.Amount = debit - credit
+	return line, nil
+}
+
+// parseJournalAmount converts an amount in dollars, e.g. "-1,234.50", "$12" or "(12.00)", to cents
+func parseJournalAmount(s string) (api.Currency, error) {
+	s = strings.TrimSpace(s)
+	negative := strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")")
+	s = strings.NewReplacer("(", "", ")", "", "$", "", ",", "").Replace(s)
+	if s == "" {
+		return 0, nil
+	}
+
+	dollars, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	amount := api.Currency(math.Round(dollars * domain.CurrencyFactor))
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
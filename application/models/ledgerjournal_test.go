@@ -0,0 +1,123 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/fin"
+)
+
+func (ms *ModelSuite) TestLedgerReport_ReconcileJournal() {
+	f := CreateLedgerFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 3})
+	ctx := CreateTestContext(CreateAdminUsers(ms.DB)[AppRoleSteward])
+
+	report, err := f.LedgerEntries.NewReport(ctx, fin.ReportFormatSage, ReportTypeMonthly, time.Now().UTC())
+	ms.NoError(err)
+	ms.NoError(report.Create(ms.DB))
+	ms.Equal(fin.ReportFormatSage, report.Format)
+
+	matched := f.LedgerEntries[0]
+	mismatched := f.LedgerEntries[1]
+	journal := strings.Join([]string{
+		`"Journal export"`,
+		`"Date","Reference","Description","Debit","Credit"`,
+		fmt.Sprintf(`2021-03-01,"%s","premium",%s,`, getReference(matched), (-matched.Amount).String()),
+		fmt.Sprintf(`2021-03-01,"%s","premium",%s,`, getReference(mismatched), (-mismatched.Amount + 1).String()),
+		`2021-03-01,"unknown","premium",1.00,`,
+		`2021-03-01,"","balance",,"1,000.00"`,
+	}, "\n")
+
+	_, err = report.ReconcileJournal(ctx, strings.NewReader(`"Date","Description"`))
+	ms.EqualAppError(api.AppError{Key: api.ErrorLedgerJournalInvalid, Category: api.CategoryUser}, err)
+
+	voided := report
+	voided.VoidedAt = nulls.NewTime(time.Now())
+	_, err = voided.ReconcileJournal(ctx, strings.NewReader(journal))
+	ms.EqualAppError(api.AppError{Key: api.ErrorLedgerReportVoided, Category: api.CategoryUser}, err)
+
+	unbalanced := report
+	unbalanced.IsBalanced = false
+	_, err = unbalanced.ReconcileJournal(ctx, strings.NewReader(journal))
+	ms.EqualAppError(api.AppError{Key: api.ErrorLedgerReportUnbalanced, Category: api.CategoryUser}, err)
+
+	unknownFormat := report
+	unknownFormat.Format = ""
+	_, err = unknownFormat.ReconcileJournal(ctx, strings.NewReader(journal))
+	ms.EqualAppError(api.AppError{Key: api.ErrorInvalidReportFormat, Category: api.CategoryUser}, err)
+
+	netSuite := strings.Join([]string{
+		`"Reference","Amount"`,
+		fmt.Sprintf(`"%s",%s`, matched.Name, (-matched.Amount).String()),
+	}, "\n")
+	got, err := report.ReconcileJournal(ctx, strings.NewReader(netSuite))
+	ms.NoError(err)
+	ms.Equal(0, got.Matched, "a reference of another format should not match")
+
+	got, err = report.ReconcileJournal(ctx, strings.NewReader(journal))
+	ms.NoError(err)
+
+	ms.Equal(4, got.LinesRead)
+	ms.Equal(1, got.LinesIgnored)
+	ms.Equal(1, got.Matched)
+	ms.Equal(1, got.Reconciled)
+
+	ms.Len(got.Unmatched, 1)
+	ms.Equal("unknown", got.Unmatched[0].Reference)
+	ms.Equal(5, got.Unmatched[0].Line)
+
+	ms.Len(got.Mismatched, 1)
+	ms.Equal(-mismatched.Amount+1, got.Mismatched[0].Amount)
+	ms.Contains(got.Mismatched[0].ExpectedAmounts, -mismatched.Amount)
+
+	ms.Len(got.Missing, 2)
+	for _, e := range got.Missing {
+		ms.NotEqual(matched.ID, e.ID)
+	}
+
+	for _, e := range f.LedgerEntries {
+		ms.NoError(ms.DB.Reload(&e))
+		ms.Equal(e.ID == matched.ID, e.DateEntered.Valid, "only the matched entry should be reconciled")
+	}
+
+	var histories LedgerReportHistories
+	ms.NoError(histories.AllForLedgerReport(ms.DB, report.ID))
+	ms.Len(histories, 1)
+	ms.Equal(FieldLedgerReportDateEntered, histories[0].FieldName)
+
+	again, err := report.ReconcileJournal(ctx, strings.NewReader(journal))
+	ms.NoError(err)
+	ms.Equal(1, again.Matched)
+	ms.Equal(0, again.Reconciled, "an entry should not be reconciled twice")
+}
+
+func (ms *ModelSuite) Test_parseJournalAmount() {
+	tests := []struct {
+		in      string
+		want    api.Currency
+		wantErr bool
+	}{
+		{in: "", want: 0},
+		{in: "12.34", want: 1234},
+		{in: "-12.34", want: -1234},
+		{in: "$1,234.50", want: 123450},
+		{in: "(12.00)", want: -1200},
+		{in: " 0.1 ", want: 10},
+		{in: "twelve", wantErr: true},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.in, func(t *testing.T) {
+			got, err := parseJournalAmount(tt.in)
+			if tt.wantErr {
+				ms.Error(err)
+				return
+			}
+			ms.NoError(err)
+			ms.Equal(tt.want, got)
+		})
+	}
+}
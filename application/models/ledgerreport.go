@@ -2,9 +2,11 @@ package models
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gobuffalo/nulls"
@@ -14,6 +16,7 @@ import (
 
 	"github.com/silinternational/cover-api/api"
 	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/fin"
 	"github.com/silinternational/cover-api/log"
 )
 
@@ -57,6 +60,20 @@ type LedgerReport struct {
 	CreatedAt time.Time  `db:"created_at"`
 	UpdatedAt time.Time  `db:"updated_at"`
 
+	// Format is the name of the fin report format of the file, or empty for a policy ledger report
+	Format string `db:"format"`
+
+	// IsBalanced is true if every block of the rendered report balances and can be posted, see JournalValidation
+	IsBalanced bool `db:"is_balanced"`
+
+	// Imbalance describes the blocks that do not balance and the entries that cause it
+	Imbalance string `db:"imbalance"`
+
+	// VoidedAt is set if the reconciliation of the report was undone. A voided report cannot be reconciled.
+	VoidedAt   nulls.Time `db:"voided_at"`
+	VoidedByID nulls.UUID `db:"voided_by_id"`
+	VoidReason string     `db:"void_reason"`
+
 	File          File          `belongs_to:"files" validate:"-"`
 	Policy        Policy        `belongs_to:"policies" validate:"-"`
 	LedgerEntries LedgerEntries `many_to_many:"ledger_report_entries" validate:"-"`
@@ -92,8 +109,17 @@ func (lr *LedgerReport) FindByID(tx *pop.Connection, id uuid.UUID) error {
 }
 
 // IsActorAllowedTo ensures the actor is either an admin or a member of
-// the LedgerReport's policy (assuming it has one)
+// the LedgerReport's policy (assuming it has one). Only signators may undo a reconciliation, and only admins may
+// reconcile a journal.
 func (lr *LedgerReport) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permission, sub SubResource, r *http.Request) bool {
+	if sub == api.ResourceUnreconcile {
+		return actor.AppRole == AppRoleSignator
+	}
+
+	if sub == api.ResourceJournal {
+		return actor.IsAdmin()
+	}
+
 	if actor.IsAdmin() {
 		return true
 	}
@@ -131,11 +157,43 @@ func (lr *LedgerReport) ConvertToAPI(tx *pop.Connection) api.LedgerReport {
 		Date:             lr.Date,
 		TransactionCount: transactionCount,
 		IsCleared:        isCleared,
+		IsBalanced:       lr.IsBalanced,
+		Imbalance:        lr.Imbalance,
+		VoidedAt:         convertTimeToAPI(lr.VoidedAt),
+		VoidedByID:       convertUUIDToAPI(lr.VoidedByID),
+		VoidReason:       lr.VoidReason,
 		CreatedAt:        lr.CreatedAt,
 		UpdatedAt:        lr.UpdatedAt,
 	}
 }
 
+// ConvertToPreview converts a LedgerReport that has not been created to api.LedgerReportPreview
+func (lr *LedgerReport) ConvertToPreview(reportFormat string) api.LedgerReportPreview {
+	transactionCount := 0
+	for _, e := range lr.LedgerEntries {
+		if e.Amount != 0 {
+			transactionCount++
+		}
+	}
+
+	content := string(lr.File.Content)
+	if lr.File.ContentType == domain.ContentXLSX {
+		content = base64.StdEncoding.EncodeToString(lr.File.Content)
+	}
+
+	return api.LedgerReportPreview{
+		Type:             lr.Type,
+		Format:           reportFormat,
+		Date:             lr.Date,
+		FileName:         lr.File.Name,
+		ContentType:      lr.File.ContentType,
+		Content:          content,
+		TransactionCount: transactionCount,
+		IsBalanced:       lr.IsBalanced,
+		Imbalance:        lr.Imbalance,
+	}
+}
+
 // LoadFile hydrates the File property if necessary or if `reload` is true. The file URL is refreshed
 // in any case.
 func (lr *LedgerReport) LoadFile(tx *pop.Connection, reload bool) {
@@ -183,6 +241,10 @@ func NewLedgerReport(ctx context.Context, reportFormat, reportType string, date
 		return report, api.NewAppError(err, api.ErrorInvalidDate, api.CategoryUser)
 	}
 
+	if _, err := fin.GetReportFormat(reportFormat); err != nil {
+		return report, api.NewAppError(err, api.ErrorInvalidReportFormat, api.CategoryUser)
+	}
+
 	var le LedgerEntries
 	switch reportType {
 	case ReportTypeMonthly:
@@ -206,13 +268,14 @@ func NewLedgerReport(ctx context.Context, reportFormat, reportType string, date
 		return LedgerReport{}, api.NewAppError(err, api.ErrorNoLedgerEntries, api.CategoryNotFound)
 	}
 
-	return le.NewReport(ctx, reportFormat, reportType, report.Date), nil
+	return le.NewReport(ctx, reportFormat, reportType, report.Date)
 }
 
 // NewPolicyLedgerReport creates a new report for one policy by querying the database according
 // to the requested report type and the month and year of the request.
 // If no ledger entries are found, it returns an empty LedgerReport.
-func NewPolicyLedgerReport(ctx context.Context, policy Policy, reportType string, month, year int) (LedgerReport, error) {
+func NewPolicyLedgerReport(ctx context.Context, policy Policy, reportType string, month, year int, exportFormat string,
+) (LedgerReport, error) {
 	tx := Tx(ctx)
 
 	report := LedgerReport{Type: reportType, PolicyID: nulls.NewUUID(policy.ID)}
@@ -242,10 +305,16 @@ func NewPolicyLedgerReport(ctx context.Context, policy Policy, reportType string
 		return LedgerReport{}, nil
 	}
 
-	content, contentType := le.ExportForPolicy()
+	content, contentType, err := le.ExportForPolicy(exportFormat)
+	if err != nil {
+		return LedgerReport{}, err
+	}
 	ext := "csv"
-	if contentType == domain.ContentZip {
+	switch contentType {
+	case domain.ContentZip:
 		ext = "zip"
+	case domain.ContentXLSX:
+		ext = api.ExportFormatXLSX
 	}
 
 	report.File = File{
@@ -256,6 +325,7 @@ func NewPolicyLedgerReport(ctx context.Context, policy Policy, reportType string
 		CreatedByID: CurrentUser(ctx).ID,
 	}
 	report.LedgerEntries = le
+	report.IsBalanced = true
 
 	return report, nil
 }
@@ -367,7 +437,7 @@ func PolicyLedgerTable(c context.Context, policy Policy, month, year int) (api.L
 		case LedgerEntryTypeCoverageRefund:
 			statusBefore = string(api.ItemCoverageStatusApproved)
 			statusAfter = string(api.ItemCoverageStatusInactive)
-		case LedgerEntryTypeCoverageChange, LedgerEntryTypeCoverageRenewal:
+		case LedgerEntryTypeCoverageChange, LedgerEntryTypeCoverageRenewal, LedgerEntryTypeNoClaimsDiscount:
 			statusBefore = string(api.ItemCoverageStatusApproved)
 			statusAfter = string(api.ItemCoverageStatusApproved)
 		}
@@ -392,13 +462,115 @@ func PolicyLedgerTable(c context.Context, policy Policy, month, year int) (api.L
 	return lTable, nil
 }
 
+// checkReconcilable returns an error if the report is void or does not balance
+func (lr *LedgerReport) checkReconcilable() error {
+	if lr.VoidedAt.Valid {
+		err := fmt.Errorf("ledger report %s is void", lr.ID)
+		return api.NewAppError(err, api.ErrorLedgerReportVoided, api.CategoryUser)
+	}
+
+	if !lr.IsBalanced {
+		err := fmt.Errorf("ledger report %s does not balance: %s", lr.ID, lr.Imbalance)
+		return api.NewAppError(err, api.ErrorLedgerReportUnbalanced, api.CategoryUser)
+	}
+	return nil
+}
+
+// Reconcile marks the entries of the report as entered into the accounting system. A report that does not
+// balance cannot be reconciled.
 func (lr *LedgerReport) Reconcile(ctx context.Context) error {
+	if err := lr.checkReconcilable(); err != nil {
+		return err
+	}
+
 	tx := Tx(ctx)
 	lr.LoadLedgerEntries(tx, false)
 	if err := lr.LedgerEntries.Reconcile(ctx); err != nil {
 		return api.NewAppError(err, api.ErrorReconcileError, api.CategoryInternal)
 	}
 	lr.LoadLedgerEntries(tx, true)
+
+	history := lr.NewHistory(ctx, api.HistoryActionUpdate, FieldUpdate{
+		FieldName: FieldLedgerReportDateEntered,
+		NewValue:  time.Now().UTC().Format(domain.DateFormat),
+	})
+	return history.Create(tx)
+}
+
+// Unreconcile undoes the reconciliation of the report. The entries of the report are no longer marked as entered,
+// so they are included in the next monthly report, and the report is voided. This is only possible while the
+// fiscal period in which the report was reconciled is still open.
+func (lr *LedgerReport) Unreconcile(ctx context.Context, reason string) error {
+	tx := Tx(ctx)
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		err := errors.New("a reason is required to undo a ledger report reconciliation")
+		return api.NewAppError(err, api.ErrorLedgerReportUnreconcile, api.CategoryUser)
+	}
+
+	if lr.VoidedAt.Valid {
+		err := fmt.Errorf("ledger report %s is already void", lr.ID)
+		return api.NewAppError(err, api.ErrorLedgerReportVoided, api.CategoryUser)
+	}
+
+	if lr.PolicyID.Valid {
+		err := fmt.Errorf("ledger report %s is a policy report and cannot be unreconciled", lr.ID)
+		return api.NewAppError(err, api.ErrorLedgerReportUnreconcile, api.CategoryUser)
+	}
+
+	lr.LoadLedgerEntries(tx, true)
+	if err := lr.checkUnreconcile(tx, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	if err := lr.LedgerEntries.Unreconcile(ctx); err != nil {
+		return err
+	}
+	lr.LoadLedgerEntries(tx, true)
+
+	lr.VoidedAt = nulls.NewTime(time.Now().UTC())
+	lr.VoidedByID = nulls.NewUUID(CurrentUser(ctx).ID)
+	lr.VoidReason = reason
+	if err := tx.UpdateColumns(lr, "voided_at", "voided_by_id", "void_reason", "updated_at"); err != nil {
+		return appErrorFromDB(err, api.ErrorUpdateFailure)
+	}
+
+	history := lr.NewHistory(ctx, api.HistoryActionUpdate, FieldUpdate{
+		FieldName: FieldLedgerReportVoided,
+		NewValue:  reason,
+	})
+	return history.Create(tx)
+}
+
+// checkUnreconcile ensures that every entry of the report was reconciled in the current fiscal period, and that
+// the period is open
+func (lr *LedgerReport) checkUnreconcile(tx *pop.Connection, now time.Time) error {
+	if len(lr.LedgerEntries) == 0 {
+		err := fmt.Errorf("ledger report %s has no entries", lr.ID)
+		return api.NewAppError(err, api.ErrorLedgerReportUnreconcile, api.CategoryUser)
+	}
+
+	var current FiscalPeriod
+	if err := current.FindByDate(tx, now); err != nil {
+		return err
+	}
+	if current.IsClosed() {
+		err := fmt.Errorf("fiscal period %d-%02d is closed", current.Year, current.Period)
+		return api.NewAppError(err, api.ErrorLedgerReportPeriodClosed, api.CategoryUser)
+	}
+
+	year, period := current.Year, current.Period
+	for _, e := range lr.LedgerEntries {
+		if !e.DateEntered.Valid {
+			err := fmt.Errorf("ledger report %s has not been reconciled", lr.ID)
+			return api.NewAppError(err, api.ErrorLedgerReportUnreconcile, api.CategoryUser)
+		}
+		if y, p := fin.FiscalPeriod(e.DateEntered.Time); y != year || p != period {
+			err := fmt.Errorf("ledger report %s was reconciled in fiscal period %d-%02d, which is closed", lr.ID, y, p)
+			return api.NewAppError(err, api.ErrorLedgerReportPeriodClosed, api.CategoryUser)
+		}
+	}
 	return nil
 }
 
@@ -101,12 +101,14 @@ func (ms *ModelSuite) TestLedgerReport_ConvertToAPI() {
 	updatedAt := time.Now()
 	createdAt := updatedAt.Add(-1 * time.Hour)
 	c := &LedgerReport{
-		ID:        id,
-		FileID:    fileID,
-		Type:      ReportTypeMonthly,
-		Date:      date,
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
+		ID:         id,
+		FileID:     fileID,
+		Type:       ReportTypeMonthly,
+		Date:       date,
+		IsBalanced: false,
+		Imbalance:  "imbalance",
+		CreatedAt:  createdAt,
+		UpdatedAt:  updatedAt,
 	}
 
 	got := c.ConvertToAPI(ms.DB)
@@ -115,6 +117,8 @@ func (ms *ModelSuite) TestLedgerReport_ConvertToAPI() {
 	ms.Equal(fileID, got.File.ID, "File ID is incorrect")
 	ms.Equal(c.Type, got.Type, "Type is incorrect")
 	ms.Equal(c.Date, got.Date, "Date is incorrect")
+	ms.Equal(c.IsBalanced, got.IsBalanced, "IsBalanced is incorrect")
+	ms.Equal(c.Imbalance, got.Imbalance, "Imbalance is incorrect")
 	ms.Equal(createdAt, got.CreatedAt, "CreatedAt is incorrect")
 	ms.Equal(updatedAt, got.UpdatedAt, "UpdatedAt is incorrect")
 
@@ -122,6 +126,134 @@ func (ms *ModelSuite) TestLedgerReport_ConvertToAPI() {
 	ms.WithinDuration(updatedAt.Add(time.Minute*10), got.File.URLExpiration, time.Minute*2)
 }
 
+func (ms *ModelSuite) TestLedgerReport_Reconcile() {
+	f := CreateLedgerFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 2})
+	ctx := CreateTestContext(CreateAdminUsers(ms.DB)[AppRoleSteward])
+
+	report, err := f.LedgerEntries.NewReport(ctx, fin.ReportFormatSage, ReportTypeMonthly, time.Now().UTC())
+	ms.NoError(err)
+	ms.True(report.IsBalanced, "fixture entries should balance: %s", report.Imbalance)
+
+	unbalanced := report
+	unbalanced.IsBalanced = false
+	unbalanced.Imbalance = "some imbalance"
+	ms.EqualAppError(api.AppError{Key: api.ErrorLedgerReportUnbalanced, Category: api.CategoryUser},
+		unbalanced.Reconcile(ctx))
+	for _, e := range f.LedgerEntries {
+		ms.NoError(ms.DB.Reload(&e))
+		ms.False(e.DateEntered.Valid, "entry should not be reconciled")
+	}
+
+	ms.NoError(report.Create(ms.DB))
+	ms.NoError(report.Reconcile(ctx))
+	for _, e := range f.LedgerEntries {
+		ms.NoError(ms.DB.Reload(&e))
+		ms.True(e.DateEntered.Valid, "entry should be reconciled")
+	}
+
+	var histories LedgerReportHistories
+	ms.NoError(histories.AllForLedgerReport(ms.DB, report.ID))
+	ms.Len(histories, 1)
+	ms.Equal(FieldLedgerReportDateEntered, histories[0].FieldName)
+}
+
+func (ms *ModelSuite) TestLedgerReport_Unreconcile() {
+	f := CreateLedgerFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 3, ClaimsPerPolicy: 1, ClaimItemsPerClaim: 1})
+	signator := CreateAdminUsers(ms.DB)[AppRoleSignator]
+	ctx := CreateTestContext(signator)
+
+	claim := UpdateClaimStatus(ms.DB, f.Claims[0], api.ClaimStatusReview3, "")
+	ms.NoError(claim.Approve(ctx))
+	var claimEntry LedgerEntry
+	ms.NoError(ms.DB.Where("claim_id = ?", claim.ID).First(&claimEntry))
+
+	newReport := func(entries LedgerEntries) LedgerReport {
+		report, err := entries.NewReport(ctx, fin.ReportFormatSage, ReportTypeMonthly, time.Now().UTC())
+		ms.NoError(err)
+		ms.NoError(report.Create(ms.DB))
+		return report
+	}
+
+	notReconciled := newReport(LedgerEntries{f.LedgerEntries[0]})
+
+	closedPeriod := newReport(LedgerEntries{f.LedgerEntries[1]})
+	ms.NoError(closedPeriod.Reconcile(ctx))
+	closedPeriod.LedgerEntries[0].DateEntered = nulls.NewTime(time.Now().UTC().AddDate(-1, 0, 0))
+	ms.NoError(closedPeriod.LedgerEntries[0].Update(ms.DB))
+
+	report := newReport(LedgerEntries{f.LedgerEntries[2], claimEntry})
+	ms.NoError(report.Reconcile(ctx))
+
+	tests := []struct {
+		name    string
+		report  LedgerReport
+		reason  string
+		wantErr *api.AppError
+	}{
+		{
+			name:    "no reason",
+			report:  report,
+			wantErr: &api.AppError{Key: api.ErrorLedgerReportUnreconcile, Category: api.CategoryUser},
+		},
+		{
+			name:    "not reconciled",
+			report:  notReconciled,
+			reason:  "import failed",
+			wantErr: &api.AppError{Key: api.ErrorLedgerReportUnreconcile, Category: api.CategoryUser},
+		},
+		{
+			name:    "closed fiscal period",
+			report:  closedPeriod,
+			reason:  "import failed",
+			wantErr: &api.AppError{Key: api.ErrorLedgerReportPeriodClosed, Category: api.CategoryUser},
+		},
+		{
+			name:   "good",
+			report: report,
+			reason: "import failed",
+		},
+		{
+			name:    "already void",
+			report:  report,
+			reason:  "import failed",
+			wantErr: &api.AppError{Key: api.ErrorLedgerReportVoided, Category: api.CategoryUser},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			Must(ms.DB.Reload(&tt.report))
+			err := tt.report.Unreconcile(ctx, tt.reason)
+			if tt.wantErr != nil {
+				ms.Error(err)
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+
+			ms.True(tt.report.VoidedAt.Valid, "report should be void")
+			ms.Equal(signator.ID, tt.report.VoidedByID.UUID)
+			ms.Equal(tt.reason, tt.report.VoidReason)
+
+			for _, e := range tt.report.LedgerEntries {
+				ms.False(e.DateEntered.Valid, "entry should not be reconciled")
+			}
+
+			var c Claim
+			ms.NoError(ms.DB.Find(&c, claim.ID))
+			ms.Equal(api.ClaimStatusApproved, c.Status, "claim should no longer be paid")
+
+			var histories LedgerReportHistories
+			ms.NoError(histories.AllForLedgerReport(ms.DB, tt.report.ID))
+			ms.Len(histories, 2)
+			ms.Equal(FieldLedgerReportVoided, histories[1].FieldName)
+			ms.Equal(tt.reason, histories[1].NewValue)
+
+			ms.EqualAppError(api.AppError{Key: api.ErrorLedgerReportVoided, Category: api.CategoryUser},
+				tt.report.Reconcile(ctx))
+		})
+	}
+}
+
 func (ms *ModelSuite) TestNewLedgerReport() {
 	f := CreateLedgerFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 2})
 	user := f.Users[0]
@@ -170,6 +302,13 @@ func (ms *ModelSuite) TestNewLedgerReport() {
 			reportFormat: fin.ReportFormatSage,
 			wantErr:      &api.AppError{Key: api.ErrorInvalidDate, Category: api.CategoryUser},
 		},
+		{
+			name:         "invalid report format",
+			date:         may,
+			reportType:   ReportTypeMonthly,
+			reportFormat: "invalid",
+			wantErr:      &api.AppError{Key: api.ErrorInvalidReportFormat, Category: api.CategoryUser},
+		},
 		{
 			name:         "one entry, sage",
 			date:         may,
@@ -196,6 +335,28 @@ func (ms *ModelSuite) TestNewLedgerReport() {
 			},
 			wantContentType: domain.ContentCSV,
 		},
+		{
+			name:         "one entry, quickbooks",
+			date:         may,
+			reportType:   ReportTypeMonthly,
+			reportFormat: fin.ReportFormatQuickBooks,
+			want: LedgerReport{
+				Type: ReportTypeMonthly,
+				Date: may,
+			},
+			wantContentType: domain.ContentIIF,
+		},
+		{
+			name:         "one entry, json",
+			date:         may,
+			reportType:   ReportTypeMonthly,
+			reportFormat: fin.ReportFormatJSON,
+			want: LedgerReport{
+				Type: ReportTypeMonthly,
+				Date: may,
+			},
+			wantContentType: domain.ContentJson,
+		},
 	}
 	for _, tt := range tests {
 		ms.T().Run(tt.name, func(t *testing.T) {
@@ -368,7 +529,7 @@ func (ms *ModelSuite) TestNewPolicyLedgerReport() {
 	}
 	for _, tt := range tests {
 		ms.T().Run(tt.name, func(t *testing.T) {
-			got, err := NewPolicyLedgerReport(ctx, policy, tt.reportType, tt.month, tt.year)
+			got, err := NewPolicyLedgerReport(ctx, policy, tt.reportType, tt.month, tt.year, "")
 			if tt.wantErr != nil {
 				ms.Error(err, "test should have produced an error")
 				ms.EqualAppError(*tt.wantErr, err)
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+type LedgerReportHistories []LedgerReportHistory
+
+type LedgerReportHistory struct {
+	ID             uuid.UUID `db:"id"`
+	LedgerReportID uuid.UUID `db:"ledger_report_id"`
+	UserID         uuid.UUID `db:"user_id"`
+	Action         string    `db:"action"`
+	FieldName      string    `db:"field_name"`
+	OldValue       string    `db:"old_value"`
+	NewValue       string    `db:"new_value"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (h *LedgerReportHistory) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validateModel(h), nil
+}
+
+func (h *LedgerReportHistory) Create(tx *pop.Connection) error {
+	return create(tx, h)
+}
+
+// AllForLedgerReport loads the history of a ledger report, oldest first
+func (h *LedgerReportHistories) AllForLedgerReport(tx *pop.Connection, reportID uuid.UUID) error {
+	err := tx.Where("ledger_report_id = ?", reportID).Order("created_at asc").All(h)
+	return appErrorFromDB(err, api.ErrorQueryFailure)
+}
+
+func (lr *LedgerReport) NewHistory(ctx context.Context, action string, fieldUpdate FieldUpdate) LedgerReportHistory {
+	return LedgerReportHistory{
+		Action:         action,
+		LedgerReportID: lr.ID,
+		UserID:         CurrentUser(ctx).ID,
+		FieldName:      fieldUpdate.FieldName,
+		OldValue:       fmt.Sprintf("%s", fieldUpdate.OldValue),
+		NewValue:       fmt.Sprintf("%s", fieldUpdate.NewValue),
+	}
+}
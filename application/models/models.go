@@ -4,7 +4,6 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -55,12 +54,15 @@ const (
 	ClaimStatusChangeApproved        = "Approved by "
 	ClaimStatusChangeDenied          = "Denied by "
 
-	ItemStatusChangeSubmitted    = "Submitted for approval"
-	ItemStatusChangeAutoApproved = "Auto approved"
-	ItemStatusChangeApproved     = "Approved by "
-	ItemStatusChangeRevisions    = "Revisions requested by "
-	ItemStatusChangeDenied       = "Denied by "
-	ItemStatusChangeInactivated  = "Deactivated by "
+	ItemStatusChangeSubmitted       = "Submitted for approval"
+	ItemStatusChangeBudgetSubmitted = "Submitted for budget approval"
+	ItemStatusChangeBudgetApproved  = "Budget approved by "
+	ItemStatusChangeBudgetRejected  = "Budget rejected by "
+	ItemStatusChangeAutoApproved    = "Auto approved"
+	ItemStatusChangeApproved        = "Approved by "
+	ItemStatusChangeRevisions       = "Revisions requested by "
+	ItemStatusChangeDenied          = "Denied by "
+	ItemStatusChangeInactivated     = "Deactivated by "
 
 	FieldClaimPolicyID            = "PolicyID"
 	FieldClaimReferenceNumber     = "ReferenceNumber"
@@ -106,6 +108,23 @@ const (
 	FieldItemCoverageStartDate = "CoverageStartDate"
 	FieldItemPaidThroughDate   = "PaidThroughDate"
 	FieldItemStatusReason      = "CoverageStatusReason"
+	FieldItemPolicyID          = "PolicyID"
+
+	FieldPolicyMaxCoverage      = "MaxCoverage"
+	FieldPolicyMembers          = "Members"
+	FieldPolicyDependents       = "Dependents"
+	FieldPolicyMergedPolicyID   = "MergedPolicyID"
+	FieldPolicySplitPolicyID    = "SplitPolicyID"
+	FieldPolicyMemberRole       = "MemberRole"
+	FieldPolicyInvites          = "Invites"
+	FieldPolicyInviteSent       = "InviteSent"
+	FieldPolicyInviteExpires    = "InviteExpiresAt"
+	FieldPolicyApprovers        = "Approvers"
+	FieldPolicyLedgerAdjustment = "LedgerAdjustment"
+	FieldPolicyLedgerCorrection = "LedgerCorrection"
+
+	FieldLedgerReportDateEntered = "DateEntered"
+	FieldLedgerReportVoided      = "Voided"
 )
 
 var uuidNamespace = uuid.FromStringOrNil(uuidNamespaceString)
@@ -418,39 +437,19 @@ func GetV5UUID(seed string) uuid.UUID {
 	return uuid.NewV5(uuidNamespace, seed)
 }
 
+// GetHHID returns the household ID of the given staff member, or an empty string if it is not found or the
+// lookup service is not configured
 func GetHHID(staffID string) string {
 	if domain.Env.HouseholdIDLookupURL == "" {
 		return ""
 	}
 
-	req, err := http.NewRequest(http.MethodGet, domain.Env.HouseholdIDLookupURL+staffID, nil)
+	householdID, err := HouseholdIDService.LookupHouseholdID(staffID)
 	if err != nil {
 		log.Error("HHID API error,", err)
 		return ""
 	}
-	req.SetBasicAuth(domain.Env.HouseholdIDLookupUsername, domain.Env.HouseholdIDLookupPassword)
-
-	client := &http.Client{Timeout: time.Second * 30}
-	response, err := client.Do(req)
-	if err != nil {
-		log.Error("HHID API error,", err)
-		return ""
-	}
-	defer func() {
-		if err := response.Body.Close(); err != nil {
-			panic(err.Error())
-		}
-	}()
-
-	dec := json.NewDecoder(response.Body)
-	var v struct {
-		ID string `json:"householdIdOut"`
-	}
-	if err = dec.Decode(&v); err != nil {
-		log.Error("HHID API error decoding response,", err)
-		return ""
-	}
-	return v.ID
+	return householdID
 }
 
 func NullsIntToPointer(i nulls.Int) *int {
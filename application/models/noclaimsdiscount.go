@@ -0,0 +1,75 @@
+package models
+
+import (
+	"math"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+// ClaimFreeYears returns the number of full years, as of the given date, since the policy was created or
+// since the incident date of its most recent approved claim, whichever is later
+func (p *Policy) ClaimFreeYears(tx *pop.Connection, date time.Time) (int, error) {
+	start := p.CreatedAt
+
+	var claim Claim
+	err := tx.Where("policy_id = ? AND incident_date <= ?", p.ID, date).
+		Where("status IN (?, ?)", api.ClaimStatusApproved, api.ClaimStatusPaid).
+		Order("incident_date desc").
+		First(&claim)
+	if domain.IsOtherThanNoRows(err) {
+		return 0, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	if err == nil && claim.IncidentDate.After(start) {
+		start = claim.IncidentDate
+	}
+
+	years := date.Year() - start.Year()
+	if start.AddDate(years, 0, 0).After(date) {
+		years--
+	}
+	if years < 0 {
+		return 0, nil
+	}
+	return years, nil
+}
+
+// NoClaimsDiscountRate returns the fraction of the premium to be discounted for a renewal on the given date.
+// It is zero until the policy has been claim-free for NoClaimsDiscountYears.
+func (p *Policy) NoClaimsDiscountRate(tx *pop.Connection, date time.Time) (float64, error) {
+	years, err := p.ClaimFreeYears(tx, date)
+	if err != nil {
+		return 0, err
+	}
+	return noClaimsDiscountRate(years), nil
+}
+
+func noClaimsDiscountRate(claimFreeYears int) float64 {
+	if claimFreeYears < domain.Env.NoClaimsDiscountYears {
+		return 0
+	}
+
+	extraYears := float64(claimFreeYears - domain.Env.NoClaimsDiscountYears)
+	rate := domain.Env.NoClaimsDiscountRate + domain.Env.NoClaimsDiscountIncrease*extraYears
+	return math.Min(rate, domain.Env.NoClaimsDiscountMaximum)
+}
+
+// renewalDiscountRate returns the no-claims discount rate given on the item's premium in the annual renewal for the
+// year of the given date. Items whose coverage started after the renewal were charged without a discount.
+func (i *Item) renewalDiscountRate(tx *pop.Connection, date time.Time) (float64, error) {
+	periodStart, _ := renewalPeriod(date, domain.BillingPeriodAnnual)
+	if !i.CoverageStartDate.Before(periodStart) {
+		return 0, nil
+	}
+
+	i.LoadPolicy(tx, false)
+	return i.Policy.NoClaimsDiscountRate(tx, periodStart)
+}
+
+// calculateNoClaimsDiscount returns the discount on the given premium, rounded to the nearest cent
+func calculateNoClaimsDiscount(premium api.Currency, rate float64) api.Currency {
+	return api.Currency(math.Round(float64(premium) * rate))
+}
@@ -0,0 +1,134 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+func (ms *ModelSuite) TestPolicy_ClaimFreeYears() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{
+		NumberOfPolicies:   3,
+		ItemsPerPolicy:     1,
+		ClaimsPerPolicy:    1,
+		ClaimItemsPerClaim: 1,
+	})
+	now := time.Now().UTC()
+
+	for _, p := range f.Policies {
+		Must(ms.DB.RawQuery("UPDATE policies SET created_at = ? WHERE id = ?", now.AddDate(-5, 0, -1), p.ID).Exec())
+	}
+
+	approved := UpdateClaimStatus(ms.DB, f.Claims[1], api.ClaimStatusApproved, "")
+	Must(ms.DB.RawQuery("UPDATE claims SET incident_date = ? WHERE id = ?", now.AddDate(-2, 0, -1), approved.ID).Exec())
+
+	denied := UpdateClaimStatus(ms.DB, f.Claims[2], api.ClaimStatusDenied, "")
+	Must(ms.DB.RawQuery("UPDATE claims SET incident_date = ? WHERE id = ?", now.AddDate(-1, 0, 0), denied.ID).Exec())
+
+	tests := []struct {
+		name   string
+		policy Policy
+		want   int
+	}{
+		{
+			name:   "draft claim",
+			policy: f.Policies[0],
+			want:   5,
+		},
+		{
+			name:   "approved claim",
+			policy: f.Policies[1],
+			want:   2,
+		},
+		{
+			name:   "denied claim",
+			policy: f.Policies[2],
+			want:   5,
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			var policy Policy
+			ms.NoError(policy.FindByID(ms.DB, tt.policy.ID))
+
+			got, err := policy.ClaimFreeYears(ms.DB, now)
+			ms.NoError(err)
+			ms.Equal(tt.want, got)
+		})
+	}
+}
+
+func (ms *ModelSuite) Test_noClaimsDiscountRate() {
+	minYears := domain.Env.NoClaimsDiscountYears
+
+	tests := []struct {
+		name  string
+		years int
+		want  float64
+	}{
+		{
+			name:  "not eligible",
+			years: minYears - 1,
+			want:  0,
+		},
+		{
+			name:  "eligible",
+			years: minYears,
+			want:  domain.Env.NoClaimsDiscountRate,
+		},
+		{
+			name:  "one extra year",
+			years: minYears + 1,
+			want:  domain.Env.NoClaimsDiscountRate + domain.Env.NoClaimsDiscountIncrease,
+		},
+		{
+			name:  "maximum",
+			years: minYears + 100,
+			want:  domain.Env.NoClaimsDiscountMaximum,
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			ms.InDelta(tt.want, noClaimsDiscountRate(tt.years), 0.000001)
+		})
+	}
+}
+
+func (ms *ModelSuite) TestPolicy_ProcessRenewals_NoClaimsDiscount() {
+	now := time.Now().UTC()
+	year := now.Year()
+
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 2})
+	policy := f.Policies[0]
+	Must(ms.DB.RawQuery("UPDATE policies SET created_at = ? WHERE id = ?",
+		time.Date(year-domain.Env.NoClaimsDiscountYears-1, 1, 1, 0, 0, 0, 0, time.UTC), policy.ID).Exec())
+	ms.NoError(policy.FindByID(ms.DB, policy.ID))
+
+	for i := range f.Items {
+		f.Items[i].PaidThroughDate = domain.EndOfYear(year - 1)
+		f.Items[i].CoverageAmount = 1000
+		UpdateItemStatus(ms.DB, f.Items[i], api.ItemCoverageStatusApproved, "")
+	}
+
+	ms.NoError(policy.ProcessRenewals(ms.DB, now, domain.BillingPeriodAnnual))
+
+	var renewal, discount LedgerEntry
+	ms.NoError(ms.DB.Where("policy_id = ? AND type = ?", policy.ID, LedgerEntryTypeCoverageRenewal).First(&renewal))
+	ms.NoError(ms.DB.Where("policy_id = ? AND type = ?", policy.ID, LedgerEntryTypeNoClaimsDiscount).First(&discount))
+
+	rate := noClaimsDiscountRate(domain.Env.NoClaimsDiscountYears)
+	ms.Equal(calculateNoClaimsDiscount(-renewal.Amount, rate), discount.Amount, "incorrect discount amount")
+	ms.Greater(int(discount.Amount), 0, "discount should be a credit")
+
+	var renewals LedgerEntries
+	ms.NoError(renewals.FindRenewals(ms.DB, year))
+	found := false
+	for _, r := range renewals {
+		if r.ID == discount.ID {
+			found = true
+		}
+	}
+	ms.True(found, "discount should be included in the annual renewal report")
+}
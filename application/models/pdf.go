@@ -0,0 +1,136 @@
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+
+	"github.com/silinternational/cover-api/domain"
+)
+
+const (
+	pdfFont       = "Helvetica"
+	pdfLineHeight = 6.0
+	pdfLabelWidth = 50.0
+)
+
+// pdfDocument wraps fpdf.Fpdf with the page layout shared by all PDF documents produced by the API
+type pdfDocument struct {
+	*fpdf.Fpdf
+	tr func(string) string
+}
+
+// newPDFDocument starts a new portrait A4 document with a title header and page-numbered footer
+func newPDFDocument(title string) *pdfDocument {
+	f := fpdf.New("P", "mm", "A4", "")
+	p := &pdfDocument{
+		Fpdf: f,
+		tr:   f.UnicodeTranslatorFromDescriptor(""),
+	}
+
+	f.SetTitle(title, true)
+	f.SetAuthor(domain.Env.AppName, true)
+	f.AliasNbPages("")
+	f.SetFooterFunc(func() {
+		f.SetY(-15)
+		f.SetFont(pdfFont, "I", 8)
+		f.CellFormat(0, 10, fmt.Sprintf("%s - page %d of {nb}", p.tr(domain.Env.AppName), f.PageNo()),
+			"", 0, "C", false, 0, "")
+	})
+
+	f.AddPage()
+	f.SetFont(pdfFont, "B", 18)
+	f.CellFormat(0, 12, p.tr(title), "", 1, "C", false, 0, "")
+	f.Ln(4)
+
+	return p
+}
+
+// heading writes a bold section heading
+func (p *pdfDocument) heading(text string) {
+	p.Ln(2)
+	p.SetFont(pdfFont, "B", 13)
+	p.CellFormat(0, pdfLineHeight+2, p.tr(text), "B", 1, "L", false, 0, "")
+	p.Ln(2)
+}
+
+// paragraph writes a block of wrapped text
+func (p *pdfDocument) paragraph(text string) {
+	p.SetFont(pdfFont, "", 10)
+	p.MultiCell(0, pdfLineHeight-1, p.tr(text), "", "L", false)
+	p.Ln(2)
+}
+
+// labeledValue writes a single "label: value" line
+func (p *pdfDocument) labeledValue(label, value string) {
+	p.SetFont(pdfFont, "B", 10)
+	p.CellFormat(pdfLabelWidth, pdfLineHeight, p.tr(label), "", 0, "L", false, 0, "")
+	p.SetFont(pdfFont, "", 10)
+	p.MultiCell(0, pdfLineHeight, p.tr(value), "", "L", false)
+}
+
+// table writes a simple table with a shaded header row. Column widths are in millimeters.
+func (p *pdfDocument) table(headers []string, widths []float64, rows [][]string) {
+	writeHeader := func() {
+		p.SetFont(pdfFont, "B", 9)
+		p.SetFillColor(220, 220, 220)
+		for i, h := range headers {
+			p.CellFormat(widths[i], pdfLineHeight, p.tr(h), "1", 0, "C", true, 0, "")
+		}
+		p.Ln(-1)
+	}
+
+	writeHeader()
+	p.SetFont(pdfFont, "", 9)
+	_, pageHeight := p.GetPageSize()
+	_, _, _, bottomMargin := p.GetMargins()
+	for _, row := range rows {
+		if p.GetY()+pdfLineHeight > pageHeight-bottomMargin-10 {
+			p.AddPage()
+			writeHeader()
+			p.SetFont(pdfFont, "", 9)
+		}
+		for i, cell := range row {
+			align := "L"
+			if len(cell) > 0 && (cell[0] == '$' || cell[0] == '-') {
+				align = "R"
+			}
+			p.CellFormat(widths[i], pdfLineHeight, p.tr(truncateForPDF(p, cell, widths[i])), "1", 0, align, false, 0, "")
+		}
+		p.Ln(-1)
+	}
+	p.Ln(2)
+}
+
+// render returns the document content
+func (p *pdfDocument) render() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := p.Output(&buf); err != nil {
+		return nil, fmt.Errorf("error rendering PDF: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// truncateForPDF shortens text that does not fit in a table cell of the given width
+func truncateForPDF(p *pdfDocument, text string, width float64) string {
+	const ellipsis = "..."
+	maxWidth := width - 2*p.GetCellMargin()
+	if p.GetStringWidth(text) <= maxWidth {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && p.GetStringWidth(string(runes)+ellipsis) > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + ellipsis
+}
+
+// pdfDate formats a date in the style used on printed documents
+func pdfDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format(domain.LocalizedDate)
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gobuffalo/events"
 	"github.com/gobuffalo/nulls"
 	"github.com/gobuffalo/pop/v6"
 	"github.com/gobuffalo/validate/v3"
@@ -41,8 +42,14 @@ type Policy struct {
 	Notes         string         `db:"notes"`
 	LegacyID      nulls.Int      `db:"legacy_id"`
 	Email         string         `db:"email"`
-	CreatedAt     time.Time      `db:"created_at"`
-	UpdatedAt     time.Time      `db:"updated_at"`
+	ClosedDate    nulls.Time     `db:"closed_date"`
+	ClosedReason  string         `db:"closed_reason"`
+
+	// HouseholdIDInvalidDate is the date the household ID was found not to match any member's staff record
+	HouseholdIDInvalidDate nulls.Time `db:"household_id_invalid_date"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
 
 	Claims     Claims            `has_many:"claims" validate:"-" order_by:"incident_date desc"`
 	Dependents PolicyDependents  `has_many:"policy_dependents" validate:"-" order_by:"name"`
@@ -85,6 +92,11 @@ func (p *Policy) Update(ctx context.Context) error {
 		return appErrorFromDB(err, api.ErrorQueryFailure)
 	}
 
+	// a corrected household ID is checked again by the next household ID validation
+	if p.HouseholdID != oldPolicy.HouseholdID {
+		p.HouseholdIDInvalidDate = nulls.Time{}
+	}
+
 	updates := p.Compare(oldPolicy)
 	for i := range updates {
 		history := p.NewHistory(ctx, api.HistoryActionUpdate, updates[i])
@@ -96,6 +108,77 @@ func (p *Policy) Update(ctx context.Context) error {
 	return update(tx, p)
 }
 
+// Close schedules the inactivation of all the policy's items as of the given date, creates cancellation
+// credits for any annual premiums already paid, and marks the policy as closed. Claims that are still in
+// process must be resolved first. No items or claims may be added to a closed policy.
+func (p *Policy) Close(ctx context.Context, date time.Time, reason string) error {
+	tx := Tx(ctx)
+
+	if p.IsClosed() {
+		err := errors.New("policy is already closed")
+		return api.NewAppError(err, api.ErrorPolicyClosed, api.CategoryUser)
+	}
+
+	today := domain.BeginningOfDay(time.Now().UTC())
+	date = domain.BeginningOfDay(date)
+	if date.Before(today) || date.Year() != today.Year() {
+		err := fmt.Errorf("policy closure date %s must be between today and the end of the year",
+			date.Format(domain.DateFormat))
+		return api.NewAppError(err, api.ErrorInvalidDate, api.CategoryUser)
+	}
+
+	if n := p.countOpenClaims(tx); n > 0 {
+		err := fmt.Errorf("policy has %d claims that must be resolved before it can be closed", n)
+		return api.NewAppError(err, api.ErrorPolicyHasOpenClaims, api.CategoryUser)
+	}
+
+	p.LoadItems(tx, true)
+	for i := range p.Items {
+		item := p.Items[i]
+		if err := item.SafeDeleteOrInactivate(ctx, date); err != nil {
+			return err
+		}
+		if err := item.CreateCancellationCredit(tx, date); err != nil {
+			return err
+		}
+	}
+
+	p.ClosedDate = nulls.NewTime(date)
+	p.ClosedReason = reason
+	if err := p.Update(ctx); err != nil {
+		return err
+	}
+
+	e := events.Event{
+		Kind:    domain.EventApiPolicyClosed,
+		Message: fmt.Sprintf("Policy Closed: %s  ID: %s", p.Name, p.ID.String()),
+		Payload: events.Payload{domain.EventPayloadID: p.ID},
+	}
+	emitEvent(e)
+
+	return nil
+}
+
+// IsClosed returns true if the policy has been closed
+func (p *Policy) IsClosed() bool {
+	return p.ClosedDate.Valid
+}
+
+// countOpenClaims returns the number of the policy's claims that have been submitted but not yet resolved
+func (p *Policy) countOpenClaims(tx *pop.Connection) int {
+	resolvedStatuses := []api.ClaimStatus{
+		api.ClaimStatusDraft,
+		api.ClaimStatusPaid,
+		api.ClaimStatusDenied,
+	}
+
+	n, err := tx.Where("policy_id = ?", p.ID).Where("status NOT IN (?)", resolvedStatuses).Count(&Claims{})
+	if err != nil {
+		panic("database error counting open claims for policy: " + err.Error())
+	}
+	return n
+}
+
 // CreateTeam creates a new Team type policy for the user.
 // The EntityCodeID, CostCenter and Account must have non-blank values
 func (p *Policy) CreateTeam(ctx context.Context) error {
@@ -146,8 +229,14 @@ func (p *Policy) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permissio
 		return true
 	}
 
-	if sub == api.ResourceStrikes {
+	switch sub {
+	case api.ResourceStrikes, api.ResourceMerge, api.ResourceSplit:
 		return false
+	case api.ResourceApprovers:
+		// members can see who approves new coverage, but only an admin may change it
+		if perm != PermissionView {
+			return false
+		}
 	}
 
 	switch perm {
@@ -159,7 +248,30 @@ func (p *Policy) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permissio
 		}
 	}
 
-	return p.isMember(tx, actor.ID)
+	role, isMember := p.memberRole(tx, actor.ID)
+	if !isMember {
+		return false
+	}
+	return isPolicyActionAllowed(role, perm, sub)
+}
+
+// isPolicyActionAllowed returns true if a member with the given role may perform the action on the policy.
+// Viewers may only view the policy. Members may view the policy's members, but only owners may update or close the
+// policy or manage its members.
+func isPolicyActionAllowed(role api.PolicyUserRole, perm Permission, sub SubResource) bool {
+	switch role {
+	case api.PolicyUserRoleOwner:
+		return true
+	case api.PolicyUserRoleMember:
+		if perm == PermissionView || perm == PermissionList {
+			return true
+		}
+		if sub == api.ResourceMembers || sub == api.ResourceClose {
+			return false
+		}
+		return !(perm == PermissionUpdate && sub == "")
+	}
+	return perm == PermissionView || perm == PermissionList
 }
 
 func (p *Policy) isMember(tx *pop.Connection, id uuid.UUID) bool {
@@ -273,16 +385,38 @@ func (p *Policy) LoadMembers(tx *pop.Connection, reload bool) {
 // GetPolicyUserIDs loads the members of the Policy and also returns a list of the corresponding
 // PolicyUser IDs
 func (p *Policy) GetPolicyUserIDs(tx *pop.Connection, reload bool) []uuid.UUID {
+	polUsers := p.GetPolicyUsers(tx, reload)
+	puIDS := make([]uuid.UUID, len(polUsers))
+	for i, pu := range polUsers {
+		puIDS[i] = pu.ID
+	}
+	return puIDS
+}
+
+// GetPolicyUsers loads the members of the Policy and also returns a list of the corresponding
+// PolicyUsers, in the same order
+func (p *Policy) GetPolicyUsers(tx *pop.Connection, reload bool) PolicyUsers {
 	p.LoadMembers(tx, reload)
-	puIDS := make([]uuid.UUID, len(p.Members))
+	polUsers := make(PolicyUsers, len(p.Members))
 	for i, m := range p.Members {
-		var polUser PolicyUser
-		if err := tx.Where("policy_id = ? AND user_id = ?", p.ID, m.ID).First(&polUser); err != nil {
+		if err := tx.Where("policy_id = ? AND user_id = ?", p.ID, m.ID).First(&polUsers[i]); err != nil {
 			panic("database error finding policy user for policy, " + err.Error())
 		}
-		puIDS[i] = polUser.ID
 	}
-	return puIDS
+	return polUsers
+}
+
+// memberRole returns the role of the user on the policy, and false if the user is not a member
+func (p *Policy) memberRole(tx *pop.Connection, userID uuid.UUID) (api.PolicyUserRole, bool) {
+	var polUser PolicyUser
+	err := tx.Where("policy_id = ? AND user_id = ?", p.ID, userID).First(&polUser)
+	if domain.IsOtherThanNoRows(err) {
+		log.Errorf("failed to find policy user for policy %s: %s", p.ID, err)
+	}
+	if err != nil {
+		return "", false
+	}
+	return polUser.Role, true
 }
 
 // LoadEntityCode - a simple wrapper method for loading the entity code on the struct
@@ -294,9 +428,34 @@ func (p *Policy) LoadEntityCode(tx *pop.Connection, reload bool) {
 	}
 }
 
+// DeductibleRate returns the deductible rate of the policy as of the given time, increased by the strikes in force
+func (p *Policy) DeductibleRate(tx *pop.Connection, cutOff time.Time) float64 {
+	var strikes Strikes
+	err := strikes.RecentForPolicy(tx, p.ID, cutOff)
+
+	if domain.IsOtherThanNoRows(err) {
+		log.Errorf("error retrieving recent strikes for policy %s: %s", p.ID.String(), err)
+		return domain.Env.DeductibleRate
+	}
+
+	extra := domain.Env.DeductibleIncrease * float64(len(strikes))
+
+	d := domain.Env.DeductibleRate + extra
+	if d >= domain.Env.DeductibleMaximum {
+		return domain.Env.DeductibleMaximum
+	}
+	return d
+}
+
 func (p *Policy) ConvertToAPI(tx *pop.Connection, hydrate bool) api.Policy {
 	p.LoadEntityCode(tx, true)
-	polUserIDs := p.GetPolicyUserIDs(tx, true)
+	polUsers := p.GetPolicyUsers(tx, true)
+
+	var closedDate *string
+	if p.ClosedDate.Valid {
+		s := p.ClosedDate.Time.Format(domain.DateFormat)
+		closedDate = &s
+	}
 
 	apiPolicy := api.Policy{
 		ID:            p.ID,
@@ -307,11 +466,35 @@ func (p *Policy) ConvertToAPI(tx *pop.Connection, hydrate bool) api.Policy {
 		Account:       p.Account,
 		AccountDetail: p.AccountDetail,
 		EntityCode:    p.EntityCode.ConvertToAPI(tx, false),
-		Members:       p.Members.ConvertToPolicyMembers(polUserIDs),
+		ClosedDate:    closedDate,
+		ClosedReason:  p.ClosedReason,
+		Members:       p.Members.ConvertToPolicyMembers(polUsers),
 		CreatedAt:     p.CreatedAt,
 		UpdatedAt:     p.UpdatedAt,
 	}
 
+	if p.HouseholdIDInvalidDate.Valid {
+		s := p.HouseholdIDInvalidDate.Time.Format(domain.DateFormat)
+		apiPolicy.HouseholdIDInvalidDate = &s
+	}
+
+	if p.Type == api.PolicyTypeHousehold {
+		maxCoverage := api.Currency(p.GetMaxCoverage(tx, time.Now().UTC()))
+		remaining := maxCoverage - api.Currency(p.itemCoverageTotals(tx)[p.ID])
+		if remaining < 0 {
+			remaining = 0
+		}
+		apiPolicy.MaxCoverage = &maxCoverage
+		apiPolicy.RemainingCoverage = &remaining
+	}
+
+	claimFreeYears, err := p.ClaimFreeYears(tx, time.Now().UTC())
+	if err != nil {
+		log.Errorf("error calculating claim-free years for policy %s: %s", p.ID.String(), err)
+	}
+	apiPolicy.ClaimFreeYears = claimFreeYears
+	apiPolicy.NoClaimsDiscount = noClaimsDiscountRate(claimFreeYears)
+
 	if hydrate {
 		p.hydrateApiPolicy(tx, &apiPolicy)
 	}
@@ -399,15 +582,16 @@ func scopeSearchPolicies(searchText string) pop.ScopeFunc {
 	}
 }
 
+// scopeFilterPoliciesByActive filters on whether a policy has any approved items and has not been closed
 func scopeFilterPoliciesByActive(active string) pop.ScopeFunc {
 	return func(q *pop.Query) *pop.Query {
 		if active == "true" {
-			return q.Where("policies.id IN (SELECT policies.id FROM policies,items " +
+			return q.Where("policies.closed_date IS NULL AND policies.id IN (SELECT policies.id FROM policies,items " +
 				"WHERE policies.id=items.policy_id AND items.coverage_status='Approved')")
 		}
 		if active == "false" {
-			return q.Where("policies.id NOT IN (SELECT policies.id FROM policies,items " +
-				"WHERE policies.id=items.policy_id AND items.coverage_status='Approved')")
+			return q.Where("(policies.closed_date IS NOT NULL OR policies.id NOT IN (SELECT policies.id FROM policies,items " +
+				"WHERE policies.id=items.policy_id AND items.coverage_status='Approved'))")
 		}
 		return q
 	}
@@ -456,6 +640,11 @@ func (p *Policy) AddClaim(ctx context.Context, input api.ClaimCreateInput) (Clai
 		return Claim{}, errors.New("policy is nil in AddClaim")
 	}
 
+	if p.IsClosed() {
+		err := errors.New("claims cannot be added to a closed policy")
+		return Claim{}, api.NewAppError(err, api.ErrorPolicyClosed, api.CategoryUser)
+	}
+
 	claim := ConvertClaimCreateInput(input)
 	claim.PolicyID = p.ID
 
@@ -526,6 +715,22 @@ func (p *Policy) Compare(old Policy) []FieldUpdate {
 		})
 	}
 
+	if p.ClosedDate != old.ClosedDate {
+		updates = append(updates, FieldUpdate{
+			OldValue:  NullsTimeToString(old.ClosedDate),
+			NewValue:  NullsTimeToString(p.ClosedDate),
+			FieldName: "ClosedDate",
+		})
+	}
+
+	if p.ClosedReason != old.ClosedReason {
+		updates = append(updates, FieldUpdate{
+			OldValue:  old.ClosedReason,
+			NewValue:  p.ClosedReason,
+			FieldName: "ClosedReason",
+		})
+	}
+
 	return updates
 }
 
@@ -562,6 +767,11 @@ func (p *Policy) currentCoverageTotal(tx *pop.Connection) api.Currency {
 }
 
 func (p *Policy) NewHouseholdInvite(tx *pop.Connection, invite api.PolicyUserInviteCreate, cUser User) error {
+	role, err := inviteRole(invite)
+	if err != nil {
+		return err
+	}
+
 	var user User
 	if err := user.FindByEmail(tx, invite.Email); domain.IsOtherThanNoRows(err) {
 		return err
@@ -569,7 +779,7 @@ func (p *Policy) NewHouseholdInvite(tx *pop.Connection, invite api.PolicyUserInv
 
 	// if user doesn't yet exist, create an invite
 	if user.ID == uuid.Nil {
-		return p.createInvite(tx, invite, cUser)
+		return p.createInvite(tx, invite, role, cUser)
 	}
 
 	// if user already exists, make sure they don't already have a household policy
@@ -584,6 +794,7 @@ func (p *Policy) NewHouseholdInvite(tx *pop.Connection, invite api.PolicyUserInv
 	pUser := PolicyUser{
 		PolicyID: p.ID,
 		UserID:   user.ID,
+		Role:     role,
 	}
 
 	if err := pUser.Create(tx); err != nil {
@@ -593,6 +804,11 @@ func (p *Policy) NewHouseholdInvite(tx *pop.Connection, invite api.PolicyUserInv
 }
 
 func (p *Policy) NewTeamInvite(tx *pop.Connection, invite api.PolicyUserInviteCreate, cUser User) error {
+	role, err := inviteRole(invite)
+	if err != nil {
+		return err
+	}
+
 	var user User
 	if err := user.FindByEmail(tx, invite.Email); domain.IsOtherThanNoRows(err) {
 		return err
@@ -600,13 +816,14 @@ func (p *Policy) NewTeamInvite(tx *pop.Connection, invite api.PolicyUserInviteCr
 
 	// if user doesn't yet exist, create an invite
 	if user.ID == uuid.Nil {
-		return p.createInvite(tx, invite, cUser)
+		return p.createInvite(tx, invite, role, cUser)
 	}
 
 	// if user already exists, just associate them with the Policy
 	pUser := PolicyUser{
 		PolicyID: p.ID,
 		UserID:   user.ID,
+		Role:     role,
 	}
 
 	if err := pUser.Create(tx); err != nil {
@@ -615,7 +832,9 @@ func (p *Policy) NewTeamInvite(tx *pop.Connection, invite api.PolicyUserInviteCr
 	return nil
 }
 
-func (p *Policy) createInvite(tx *pop.Connection, invite api.PolicyUserInviteCreate, cUser User) error {
+func (p *Policy) createInvite(tx *pop.Connection, invite api.PolicyUserInviteCreate, role api.PolicyUserRole,
+	cUser User,
+) error {
 	// create invite
 	puInvite := PolicyUserInvite{
 		PolicyID:       p.ID,
@@ -624,6 +843,7 @@ func (p *Policy) createInvite(tx *pop.Connection, invite api.PolicyUserInviteCre
 		InviterName:    cUser.Name(),
 		InviterEmail:   cUser.Email,
 		InviterMessage: invite.InviterMessage,
+		Role:           role,
 	}
 
 	if err := puInvite.Create(tx); err != nil {
@@ -646,6 +866,85 @@ func (p *Policies) ProcessRenewals(tx *pop.Connection, date time.Time, billingPe
 // ProcessRenewals creates coverage renewal ledger entries for all items covered for the given period.
 // Does not create new records for items already processed.
 func (p *Policy) ProcessRenewals(tx *pop.Connection, date time.Time, billingPeriod int) error {
+	items, err := p.itemsToRenew(tx, date, billingPeriod)
+	if err != nil {
+		return err
+	}
+
+	periodStart, paidThroughDate := renewalPeriod(date, billingPeriod)
+
+	charges, err := p.renewalCharges(tx, items, periodStart)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range charges {
+		for i := range c.Items {
+			if err := c.Items[i].SetPaidThroughDate(tx, paidThroughDate); err != nil {
+				return err
+			}
+		}
+
+		err := p.CreateRenewalLedgerEntry(tx, c.RiskCategoryID, c.Premium)
+		if err != nil {
+			return api.NewAppError(err, api.ErrorCreateRenewalEntry, api.CategoryInternal)
+		}
+
+		if c.Discount <= 0 {
+			continue
+		}
+		if err = p.createPremiumLedgerEntry(tx, c.RiskCategoryID, LedgerEntryTypeNoClaimsDiscount, c.Discount); err != nil {
+			return api.NewAppError(err, api.ErrorCreateRenewalEntry, api.CategoryInternal)
+		}
+	}
+	return nil
+}
+
+// renewalCharge holds the items of one risk category that are due for renewal, with their premiums and the
+// no-claims discount on the total
+type renewalCharge struct {
+	RiskCategoryID uuid.UUID
+	Items          Items
+
+	// premium of each item, in the same order as Items
+	ItemPremiums []api.Currency
+
+	Premium  api.Currency
+	Discount api.Currency
+}
+
+// renewalCharges groups the items to be renewed by risk category, in the order in which each risk category first
+// appears, and calculates the premium and no-claims discount of each group
+func (p *Policy) renewalCharges(tx *pop.Connection, items Items, periodStart time.Time) ([]*renewalCharge, error) {
+	discountRate, err := p.NoClaimsDiscountRate(tx, periodStart)
+	if err != nil {
+		return nil, err
+	}
+
+	var charges []*renewalCharge
+	byRiskCategory := map[uuid.UUID]*renewalCharge{}
+	for i := range items {
+		c, ok := byRiskCategory[items[i].RiskCategoryID]
+		if !ok {
+			c = &renewalCharge{RiskCategoryID: items[i].RiskCategoryID}
+			byRiskCategory[items[i].RiskCategoryID] = c
+			charges = append(charges, c)
+		}
+		premium := items[i].CalculateBillingPremium(tx)
+		c.Items = append(c.Items, items[i])
+		c.ItemPremiums = append(c.ItemPremiums, premium)
+		c.Premium += premium
+	}
+
+	for _, c := range charges {
+		c.Discount = calculateNoClaimsDiscount(c.Premium, discountRate)
+	}
+	return charges, nil
+}
+
+// itemsToRenew returns the approved items of the policy with the given billing period that are not paid through
+// the given date
+func (p *Policy) itemsToRenew(tx *pop.Connection, date time.Time, billingPeriod int) (Items, error) {
 	var items Items
 	if err := tx.Where("coverage_status = ?", api.ItemCoverageStatusApproved).
 		Where("paid_through_date < ?", date).
@@ -653,36 +952,31 @@ func (p *Policy) ProcessRenewals(tx *pop.Connection, date time.Time, billingPeri
 		Join("item_categories ic", "items.category_id = ic.id").
 		Where("ic.billing_period = ?", billingPeriod).
 		All(&items); err != nil {
-		return appErrorFromDB(err, api.ErrorQueryFailure)
+		return nil, appErrorFromDB(err, api.ErrorQueryFailure)
 	}
+	return items, nil
+}
 
-	paidThroughDate := date
+// renewalPeriod returns the first day of the billing period that includes the given date and the date that renewed
+// items are paid through
+func renewalPeriod(date time.Time, billingPeriod int) (periodStart, paidThroughDate time.Time) {
 	switch billingPeriod {
 	case domain.BillingPeriodAnnual:
-		paidThroughDate = domain.EndOfYear(date.Year())
+		return time.Date(date.Year(), 1, 1, 0, 0, 0, 0, time.UTC), domain.EndOfYear(date.Year())
 	case domain.BillingPeriodMonthly:
-		paidThroughDate = domain.EndOfMonth(date)
-	}
-
-	totalPremiumGroupedByRiskCategory := map[uuid.UUID]api.Currency{}
-	for i := range items {
-		if err := items[i].SetPaidThroughDate(tx, paidThroughDate); err != nil {
-			return err
-		}
-		totalPremiumGroupedByRiskCategory[items[i].RiskCategoryID] += items[i].CalculateBillingPremium(tx)
+		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC), domain.EndOfMonth(date)
 	}
-
-	for riskCategoryID, amount := range totalPremiumGroupedByRiskCategory {
-		err := p.CreateRenewalLedgerEntry(tx, riskCategoryID, amount)
-		if err != nil {
-			return api.NewAppError(err, api.ErrorCreateRenewalEntry, api.CategoryInternal)
-		}
-	}
-	return nil
+	return date, date
 }
 
 // CreateRenewalLedgerEntry creates a new ledger entry for coverage renewal
 func (p *Policy) CreateRenewalLedgerEntry(tx *pop.Connection, riskCategoryID uuid.UUID, amount api.Currency) error {
+	return p.createPremiumLedgerEntry(tx, riskCategoryID, LedgerEntryTypeCoverageRenewal, -amount)
+}
+
+// createPremiumLedgerEntry creates a new policy-level ledger entry for a risk category. Charges are negative
+// and credits are positive.
+func (p *Policy) createPremiumLedgerEntry(tx *pop.Connection, riskCategoryID uuid.UUID, entryType LedgerEntryType, amount api.Currency) error {
 	p.LoadEntityCode(tx, false)
 
 	var rc RiskCategory
@@ -694,8 +988,8 @@ func (p *Policy) CreateRenewalLedgerEntry(tx *pop.Connection, riskCategoryID uui
 	dateSubmitted := time.Now().UTC().Add(-24 * time.Hour).Truncate(24 * time.Hour)
 
 	le := NewLedgerEntry("", *p, nil, nil, dateSubmitted)
-	le.Type = LedgerEntryTypeCoverageRenewal
-	le.Amount = -amount
+	le.Type = entryType
+	le.Amount = amount
 	le.EntityCode = p.EntityCode.Code
 	le.RiskCategoryName = rc.Name
 	le.RiskCategoryCC = rc.CostCenter
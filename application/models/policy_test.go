@@ -665,6 +665,9 @@ func (ms *ModelSuite) TestPolicies_Query() {
 	f.Policies[3].Members[0].FirstName = "John"
 	ms.NoError(ms.DB.Update(&f.Policies[3].Members[0]))
 
+	f.Policies[3].ClosedDate = nulls.NewTime(time.Now().UTC())
+	ms.NoError(ms.DB.Update(&f.Policies[3]))
+
 	// create a policy with no users
 	f2 := CreatePolicyFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 1})
 	f2.Policies[0].Name = "ABC123"
@@ -747,6 +750,11 @@ func (ms *ModelSuite) TestPolicies_Query() {
 			query:                "filter=active:false",
 			wantNumberOfPolicies: 5,
 		},
+		{
+			name:                 "inactive with search",
+			query:                "search=smith&filter=active:false",
+			wantNumberOfPolicies: 1,
+		},
 	}
 	for _, tt := range tests {
 		ms.T().Run(tt.name, func(t *testing.T) {
@@ -1203,3 +1211,91 @@ func (ms *ModelSuite) Test_parseVehicleYear() {
 		})
 	}
 }
+
+func (ms *ModelSuite) TestPolicy_Close() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 2, ItemsPerPolicy: 2})
+	claimFixtures := CreateItemFixtures(ms.DB, FixturesConfig{ClaimsPerPolicy: 1, ClaimItemsPerClaim: 1})
+
+	now := time.Now().UTC()
+	ctx := CreateTestContext(f.Policies[0].Members[0])
+
+	approvedItem := UpdateItemStatus(ms.DB, f.Items[0], api.ItemCoverageStatusApproved, "")
+	ms.NoError(approvedItem.SetPaidThroughDate(ms.DB, domain.EndOfYear(now.Year())))
+	draftItem := f.Items[1]
+
+	UpdateClaimStatus(ms.DB, claimFixtures.Claims[0], api.ClaimStatusReview1, "")
+
+	closedPolicy := f.Policies[1]
+	closedPolicy.ClosedDate = nulls.NewTime(now)
+	ms.NoError(ms.DB.Update(&closedPolicy))
+
+	tests := []struct {
+		name    string
+		policy  Policy
+		date    time.Time
+		wantErr *api.AppError
+	}{
+		{
+			name:    "already closed",
+			policy:  closedPolicy,
+			date:    now,
+			wantErr: &api.AppError{Key: api.ErrorPolicyClosed, Category: api.CategoryUser},
+		},
+		{
+			name:    "date in the past",
+			policy:  f.Policies[0],
+			date:    now.AddDate(0, 0, -1),
+			wantErr: &api.AppError{Key: api.ErrorInvalidDate, Category: api.CategoryUser},
+		},
+		{
+			name:    "date next year",
+			policy:  f.Policies[0],
+			date:    domain.EndOfYear(now.Year()).AddDate(0, 0, 1),
+			wantErr: &api.AppError{Key: api.ErrorInvalidDate, Category: api.CategoryUser},
+		},
+		{
+			name:    "open claim",
+			policy:  claimFixtures.Policies[0],
+			date:    now,
+			wantErr: &api.AppError{Key: api.ErrorPolicyHasOpenClaims, Category: api.CategoryUser},
+		},
+		{
+			name:   "good",
+			policy: f.Policies[0],
+			date:   now,
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Close(ctx, tt.date, "leaving the organization")
+			if tt.wantErr != nil {
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+
+			var dbPolicy Policy
+			ms.NoError(dbPolicy.FindByID(ms.DB, tt.policy.ID))
+			ms.True(dbPolicy.IsClosed(), "policy should be closed")
+			ms.Equal("leaving the organization", dbPolicy.ClosedReason, "incorrect ClosedReason")
+
+			var dbItem Item
+			ms.NoError(dbItem.FindByID(ms.DB, approvedItem.ID))
+			ms.True(dbItem.CoverageEndDate.Valid, "approved item should be scheduled for inactivation")
+
+			var le LedgerEntry
+			ms.NoError(ms.DB.Where("item_id = ?", approvedItem.ID).First(&le))
+			ms.Equal(LedgerEntryTypeCoverageRefund, le.Type, "LedgerEntry Type is incorrect")
+
+			n, err := ms.DB.Where("id = ?", draftItem.ID).Count(&Items{})
+			ms.NoError(err)
+			ms.Equal(0, n, "new draft item should have been deleted")
+
+			var history PolicyHistory
+			ms.NoError(ms.DB.Where("policy_id = ? AND field_name = ?", tt.policy.ID, "ClosedDate").First(&history))
+
+			_, err = dbPolicy.AddClaim(ctx, api.ClaimCreateInput{})
+			ms.EqualAppError(api.AppError{Key: api.ErrorPolicyClosed, Category: api.CategoryUser}, err)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/log"
+)
+
+type PolicyApprovers []PolicyApprover
+
+// PolicyApprover is a user who must accept new coverage on a team policy before it goes to steward review
+type PolicyApprover struct {
+	ID        uuid.UUID `db:"id"`
+	PolicyID  uuid.UUID `db:"policy_id" validate:"required"`
+	UserID    uuid.UUID `db:"user_id" validate:"required"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+
+	Policy Policy `belongs_to:"policies" validate:"-"`
+	User   User   `belongs_to:"users" validate:"-"`
+}
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (a *PolicyApprover) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validateModel(a), nil
+}
+
+func (a *PolicyApprover) Create(tx *pop.Connection) error {
+	return create(tx, a)
+}
+
+func (a *PolicyApprover) GetID() uuid.UUID {
+	return a.ID
+}
+
+func (a *PolicyApprover) FindByID(tx *pop.Connection, id uuid.UUID) error {
+	return tx.Find(a, id)
+}
+
+// IsActorAllowedTo ensures the actor is an admin. Policy members may not change the approvers of their own policy.
+func (a *PolicyApprover) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permission, sub SubResource, req *http.Request) bool {
+	return actor.IsAdmin() && sub == ""
+}
+
+// Delete removes the approver from the policy and records it in the policy history
+func (a *PolicyApprover) Delete(ctx context.Context) error {
+	tx := Tx(ctx)
+	a.LoadPolicy(tx, false)
+	a.LoadUser(tx, false)
+
+	history := a.Policy.NewHistory(ctx, api.HistoryActionUpdate, FieldUpdate{
+		FieldName: FieldPolicyApprovers,
+		OldValue:  a.User.Email,
+	})
+	if err := history.Create(tx); err != nil {
+		return err
+	}
+
+	return destroy(tx, a)
+}
+
+// LoadPolicy - a simple wrapper method for loading the policy on the struct
+func (a *PolicyApprover) LoadPolicy(tx *pop.Connection, reload bool) {
+	if a.Policy.ID == uuid.Nil || reload {
+		if err := tx.Load(a, "Policy"); err != nil {
+			panic("database error loading PolicyApprover.Policy, " + err.Error())
+		}
+	}
+}
+
+// LoadUser - a simple wrapper method for loading the user on the struct
+func (a *PolicyApprover) LoadUser(tx *pop.Connection, reload bool) {
+	if a.User.ID == uuid.Nil || reload {
+		if err := tx.Load(a, "User"); err != nil {
+			panic("database error loading PolicyApprover.User, " + err.Error())
+		}
+	}
+}
+
+func (a *PolicyApprover) ConvertToAPI(tx *pop.Connection) api.PolicyApprover {
+	a.LoadUser(tx, false)
+
+	return api.PolicyApprover{
+		ID:        a.ID,
+		PolicyID:  a.PolicyID,
+		UserID:    a.UserID,
+		Name:      a.User.Name(),
+		Email:     a.User.EmailOfChoice(),
+		CreatedAt: a.CreatedAt,
+	}
+}
+
+func (a *PolicyApprovers) ConvertToAPI(tx *pop.Connection) api.PolicyApprovers {
+	approvers := make(api.PolicyApprovers, len(*a))
+	for i, aa := range *a {
+		approvers[i] = aa.ConvertToAPI(tx)
+	}
+	return approvers
+}
+
+// GetApprovers returns the budget approvers of the policy
+func (p *Policy) GetApprovers(tx *pop.Connection) PolicyApprovers {
+	var approvers PolicyApprovers
+	if err := tx.Where("policy_id = ?", p.ID).Order("created_at asc").All(&approvers); err != nil {
+		panic("database error loading policy approvers, " + err.Error())
+	}
+	return approvers
+}
+
+// AddApprover adds an existing user as a budget approver of a team policy
+func (p *Policy) AddApprover(ctx context.Context, input api.PolicyApproverInput) (PolicyApprover, error) {
+	tx := Tx(ctx)
+
+	if p.Type != api.PolicyTypeTeam {
+		err := fmt.Errorf("policy %s is not a team policy", p.ID)
+		return PolicyApprover{}, api.NewAppError(err, api.ErrorPolicyApproverTeamOnly, api.CategoryUser)
+	}
+
+	var user User
+	if err := user.FindByEmail(tx, strings.TrimSpace(input.Email)); err != nil {
+		return PolicyApprover{}, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	if p.isApprover(tx, user.ID) {
+		err := fmt.Errorf("user %s is already an approver of policy %s", user.ID, p.ID)
+		return PolicyApprover{}, api.NewAppError(err, api.ErrorPolicyApproverExists, api.CategoryUser)
+	}
+
+	approver := PolicyApprover{
+		PolicyID: p.ID,
+		UserID:   user.ID,
+		User:     user,
+	}
+	if err := approver.Create(tx); err != nil {
+		return PolicyApprover{}, err
+	}
+
+	history := p.NewHistory(ctx, api.HistoryActionUpdate, FieldUpdate{
+		FieldName: FieldPolicyApprovers,
+		NewValue:  user.Email,
+	})
+	if err := history.Create(tx); err != nil {
+		return PolicyApprover{}, err
+	}
+
+	return approver, nil
+}
+
+// isApprover returns true if the user is a budget approver of the policy
+func (p *Policy) isApprover(tx *pop.Connection, userID uuid.UUID) bool {
+	count, err := tx.Where("policy_id = ? AND user_id = ?", p.ID, userID).Count(PolicyApprover{})
+	if err != nil {
+		log.Errorf("failed to count approvers of policy %s: %s", p.ID, err)
+		return false
+	}
+	return count > 0
+}
+
+// needsBudgetApproval returns true if new coverage on the policy must be accepted by an approver
+func (p *Policy) needsBudgetApproval(tx *pop.Connection) bool {
+	if p.Type != api.PolicyTypeTeam {
+		return false
+	}
+	count, err := tx.Where("policy_id = ?", p.ID).Count(PolicyApprover{})
+	if err != nil {
+		panic("database error counting policy approvers, " + err.Error())
+	}
+	return count > 0
+}
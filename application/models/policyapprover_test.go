@@ -0,0 +1,120 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+func (ms *ModelSuite) TestPolicy_AddApprover() {
+	teamPolicy := CreateTeamPolicyFixtures(ms.DB, FixturesConfig{}).Policies[0]
+	householdPolicy := CreatePolicyFixtures(ms.DB, FixturesConfig{}).Policies[0]
+	user := CreateUserFixtures(ms.DB, 1).Users[0]
+	admin := createAdminUserWithRole(ms.DB, AppRoleSteward)
+	ctx := CreateTestContext(admin)
+
+	tests := []struct {
+		name    string
+		policy  Policy
+		email   string
+		wantErr *api.AppError
+	}{
+		{
+			name:    "household policy",
+			policy:  householdPolicy,
+			email:   user.Email,
+			wantErr: &api.AppError{Key: api.ErrorPolicyApproverTeamOnly, Category: api.CategoryUser},
+		},
+		{
+			name:    "unknown user",
+			policy:  teamPolicy,
+			email:   "nobody@example.org",
+			wantErr: &api.AppError{Key: api.ErrorNoRows, Category: api.CategoryUser},
+		},
+		{
+			name:   "good",
+			policy: teamPolicy,
+			email:  user.Email,
+		},
+		{
+			name:    "already an approver",
+			policy:  teamPolicy,
+			email:   user.Email,
+			wantErr: &api.AppError{Key: api.ErrorPolicyApproverExists, Category: api.CategoryUser},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			got, err := tt.policy.AddApprover(ctx, api.PolicyApproverInput{Email: tt.email})
+			if tt.wantErr != nil {
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+			ms.Equal(user.ID, got.UserID, "incorrect UserID")
+			ms.True(tt.policy.isApprover(ms.DB, user.ID), "user should be an approver")
+			ms.True(tt.policy.needsBudgetApproval(ms.DB), "policy should need budget approval")
+
+			var h PolicyHistory
+			ms.NoError(ms.DB.Where("policy_id = ? AND field_name = ?", tt.policy.ID, FieldPolicyApprovers).First(&h))
+			ms.Equal(user.Email, h.NewValue, "incorrect history")
+		})
+	}
+}
+
+func (ms *ModelSuite) TestItem_BudgetApproval() {
+	f := CreateTeamPolicyFixtures(ms.DB, FixturesConfig{})
+	policy := f.Policies[0]
+	member := f.Users[0]
+	approver := CreateUserFixtures(ms.DB, 1).Users[0]
+
+	category := CreateCategoryFixtures(ms.DB, 1).ItemCategories[0]
+	item := createItemFixture(ms.DB, policy.ID, category.ID)
+	item.CoverageAmount = category.AutoApproveMax + 1
+	ms.NoError(ms.DB.Update(&item))
+
+	memberCtx := CreateTestContext(member)
+	approverCtx := CreateTestContext(approver)
+
+	// without an approver, the item goes straight to steward review
+	ms.False(policy.needsBudgetApproval(ms.DB), "policy should not need budget approval yet")
+
+	_, err := policy.AddApprover(CreateTestContext(createAdminUserWithRole(ms.DB, AppRoleSteward)),
+		api.PolicyApproverInput{Email: approver.Email})
+	ms.NoError(err)
+
+	ms.NoError(item.SubmitForApproval(memberCtx))
+	ms.Equal(api.ItemCoverageStatusPendingBudget, item.CoverageStatus, "submitted item should await budget approval")
+	ms.Equal(ItemStatusChangeBudgetSubmitted, item.StatusChange, "incorrect StatusChange")
+
+	var pending Items
+	ms.NoError(pending.FindPendingBudgetForApprover(ms.DB, approver.ID))
+	ms.Len(pending, 1, "approver should have one item to approve")
+	ms.Equal(item.ID, pending[0].ID, "incorrect item awaiting approval")
+
+	ms.NoError(item.BudgetReject(approverCtx, "over budget"))
+	ms.Equal(api.ItemCoverageStatusRevision, item.CoverageStatus, "rejected item should go to revision")
+	ms.Equal("over budget", item.StatusReason, "incorrect StatusReason")
+
+	ms.NoError(pending.FindPendingBudgetForApprover(ms.DB, approver.ID))
+	ms.Len(pending, 0, "approver should have no items to approve")
+
+	ms.NoError(item.SubmitForApproval(memberCtx))
+	ms.Equal(api.ItemCoverageStatusPendingBudget, item.CoverageStatus, "resubmitted item should await budget approval")
+
+	ms.NoError(item.BudgetApprove(approverCtx))
+	ms.Equal(api.ItemCoverageStatusPending, item.CoverageStatus, "approved item should go to steward review")
+	ms.Equal(ItemStatusChangeBudgetApproved+approver.Name(), item.StatusChange, "incorrect StatusChange")
+
+	item.CoverageAmount -= 1
+	ms.NoError(item.Update(memberCtx))
+	ms.Equal(api.ItemCoverageStatusPending, item.CoverageStatus, "decrease should not need budget approval")
+
+	item.CoverageAmount += 100
+	ms.NoError(item.Update(memberCtx))
+	ms.Equal(api.ItemCoverageStatusPendingBudget, item.CoverageStatus, "increase should need budget approval")
+	ms.Equal(ItemStatusChangeBudgetSubmitted, item.StatusChange, "incorrect StatusChange")
+
+	ms.NoError(pending.FindPendingBudgetForApprover(ms.DB, approver.ID))
+	ms.Len(pending, 1, "approver should have the increased item to approve")
+}
@@ -100,15 +100,11 @@ func (p *PolicyDependent) IsActorAllowedTo(tx *pop.Connection, actor User, perm
 		return false
 	}
 
-	policy.LoadMembers(tx, false)
-
-	for _, m := range policy.Members {
-		if m.ID == actor.ID {
-			return true
-		}
+	role, isMember := policy.memberRole(tx, actor.ID)
+	if !isMember {
+		return false
 	}
-
-	return false
+	return role != api.PolicyUserRoleViewer || perm == PermissionView
 }
 
 func (p *PolicyDependent) ConvertToAPI() api.PolicyDependent {
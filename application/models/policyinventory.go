@@ -0,0 +1,242 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+var inventoryHeader = []string{
+	"Item", "Category", "Risk Category", "Make", "Model", "Serial Number", "Accountable Person",
+	"Coverage Status", "Coverage Amount", "Billing Period", "Premium", "Coverage Start", "Coverage End",
+	"Paid Through",
+}
+
+// inventoryRow holds the exported details of a single item
+type inventoryRow struct {
+	Name              string
+	Category          string
+	RiskCategory      string
+	Make              string
+	Model             string
+	SerialNumber      string
+	AccountablePerson string
+	CoverageStatus    api.ItemCoverageStatus
+	CoverageAmount    api.Currency
+	BillingPeriod     string
+	Premium           api.Currency
+	CoverageStartDate time.Time
+	CoverageEndDate   *time.Time
+	PaidThroughDate   time.Time
+}
+
+// NewItemsExport creates an inventory of the items on the policy in the given format (csv or pdf)
+// and stores it as an unlinked File
+func (p *Policy) NewItemsExport(ctx context.Context, format string) (File, error) {
+	tx := Tx(ctx)
+
+	rows, err := p.inventory(tx)
+	if err != nil {
+		return File{}, err
+	}
+
+	var content []byte
+	var contentType string
+	switch format {
+	case api.ExportFormatCSV:
+		content, err = renderInventoryCSV(rows)
+		contentType = domain.ContentCSV
+	case api.ExportFormatPDF:
+		p.LoadDependents(tx, false)
+		content, err = p.renderInventoryPDF(rows)
+		contentType = domain.ContentPDF
+	default:
+		err := errors.New("invalid export format: " + format)
+		return File{}, api.NewAppError(err, api.ErrorInvalidExportFormat, api.CategoryUser)
+	}
+	if err != nil {
+		return File{}, api.NewAppError(err, api.ErrorUnableToStoreFile, api.CategoryInternal)
+	}
+
+	f := File{
+		Name: fmt.Sprintf("%s_policy_%s_items_%s.%s",
+			domain.Env.AppName, p.ID.String(), time.Now().UTC().Format(domain.DateFormat), format),
+		Content:     content,
+		ContentType: contentType,
+		CreatedByID: CurrentUser(ctx).ID,
+	}
+	if err := f.Store(tx); err != nil {
+		return File{}, err
+	}
+	return f, nil
+}
+
+func (p *Policy) inventory(tx *pop.Connection) ([]inventoryRow, error) {
+	var items Items
+	if err := tx.Where("policy_id = ?", p.ID).Order("name asc").All(&items); err != nil {
+		return nil, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	rows := make([]inventoryRow, len(items))
+	for i := range items {
+		item := &items[i]
+		item.LoadCategory(tx, false)
+		item.LoadRiskCategory(tx, false)
+
+		billingPeriod := "Annual"
+		if item.Category.GetBillingPeriod() == domain.BillingPeriodMonthly {
+			billingPeriod = "Monthly"
+		}
+
+		rows[i] = inventoryRow{
+			Name:              item.Name,
+			Category:          item.Category.Name,
+			RiskCategory:      item.RiskCategory.Name,
+			Make:              item.Make,
+			Model:             item.Model,
+			SerialNumber:      item.SerialNumber,
+			AccountablePerson: item.GetAccountablePersonName(tx).String(),
+			CoverageStatus:    item.CoverageStatus,
+			CoverageAmount:    api.Currency(item.CoverageAmount),
+			BillingPeriod:     billingPeriod,
+			Premium:           item.CalculateBillingPremium(tx),
+			CoverageStartDate: item.CoverageStartDate,
+			CoverageEndDate:   convertTimeToAPI(item.CoverageEndDate),
+			PaidThroughDate:   item.PaidThroughDate,
+		}
+	}
+	return rows, nil
+}
+
+func (r inventoryRow) coverageEnd(format func(time.Time) string) string {
+	if r.CoverageEndDate == nil {
+		return ""
+	}
+	return format(*r.CoverageEndDate)
+}
+
+func renderInventoryCSV(rows []inventoryRow) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(inventoryHeader); err != nil {
+		return nil, err
+	}
+
+	csvDate := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(domain.DateFormat)
+	}
+
+	for _, r := range rows {
+		record := []string{
+			r.Name,
+			r.Category,
+			r.RiskCategory,
+			r.Make,
+			r.Model,
+			r.SerialNumber,
+			r.AccountablePerson,
+			string(r.CoverageStatus),
+			r.CoverageAmount.String(),
+			r.BillingPeriod,
+			r.Premium.String(),
+			csvDate(r.CoverageStartDate),
+			r.coverageEnd(csvDate),
+			csvDate(r.PaidThroughDate),
+		}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func (p *Policy) renderInventoryPDF(rows []inventoryRow) ([]byte, error) {
+	doc := newPDFDocument("Policy Item Inventory")
+
+	doc.heading("Policy")
+	doc.labeledValue("Policy name", p.Name)
+	doc.labeledValue("Policy type", string(p.Type))
+	doc.labeledValue("Date", pdfDate(time.Now().UTC()))
+
+	var coverageTotal, annualPremiumTotal, monthlyPremiumTotal api.Currency
+	for _, r := range rows {
+		if r.CoverageStatus != api.ItemCoverageStatusApproved {
+			continue
+		}
+		coverageTotal += r.CoverageAmount
+		if r.BillingPeriod == "Monthly" {
+			monthlyPremiumTotal += r.Premium
+		} else {
+			annualPremiumTotal += r.Premium
+		}
+	}
+	doc.labeledValue("Covered value", "$"+coverageTotal.String())
+	doc.labeledValue("Annual premiums", "$"+annualPremiumTotal.String())
+	doc.labeledValue("Monthly premiums", "$"+monthlyPremiumTotal.String())
+
+	if len(p.Dependents) > 0 {
+		doc.heading("Dependents")
+		for _, d := range p.Dependents {
+			doc.labeledValue(d.Name, string(d.Relationship))
+		}
+	}
+
+	doc.heading("Items")
+	tableRows := make([][]string, len(rows))
+	for i, r := range rows {
+		tableRows[i] = []string{
+			r.Name,
+			r.Category,
+			r.AccountablePerson,
+			string(r.CoverageStatus),
+			"$" + r.CoverageAmount.String(),
+			"$" + r.Premium.String() + " " + r.BillingPeriod[:1],
+			pdfDate(r.PaidThroughDate),
+		}
+	}
+	doc.table(
+		[]string{"Item", "Category", "Accountable Person", "Status", "Coverage", "Premium", "Paid Through"},
+		[]float64{38, 28, 32, 18, 22, 24, 28},
+		tableRows,
+	)
+	doc.paragraph("Premium: A = annual billing, M = monthly billing. Totals include approved items only.")
+
+	doc.heading("Item Details")
+	detailRows := make([][]string, len(rows))
+	for i, r := range rows {
+		coverageEnd := r.coverageEnd(pdfDate)
+		if coverageEnd == "" {
+			coverageEnd = "ongoing"
+		}
+		detailRows[i] = []string{
+			r.Name,
+			r.RiskCategory,
+			strings.TrimSpace(r.Make + " " + r.Model),
+			r.SerialNumber,
+			pdfDate(r.CoverageStartDate),
+			coverageEnd,
+		}
+	}
+	doc.table(
+		[]string{"Item", "Risk Category", "Make and Model", "Serial Number", "Coverage Start", "Coverage End"},
+		[]float64{38, 24, 38, 30, 30, 30},
+		detailRows,
+	)
+
+	return doc.render()
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+func (ms *ModelSuite) TestPolicy_NewItemsExport() {
+	t := ms.T()
+
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 2})
+	policy := f.Policies[0]
+	UpdateItemStatus(ms.DB, f.Items[0], api.ItemCoverageStatusApproved, "")
+
+	ctx := CreateTestContext(f.Users[0])
+
+	tests := []struct {
+		name            string
+		format          string
+		wantContentType string
+		wantErr         *api.AppError
+	}{
+		{
+			name:    "invalid format",
+			format:  "doc",
+			wantErr: &api.AppError{Key: api.ErrorInvalidExportFormat, Category: api.CategoryUser},
+		},
+		{
+			name:            "csv",
+			format:          api.ExportFormatCSV,
+			wantContentType: domain.ContentCSV,
+		},
+		{
+			name:            "pdf",
+			format:          api.ExportFormatPDF,
+			wantContentType: domain.ContentPDF,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := policy.NewItemsExport(ctx, tt.format)
+			if tt.wantErr != nil {
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+			ms.Equal(tt.wantContentType, got.ContentType, "incorrect content type")
+			ms.False(got.Linked, "export file should not be linked")
+		})
+	}
+}
+
+func (ms *ModelSuite) Test_renderInventoryCSV() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 2})
+	policy := f.Policies[0]
+	item := UpdateItemStatus(ms.DB, f.Items[0], api.ItemCoverageStatusApproved, "")
+
+	rows, err := policy.inventory(ms.DB)
+	ms.NoError(err)
+	ms.Len(rows, 2, "incorrect number of inventory rows")
+
+	got, err := renderInventoryCSV(rows)
+	ms.NoError(err)
+
+	lines := strings.Split(strings.TrimSpace(string(got)), "\n")
+	ms.Len(lines, 3, "incorrect number of lines")
+	ms.Equal(strings.Join(inventoryHeader, ","), lines[0], "incorrect header")
+	ms.Contains(string(got), item.SerialNumber)
+	ms.Contains(string(got), item.CalculateBillingPremium(ms.DB).String())
+}
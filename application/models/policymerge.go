@@ -0,0 +1,350 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+// Merge moves the members, dependents, items, claims, invites and strikes of another Household policy into this
+// one and then closes the other policy. This policy takes the given HouseholdID, which must be that of one of the
+// two policies. If none is given, it keeps its own HouseholdID, or takes over the other policy's if it has none.
+func (p *Policy) Merge(ctx context.Context, other *Policy, householdID string) error {
+	tx := Tx(ctx)
+
+	if err := p.validateMerge(other, householdID); err != nil {
+		return api.NewAppError(err, api.ErrorPolicyMergeInvalid, api.CategoryUser)
+	}
+
+	p.LoadMembers(tx, true)
+	other.LoadMembers(tx, true)
+	for _, m := range other.Members {
+		if err := p.moveMember(ctx, other, m); err != nil {
+			return err
+		}
+	}
+
+	p.LoadDependents(tx, true)
+	other.LoadDependents(tx, true)
+	for _, d := range other.Dependents {
+		if err := p.moveDependent(ctx, other, d); err != nil {
+			return err
+		}
+	}
+
+	other.LoadItems(tx, true)
+	now := time.Now().UTC()
+	for i := range other.Items {
+		if err := other.Items[i].transferToPolicy(ctx, *other, *p, now); err != nil {
+			return err
+		}
+	}
+
+	for _, table := range []string{"claims", "policy_user_invites", "strikes"} {
+		q := fmt.Sprintf("UPDATE %s SET policy_id = ?, updated_at = ? WHERE policy_id = ?", table)
+		if err := tx.RawQuery(q, p.ID, now, other.ID).Exec(); err != nil {
+			return appErrorFromDB(err, api.ErrorUpdateFailure)
+		}
+	}
+
+	mergedHouseholdID := p.HouseholdID
+	if householdID != "" {
+		mergedHouseholdID = nulls.NewString(householdID)
+	} else if !p.HouseholdID.Valid {
+		mergedHouseholdID = other.HouseholdID
+	}
+
+	// the HouseholdID is unique, so the other policy gives it up first
+	other.HouseholdID = nulls.String{}
+	other.ClosedDate = nulls.NewTime(domain.BeginningOfDay(now))
+	other.ClosedReason = fmt.Sprintf("merged into policy %s", p.Name)
+	if err := other.Update(ctx); err != nil {
+		return err
+	}
+
+	p.HouseholdID = mergedHouseholdID
+	if err := p.Update(ctx); err != nil {
+		return err
+	}
+
+	history := p.NewHistory(ctx, api.HistoryActionUpdate, FieldUpdate{
+		FieldName: FieldPolicyMergedPolicyID,
+		NewValue:  other.ID.String(),
+	})
+	return history.Create(tx)
+}
+
+func (p *Policy) validateMerge(other *Policy, householdID string) error {
+	if p.ID == other.ID {
+		return errors.New("a policy cannot be merged into itself")
+	}
+	if p.Type != api.PolicyTypeHousehold || other.Type != api.PolicyTypeHousehold {
+		return errors.New("only household policies can be merged")
+	}
+	if p.IsClosed() || other.IsClosed() {
+		return errors.New("a closed policy cannot be merged")
+	}
+	if householdID != "" && householdID != p.HouseholdID.String && householdID != other.HouseholdID.String {
+		return fmt.Errorf("household ID %s is not the household ID of either policy", householdID)
+	}
+	return nil
+}
+
+// Split creates a new Household policy and moves the given members, dependents and items to it. Items whose
+// accountable person is moved are moved as well, even if they are not listed.
+func (p *Policy) Split(ctx context.Context, input api.PolicySplitInput) (Policy, error) {
+	tx := Tx(ctx)
+
+	if p.Type != api.PolicyTypeHousehold || p.IsClosed() {
+		err := errors.New("only an open household policy can be split")
+		return Policy{}, api.NewAppError(err, api.ErrorPolicySplitInvalid, api.CategoryUser)
+	}
+
+	members, dependents, items, err := p.selectForSplit(ctx, input)
+	if err != nil {
+		return Policy{}, api.NewAppError(err, api.ErrorPolicySplitInvalid, api.CategoryUser)
+	}
+
+	newPolicy := Policy{
+		Name:         input.Name,
+		Type:         api.PolicyTypeHousehold,
+		EntityCodeID: HouseholdEntityID(),
+	}
+	if newPolicy.Name == "" {
+		newPolicy.Name = members[0].LastName + " household"
+	}
+	if input.HouseholdID != "" {
+		newPolicy.HouseholdID = nulls.NewString(input.HouseholdID)
+	}
+	if err := newPolicy.CreateWithHistory(ctx); err != nil {
+		return Policy{}, err
+	}
+
+	for _, m := range members {
+		if err := newPolicy.moveMember(ctx, p, m); err != nil {
+			return Policy{}, err
+		}
+	}
+	for _, d := range dependents {
+		if err := newPolicy.moveDependent(ctx, p, d); err != nil {
+			return Policy{}, err
+		}
+	}
+
+	now := time.Now().UTC()
+	for i := range items {
+		if err := items[i].transferToPolicy(ctx, *p, newPolicy, now); err != nil {
+			return Policy{}, err
+		}
+	}
+
+	history := p.NewHistory(ctx, api.HistoryActionUpdate, FieldUpdate{
+		FieldName: FieldPolicySplitPolicyID,
+		NewValue:  newPolicy.ID.String(),
+	})
+	if err := history.Create(tx); err != nil {
+		return Policy{}, err
+	}
+
+	return newPolicy, nil
+}
+
+// selectForSplit validates the split input and returns the members, dependents and items to be moved
+func (p *Policy) selectForSplit(ctx context.Context, input api.PolicySplitInput) (Users, PolicyDependents, Items, error) {
+	tx := Tx(ctx)
+
+	p.LoadMembers(tx, true)
+	var members Users
+	for _, id := range input.MemberIDs {
+		found := false
+		for _, m := range p.Members {
+			if m.ID == id {
+				members = append(members, m)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, nil, nil, fmt.Errorf("user %s is not a member of the policy", id)
+		}
+	}
+	if len(members) == 0 {
+		return nil, nil, nil, errors.New("at least one member must be moved to the new policy")
+	}
+	if len(members) == len(p.Members) {
+		return nil, nil, nil, errors.New("at least one member must remain on the policy")
+	}
+
+	p.LoadDependents(tx, true)
+	var dependents PolicyDependents
+	for _, id := range input.DependentIDs {
+		found := false
+		for _, d := range p.Dependents {
+			if d.ID == id {
+				dependents = append(dependents, d)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, nil, nil, fmt.Errorf("dependent %s is not on the policy", id)
+		}
+	}
+
+	movingPeople := map[uuid.UUID]bool{}
+	for _, m := range members {
+		movingPeople[m.ID] = true
+	}
+	for _, d := range dependents {
+		movingPeople[d.ID] = true
+	}
+
+	selectedItems := map[uuid.UUID]bool{}
+	for _, id := range input.ItemIDs {
+		selectedItems[id] = true
+	}
+
+	p.LoadItems(tx, true)
+	var items Items
+	for _, item := range p.Items {
+		personMoves := (item.PolicyUserID.Valid && movingPeople[item.PolicyUserID.UUID]) ||
+			(item.PolicyDependentID.Valid && movingPeople[item.PolicyDependentID.UUID])
+		if selectedItems[item.ID] && !personMoves {
+			return nil, nil, nil, fmt.Errorf("item %s cannot be moved without its accountable person", item.Name)
+		}
+		delete(selectedItems, item.ID)
+		if !personMoves {
+			continue
+		}
+		if item.hasOpenClaim(tx) {
+			return nil, nil, nil, fmt.Errorf("item %s has an open claim", item.Name)
+		}
+		items = append(items, item)
+	}
+	if len(selectedItems) > 0 {
+		return nil, nil, nil, errors.New("some of the selected items are not on the policy")
+	}
+
+	return members, dependents, items, nil
+}
+
+// moveMember moves a member from another policy to this one. If the user is already a member of this
+// policy, the membership on the other policy is removed.
+func (p *Policy) moveMember(ctx context.Context, from *Policy, member User) error {
+	tx := Tx(ctx)
+
+	var polUser PolicyUser
+	if err := tx.Where("policy_id = ? AND user_id = ?", from.ID, member.ID).First(&polUser); err != nil {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	if p.isMember(tx, member.ID) {
+		if err := tx.Destroy(&polUser); err != nil {
+			return appErrorFromDB(err, api.ErrorUpdateFailure)
+		}
+	} else {
+		polUser.PolicyID = p.ID
+		if err := tx.UpdateColumns(&polUser, "policy_id", "updated_at"); err != nil {
+			return appErrorFromDB(err, api.ErrorUpdateFailure)
+		}
+	}
+
+	return writeTransferHistory(ctx, from, p, FieldPolicyMembers, member.Name(), nulls.UUID{})
+}
+
+// moveDependent moves a dependent from another policy to this one. If this policy already has a
+// dependent with the same name, the other policy's items are reassigned to it and the duplicate is removed.
+func (p *Policy) moveDependent(ctx context.Context, from *Policy, dependent PolicyDependent) error {
+	tx := Tx(ctx)
+
+	var existing PolicyDependent
+	err := tx.Where("policy_id = ? AND name = ?", p.ID, dependent.Name).First(&existing)
+	if domain.IsOtherThanNoRows(err) {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	if err == nil {
+		if err := tx.RawQuery("UPDATE items SET policy_dependent_id = ? WHERE policy_dependent_id = ?",
+			existing.ID, dependent.ID).Exec(); err != nil {
+			return appErrorFromDB(err, api.ErrorUpdateFailure)
+		}
+		if err := tx.Destroy(&dependent); err != nil {
+			return appErrorFromDB(err, api.ErrorUpdateFailure)
+		}
+	} else {
+		dependent.PolicyID = p.ID
+		if err := tx.UpdateColumns(&dependent, "policy_id", "updated_at"); err != nil {
+			return appErrorFromDB(err, api.ErrorUpdateFailure)
+		}
+	}
+
+	return writeTransferHistory(ctx, from, p, FieldPolicyDependents, dependent.Name, nulls.UUID{})
+}
+
+// transferToPolicy moves the item to another policy. If annual coverage has already been paid, the unused
+// premium is credited to the old policy and charged to the new one.
+func (i *Item) transferToPolicy(ctx context.Context, from, to Policy, now time.Time) error {
+	tx := Tx(ctx)
+
+	i.LoadCategory(tx, false)
+	var credit api.Currency
+	if i.CoverageStatus == api.ItemCoverageStatusApproved && !i.PaidThroughDate.Before(now) &&
+		i.Category.GetBillingPeriod() == domain.BillingPeriodAnnual {
+		refund, err := i.calculateCancellationCredit(tx, now)
+		if err != nil {
+			return err
+		}
+		credit = -refund
+	}
+
+	if credit > 0 {
+		i.Policy = from
+		if err := i.CreateLedgerEntry(tx, LedgerEntryTypePolicyAdjustment, -credit, now); err != nil {
+			return err
+		}
+	}
+
+	i.PolicyID = to.ID
+	i.Policy = to
+	if err := tx.UpdateColumns(i, "policy_id", "updated_at"); err != nil {
+		return appErrorFromDB(err, api.ErrorUpdateFailure)
+	}
+
+	if credit > 0 {
+		if err := i.CreateLedgerEntry(tx, LedgerEntryTypePolicyAdjustment, credit, now); err != nil {
+			return err
+		}
+	}
+
+	return writeTransferHistory(ctx, &from, &to, FieldItemPolicyID, i.Name, nulls.NewUUID(i.ID))
+}
+
+// writeTransferHistory records the move of a member, dependent or item on both policies
+func writeTransferHistory(ctx context.Context, from, to *Policy, fieldName, name string, itemID nulls.UUID) error {
+	tx := Tx(ctx)
+
+	fromHistory := from.NewHistory(ctx, api.HistoryActionUpdate, FieldUpdate{
+		FieldName: fieldName,
+		OldValue:  name,
+		NewValue:  to.ID.String(),
+	})
+	fromHistory.ItemID = itemID
+	if err := fromHistory.Create(tx); err != nil {
+		return err
+	}
+
+	toHistory := to.NewHistory(ctx, api.HistoryActionUpdate, FieldUpdate{
+		FieldName: fieldName,
+		OldValue:  from.ID.String(),
+		NewValue:  name,
+	})
+	toHistory.ItemID = itemID
+	return toHistory.Create(tx)
+}
@@ -0,0 +1,250 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+func (ms *ModelSuite) TestPolicy_Merge() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{
+		NumberOfPolicies:    3,
+		UsersPerPolicy:      1,
+		DependentsPerPolicy: 1,
+		ItemsPerPolicy:      1,
+		InvitesPerPolicy:    1,
+	})
+	steward := CreateAdminUsers(ms.DB)[AppRoleSteward]
+	ctx := CreateTestContext(steward)
+
+	survivor := f.Policies[0]
+	other := f.Policies[1]
+	survivor.HouseholdID = nulls.String{}
+	ms.NoError(ms.DB.Update(&survivor))
+
+	now := time.Now().UTC()
+	otherItem := UpdateItemStatus(ms.DB, f.Items[1], api.ItemCoverageStatusApproved, "")
+	ms.NoError(otherItem.SetPaidThroughDate(ms.DB, domain.EndOfYear(now.Year())))
+
+	tests := []struct {
+		name        string
+		policy      Policy
+		other       Policy
+		householdID string
+		wantErr     *api.AppError
+	}{
+		{
+			name:    "same policy",
+			policy:  survivor,
+			other:   survivor,
+			wantErr: &api.AppError{Key: api.ErrorPolicyMergeInvalid, Category: api.CategoryUser},
+		},
+		{
+			name:        "household ID of neither policy",
+			policy:      f.Policies[2],
+			other:       other,
+			householdID: "not-a-household",
+			wantErr:     &api.AppError{Key: api.ErrorPolicyMergeInvalid, Category: api.CategoryUser},
+		},
+		{
+			name:   "good",
+			policy: survivor,
+			other:  other,
+		},
+		{
+			name:    "already merged",
+			policy:  f.Policies[2],
+			other:   other,
+			wantErr: &api.AppError{Key: api.ErrorPolicyMergeInvalid, Category: api.CategoryUser},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			var otherPolicy Policy
+			ms.NoError(otherPolicy.FindByID(ms.DB, tt.other.ID))
+
+			err := tt.policy.Merge(ctx, &otherPolicy, tt.householdID)
+			if tt.wantErr != nil {
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+
+			var dbPolicy Policy
+			ms.NoError(dbPolicy.FindByID(ms.DB, tt.policy.ID))
+			ms.Equal(tt.other.HouseholdID, dbPolicy.HouseholdID, "survivor should take the other HouseholdID")
+			dbPolicy.LoadMembers(ms.DB, false)
+			dbPolicy.LoadDependents(ms.DB, false)
+			dbPolicy.LoadItems(ms.DB, false)
+			ms.Len(dbPolicy.Members, 2, "incorrect number of members")
+			ms.Len(dbPolicy.Dependents, 2, "incorrect number of dependents")
+			ms.Len(dbPolicy.Items, 2, "incorrect number of items")
+
+			n, err := ms.DB.Where("policy_id = ?", tt.policy.ID).Count(&PolicyUserInvites{})
+			ms.NoError(err)
+			ms.Equal(2, n, "incorrect number of invites")
+
+			ms.NoError(otherPolicy.FindByID(ms.DB, tt.other.ID))
+			ms.True(otherPolicy.IsClosed(), "merged policy should be closed")
+			ms.False(otherPolicy.HouseholdID.Valid, "merged policy should not have a HouseholdID")
+
+			// no credit is given in December
+			if now.Month() != 12 {
+				var credit, charge LedgerEntry
+				ms.NoError(ms.DB.Where("policy_id = ? AND type = ?", tt.other.ID, LedgerEntryTypePolicyAdjustment).
+					First(&credit))
+				ms.NoError(ms.DB.Where("policy_id = ? AND type = ?", tt.policy.ID, LedgerEntryTypePolicyAdjustment).
+					First(&charge))
+				ms.Greater(int(credit.Amount), 0, "old policy should be credited")
+				ms.Equal(credit.Amount, -charge.Amount, "transfer should balance")
+			}
+
+			for _, policyID := range []uuid.UUID{tt.policy.ID, tt.other.ID} {
+				var h PolicyHistory
+				ms.NoError(ms.DB.Where("policy_id = ? AND item_id = ? AND field_name = ?",
+					policyID, otherItem.ID, FieldItemPolicyID).First(&h), "missing item history")
+			}
+		})
+	}
+}
+
+func (ms *ModelSuite) TestPolicy_Merge_HouseholdID() {
+	f := CreatePolicyFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 4})
+	steward := CreateAdminUsers(ms.DB)[AppRoleSteward]
+	ctx := CreateTestContext(steward)
+
+	tests := []struct {
+		name            string
+		policy          Policy
+		other           Policy
+		householdID     string
+		wantHouseholdID string
+	}{
+		{
+			name:            "default to the remaining policy",
+			policy:          f.Policies[0],
+			other:           f.Policies[1],
+			wantHouseholdID: f.Policies[0].HouseholdID.String,
+		},
+		{
+			name:            "the other policy",
+			policy:          f.Policies[2],
+			other:           f.Policies[3],
+			householdID:     f.Policies[3].HouseholdID.String,
+			wantHouseholdID: f.Policies[3].HouseholdID.String,
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			ms.NotEqual(tt.policy.HouseholdID, tt.other.HouseholdID, "fixtures should have different household IDs")
+
+			other := tt.other
+			ms.NoError(tt.policy.Merge(ctx, &other, tt.householdID))
+
+			var dbPolicy, dbOther Policy
+			ms.NoError(dbPolicy.FindByID(ms.DB, tt.policy.ID))
+			ms.NoError(dbOther.FindByID(ms.DB, tt.other.ID))
+			ms.Equal(tt.wantHouseholdID, dbPolicy.HouseholdID.String, "incorrect HouseholdID on the merged policy")
+			ms.False(dbOther.HouseholdID.Valid, "merged policy should not have a HouseholdID")
+
+			var h PolicyHistory
+			ms.NoError(ms.DB.Where("policy_id = ? AND field_name = ? AND old_value = ?",
+				tt.other.ID, "HouseholdID", tt.other.HouseholdID.String).First(&h), "missing history on merged policy")
+			if tt.wantHouseholdID != tt.policy.HouseholdID.String {
+				ms.NoError(ms.DB.Where("policy_id = ? AND field_name = ? AND new_value = ?",
+					tt.policy.ID, "HouseholdID", tt.wantHouseholdID).First(&h), "missing history on remaining policy")
+			}
+		})
+	}
+}
+
+func (ms *ModelSuite) TestPolicy_Split() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{UsersPerPolicy: 2, DependentsPerPolicy: 2, ItemsPerPolicy: 3})
+	steward := CreateAdminUsers(ms.DB)[AppRoleSteward]
+	ctx := CreateTestContext(steward)
+
+	policy := f.Policies[0]
+	leaving := policy.Members[1]
+	dependent := policy.Dependents[0]
+
+	leavingItem := f.Items[0]
+	leavingItem.PolicyUserID = nulls.NewUUID(leaving.ID)
+	ms.NoError(ms.DB.Update(&leavingItem))
+	dependentItem := f.Items[1]
+	dependentItem.PolicyDependentID = nulls.NewUUID(dependent.ID)
+	ms.NoError(ms.DB.Update(&dependentItem))
+	stayingItem := f.Items[2]
+	stayingItem.PolicyUserID = nulls.NewUUID(policy.Members[0].ID)
+	ms.NoError(ms.DB.Update(&stayingItem))
+
+	tests := []struct {
+		name    string
+		input   api.PolicySplitInput
+		wantErr *api.AppError
+	}{
+		{
+			name:    "all members",
+			input:   api.PolicySplitInput{MemberIDs: []uuid.UUID{policy.Members[0].ID, leaving.ID}},
+			wantErr: &api.AppError{Key: api.ErrorPolicySplitInvalid, Category: api.CategoryUser},
+		},
+		{
+			name:    "no members",
+			input:   api.PolicySplitInput{DependentIDs: []uuid.UUID{dependent.ID}},
+			wantErr: &api.AppError{Key: api.ErrorPolicySplitInvalid, Category: api.CategoryUser},
+		},
+		{
+			name: "item without its accountable person",
+			input: api.PolicySplitInput{
+				MemberIDs: []uuid.UUID{leaving.ID},
+				ItemIDs:   []uuid.UUID{stayingItem.ID},
+			},
+			wantErr: &api.AppError{Key: api.ErrorPolicySplitInvalid, Category: api.CategoryUser},
+		},
+		{
+			name: "good",
+			input: api.PolicySplitInput{
+				Name:         "new household",
+				HouseholdID:  randStr(10),
+				MemberIDs:    []uuid.UUID{leaving.ID},
+				DependentIDs: []uuid.UUID{dependent.ID},
+			},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			got, err := policy.Split(ctx, tt.input)
+			if tt.wantErr != nil {
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+
+			ms.Equal(tt.input.Name, got.Name, "incorrect policy name")
+			ms.Equal(tt.input.HouseholdID, got.HouseholdID.String, "incorrect HouseholdID")
+
+			got.LoadMembers(ms.DB, true)
+			got.LoadDependents(ms.DB, true)
+			got.LoadItems(ms.DB, true)
+			ms.Len(got.Members, 1, "incorrect number of members on new policy")
+			ms.Equal(leaving.ID, got.Members[0].ID, "wrong member moved")
+			ms.Len(got.Dependents, 1, "incorrect number of dependents on new policy")
+			ms.Len(got.Items, 2, "incorrect number of items on new policy")
+
+			policy.LoadMembers(ms.DB, true)
+			policy.LoadItems(ms.DB, true)
+			ms.Len(policy.Members, 1, "incorrect number of members on original policy")
+			ms.Len(policy.Items, 1, "incorrect number of items on original policy")
+			ms.Equal(stayingItem.ID, policy.Items[0].ID, "wrong item remained")
+
+			var h PolicyHistory
+			ms.NoError(ms.DB.Where("policy_id = ? AND field_name = ?", policy.ID, FieldPolicySplitPolicyID).
+				First(&h))
+			ms.Equal(got.ID.String(), h.NewValue, "incorrect split history")
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gobuffalo/events"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+type PolicyStatements []PolicyStatement
+
+// PolicyStatement is a monthly summary of the ledger activity on a policy, with the PDF that was sent to the
+// policy members. Amounts follow the ledger convention: charges are negative and reimbursements are positive.
+type PolicyStatement struct {
+	ID             uuid.UUID    `db:"id"`
+	PolicyID       uuid.UUID    `db:"policy_id" validate:"required"`
+	FileID         uuid.UUID    `db:"file_id" validate:"required"`
+	StatementDate  time.Time    `db:"statement_date" validate:"required"` // first day of the month
+	OpeningBalance api.Currency `db:"opening_balance"`
+	Premiums       api.Currency `db:"premiums"`
+	Adjustments    api.Currency `db:"adjustments"`
+	Refunds        api.Currency `db:"refunds"`
+	ClaimPayouts   api.Currency `db:"claim_payouts"`
+	ClosingBalance api.Currency `db:"closing_balance"`
+	CoveredItems   int          `db:"covered_items" validate:"min=0"`
+	CreatedAt      time.Time    `db:"created_at"`
+	UpdatedAt      time.Time    `db:"updated_at"`
+
+	File   File   `belongs_to:"files" validate:"-"`
+	Policy Policy `belongs_to:"policies" validate:"-"`
+}
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (s *PolicyStatement) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validateModel(s), nil
+}
+
+// Create stores the statement File and then the PolicyStatement record
+func (s *PolicyStatement) Create(tx *pop.Connection) error {
+	s.File.Linked = true
+	if err := s.File.Store(tx); err != nil {
+		return err
+	}
+	s.FileID = s.File.ID
+
+	return create(tx, s)
+}
+
+func (s *PolicyStatement) FindByID(tx *pop.Connection, id uuid.UUID) error {
+	return tx.Find(s, id)
+}
+
+// LoadFile - a simple wrapper method for loading the file on the struct
+func (s *PolicyStatement) LoadFile(tx *pop.Connection, reload bool) {
+	if s.File.ID == uuid.Nil || reload {
+		if err := tx.Load(s, "File"); err != nil {
+			panic("database error loading PolicyStatement.File, " + err.Error())
+		}
+	}
+}
+
+// LoadPolicy - a simple wrapper method for loading the policy on the struct
+func (s *PolicyStatement) LoadPolicy(tx *pop.Connection, reload bool) {
+	if s.Policy.ID == uuid.Nil || reload {
+		if err := tx.Load(s, "Policy"); err != nil {
+			panic("database error loading PolicyStatement.Policy, " + err.Error())
+		}
+	}
+}
+
+// FindByPolicyID loads the statements of a policy, most recent first
+func (s *PolicyStatements) FindByPolicyID(tx *pop.Connection, policyID uuid.UUID) error {
+	err := tx.Where("policy_id = ?", policyID).Order("statement_date desc").All(s)
+	return appErrorFromDB(err, api.ErrorQueryFailure)
+}
+
+// NewPolicyStatement creates the statement of a policy for the given month, stores its PDF, and triggers the
+// notification to the policy members. Ledger entries are assigned to a month by the date they were added to the
+// ledger, so a closed month does not change when entries are later entered into the accounting system.
+func NewPolicyStatement(ctx context.Context, policy Policy, month, year int) (PolicyStatement, error) {
+	if err := validateMonthYearForReport(month, year); err != nil {
+		return PolicyStatement{}, err
+	}
+
+	tx := Tx(ctx)
+
+	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(0, 1, 0)
+
+	var entries LedgerEntries
+	if err := tx.Where("policy_id = ? AND date_submitted >= ? AND date_submitted < ?", policy.ID, startDate, endDate).
+		Order("date_submitted asc").All(&entries); err != nil {
+		return PolicyStatement{}, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	openingBalance, err := policy.ledgerBalance(tx, startDate)
+	if err != nil {
+		return PolicyStatement{}, err
+	}
+
+	items, err := policy.itemsCoveredBetween(tx, startDate, endDate)
+	if err != nil {
+		return PolicyStatement{}, err
+	}
+
+	s := PolicyStatement{
+		PolicyID:       policy.ID,
+		StatementDate:  startDate,
+		OpeningBalance: openingBalance,
+		ClosingBalance: openingBalance,
+		CoveredItems:   len(items),
+		Policy:         policy,
+	}
+	for _, e := range entries {
+		s.addEntry(e)
+	}
+
+	content, err := renderPolicyStatement(tx, s, entries, items)
+	if err != nil {
+		return PolicyStatement{}, api.NewAppError(err, api.ErrorUnableToStoreFile, api.CategoryInternal)
+	}
+
+	s.File = File{
+		Name: fmt.Sprintf("%s_statement_%s_%s.pdf",
+			domain.Env.AppName, policy.Name, startDate.Format("2006-01")),
+		Content:     content,
+		ContentType: domain.ContentPDF,
+		CreatedByID: CurrentUser(ctx).ID,
+	}
+
+	if err := s.Create(tx); err != nil {
+		return PolicyStatement{}, err
+	}
+
+	emitEvent(events.Event{
+		Kind:    domain.EventApiPolicyStatementCreated,
+		Message: fmt.Sprintf("Policy statement created for %s", startDate.Format("January 2006")),
+		Payload: events.Payload{domain.EventPayloadID: s.ID},
+	})
+
+	return s, nil
+}
+
+// FindNeedingStatement loads the policies that had coverage or ledger activity during the given month and do not
+// yet have a statement for it
+func (p *Policies) FindNeedingStatement(tx *pop.Connection, month, year int) error {
+	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(0, 1, 0)
+
+	err := tx.Where("id NOT IN (SELECT policy_id FROM policy_statements WHERE statement_date = ?)", startDate).
+		Where("(id IN (SELECT policy_id FROM ledger_entries WHERE date_submitted >= ? AND date_submitted < ?) "+
+			"OR id IN (SELECT policy_id FROM items WHERE coverage_status IN (?, ?) AND coverage_start_date < ? "+
+			"AND (coverage_end_date IS NULL OR coverage_end_date >= ?)))",
+			startDate, endDate, api.ItemCoverageStatusApproved, api.ItemCoverageStatusInactive, endDate, startDate).
+		Order("name asc").All(p)
+	return appErrorFromDB(err, api.ErrorQueryFailure)
+}
+
+func (s *PolicyStatement) addEntry(e LedgerEntry) {
+	switch e.Type {
+	case LedgerEntryTypeNewCoverage, LedgerEntryTypeCoverageChange, LedgerEntryTypeCoverageRenewal:
+		s.Premiums += e.Amount
+	case LedgerEntryTypeCoverageRefund:
+		s.Refunds += e.Amount
+	case LedgerEntryTypeClaim, LedgerEntryTypeClaimAdjustment:
+		s.ClaimPayouts += e.Amount
+	default:
+		s.Adjustments += e.Amount
+	}
+	s.ClosingBalance += e.Amount
+}
+
+// ledgerBalance returns the sum of the ledger entries of the policy that were added before the given date
+func (p *Policy) ledgerBalance(tx *pop.Connection, before time.Time) (api.Currency, error) {
+	var entries LedgerEntries
+	if err := tx.Where("policy_id = ? AND date_submitted < ?", p.ID, before).All(&entries); err != nil {
+		return 0, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	balance := api.Currency(0)
+	for _, e := range entries {
+		balance += e.Amount
+	}
+	return balance, nil
+}
+
+// itemsCoveredBetween returns the items of the policy that had coverage at some time in the given date range
+func (p *Policy) itemsCoveredBetween(tx *pop.Connection, start, end time.Time) (Items, error) {
+	var items Items
+	err := tx.Where("policy_id = ?", p.ID).
+		Where("coverage_status IN (?, ?)", api.ItemCoverageStatusApproved, api.ItemCoverageStatusInactive).
+		Where("coverage_start_date < ?", end).
+		Where("(coverage_end_date IS NULL OR coverage_end_date >= ?)", start).
+		Order("name asc").All(&items)
+	if err != nil {
+		return nil, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	return items, nil
+}
+
+func renderPolicyStatement(tx *pop.Connection, s PolicyStatement, entries LedgerEntries, items Items) ([]byte, error) {
+	doc := newPDFDocument("Policy Statement")
+
+	doc.heading("Policy")
+	doc.labeledValue("Policy name", s.Policy.Name)
+	doc.labeledValue("Policy type", string(s.Policy.Type))
+	doc.labeledValue("Statement period", s.StatementDate.Format("January 2006"))
+
+	doc.heading("Summary")
+	doc.paragraph("Charges to the policy are shown as negative amounts and reimbursements as positive amounts.")
+	doc.labeledValue("Opening balance", s.OpeningBalance.Dollars())
+	doc.labeledValue("Premiums", s.Premiums.Dollars())
+	doc.labeledValue("Adjustments", s.Adjustments.Dollars())
+	doc.labeledValue("Refunds", s.Refunds.Dollars())
+	doc.labeledValue("Claim payouts", s.ClaimPayouts.Dollars())
+	doc.labeledValue("Closing balance", s.ClosingBalance.Dollars())
+
+	doc.heading("Transactions")
+	if len(entries) == 0 {
+		doc.paragraph("There were no transactions on this policy during the month.")
+	} else {
+		rows := make([][]string, len(entries))
+		for i, e := range entries {
+			rows[i] = []string{
+				pdfDate(e.DateSubmitted),
+				e.Type.Description(e.ClaimPayoutOption, e.Amount),
+				e.getItemName(tx),
+				e.Amount.Dollars(),
+			}
+		}
+		doc.table([]string{"Date", "Description", "Item", "Amount"}, []float64{30, 60, 60, 40}, rows)
+	}
+
+	doc.heading("Covered items")
+	if len(items) == 0 {
+		doc.paragraph("No items were covered during the month.")
+	} else {
+		rows := make([][]string, len(items))
+		for i, item := range items {
+			rows[i] = []string{
+				item.Name,
+				item.GetAccountablePersonName(tx).String(),
+				string(item.CoverageStatus),
+				api.Currency(item.CoverageAmount).Dollars(),
+			}
+		}
+		doc.table([]string{"Item", "Accountable person", "Status", "Coverage"}, []float64{60, 60, 30, 40}, rows)
+	}
+
+	return doc.render()
+}
+
+func (s *PolicyStatement) ConvertToAPI(tx *pop.Connection) api.PolicyStatement {
+	s.LoadFile(tx, false)
+
+	return api.PolicyStatement{
+		ID:             s.ID,
+		PolicyID:       s.PolicyID,
+		Month:          int(s.StatementDate.Month()),
+		Year:           s.StatementDate.Year(),
+		OpeningBalance: s.OpeningBalance,
+		Premiums:       s.Premiums,
+		Adjustments:    s.Adjustments,
+		Refunds:        s.Refunds,
+		ClaimPayouts:   s.ClaimPayouts,
+		ClosingBalance: s.ClosingBalance,
+		CoveredItems:   s.CoveredItems,
+		File:           s.File.ConvertToAPI(tx),
+		CreatedAt:      s.CreatedAt,
+	}
+}
+
+func (s *PolicyStatements) ConvertToAPI(tx *pop.Connection) api.PolicyStatements {
+	statements := make(api.PolicyStatements, len(*s))
+	for i, ss := range *s {
+		statements[i] = ss.ConvertToAPI(tx)
+	}
+	return statements
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+func (ms *ModelSuite) TestNewPolicyStatement() {
+	f := CreateLedgerFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 3})
+	policy := f.Policies[0]
+	ctx := CreateTestContext(f.Users[0])
+
+	march := time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)
+	april := time.Date(2021, 4, 10, 0, 0, 0, 0, time.UTC)
+
+	entries := f.LedgerEntries
+	entries[0].DateSubmitted = march
+	entries[1].DateSubmitted = april
+	entries[2].DateSubmitted = april
+	entries[2].Type = LedgerEntryTypeCoverageRefund
+	entries[2].Amount = -entries[2].Amount
+	for i := range entries {
+		Must(ms.DB.Update(&entries[i]))
+	}
+
+	// the first item was covered in April, the others only later
+	for i, start := range []time.Time{march, april.AddDate(0, 1, 0), april.AddDate(0, 2, 0)} {
+		f.Items[i].CoverageStartDate = start
+		Must(ms.DB.UpdateColumns(&f.Items[i], "coverage_start_date"))
+	}
+
+	tests := []struct {
+		name    string
+		month   int
+		year    int
+		want    PolicyStatement
+		wantErr *api.AppError
+	}{
+		{
+			name:    "invalid month",
+			month:   13,
+			year:    2021,
+			wantErr: &api.AppError{Key: api.ErrorInvalidDate, Category: api.CategoryUser},
+		},
+		{
+			name:  "April",
+			month: 4,
+			year:  2021,
+			want: PolicyStatement{
+				OpeningBalance: entries[0].Amount,
+				Premiums:       entries[1].Amount,
+				Refunds:        entries[2].Amount,
+				ClosingBalance: entries[0].Amount + entries[1].Amount + entries[2].Amount,
+				CoveredItems:   1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			eventDetected := false
+			deleteFn, err := RegisterEventDetector(domain.EventApiPolicyStatementCreated, &eventDetected)
+			ms.NoError(err)
+			defer deleteFn()
+
+			got, err := NewPolicyStatement(ctx, policy, tt.month, tt.year)
+			if tt.wantErr != nil {
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+			ms.True(eventDetected, "expected %s event", domain.EventApiPolicyStatementCreated)
+
+			ms.Equal(time.Date(tt.year, time.Month(tt.month), 1, 0, 0, 0, 0, time.UTC), got.StatementDate,
+				"incorrect StatementDate")
+			ms.Equal(tt.want.OpeningBalance, got.OpeningBalance, "incorrect OpeningBalance")
+			ms.Equal(tt.want.Premiums, got.Premiums, "incorrect Premiums")
+			ms.Equal(api.Currency(0), got.Adjustments, "incorrect Adjustments")
+			ms.Equal(tt.want.Refunds, got.Refunds, "incorrect Refunds")
+			ms.Equal(api.Currency(0), got.ClaimPayouts, "incorrect ClaimPayouts")
+			ms.Equal(tt.want.ClosingBalance, got.ClosingBalance, "incorrect ClosingBalance")
+			ms.Equal(tt.want.CoveredItems, got.CoveredItems, "incorrect CoveredItems")
+			ms.Equal(domain.ContentPDF, got.File.ContentType, "incorrect file content type")
+			ms.True(got.File.Linked, "statement file should be linked")
+		})
+	}
+}
+
+func (ms *ModelSuite) TestPolicies_FindNeedingStatement() {
+	f := CreateLedgerFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 3, ItemsPerPolicy: 1})
+	ctx := CreateTestContext(f.Users[0])
+
+	april := time.Date(2021, 4, 10, 0, 0, 0, 0, time.UTC)
+
+	// the first two policies have activity in April; the third does not
+	for i := 0; i < 2; i++ {
+		f.LedgerEntries[i].DateSubmitted = april
+		Must(ms.DB.Update(&f.LedgerEntries[i]))
+	}
+
+	// the first policy already has its statement
+	_, err := NewPolicyStatement(ctx, f.Policies[0], 4, 2021)
+	ms.NoError(err)
+
+	var policies Policies
+	ms.NoError(policies.FindNeedingStatement(ms.DB, 4, 2021))
+	ms.Len(policies, 1, "incorrect number of policies")
+	ms.Equal(f.Policies[1].ID, policies[0].ID, "incorrect policy")
+}
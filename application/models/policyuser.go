@@ -17,12 +17,19 @@ import (
 	"github.com/silinternational/cover-api/log"
 )
 
+var ValidPolicyUserRoles = map[api.PolicyUserRole]struct{}{
+	api.PolicyUserRoleOwner:  {},
+	api.PolicyUserRoleMember: {},
+	api.PolicyUserRoleViewer: {},
+}
+
 type PolicyUsers []PolicyUser
 
 type PolicyUser struct {
-	ID       uuid.UUID `db:"id"`
-	PolicyID uuid.UUID `db:"policy_id"`
-	UserID   uuid.UUID `db:"user_id"`
+	ID       uuid.UUID          `db:"id"`
+	PolicyID uuid.UUID          `db:"policy_id"`
+	UserID   uuid.UUID          `db:"user_id"`
+	Role     api.PolicyUserRole `db:"role" validate:"policyUserRole"`
 
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
@@ -33,8 +40,11 @@ func (p *PolicyUser) Validate(tx *pop.Connection) (*validate.Errors, error) {
 	return validateModel(p), nil
 }
 
-// Create stores the data as a new record in the database.
+// Create stores the data as a new record in the database. If no role is given, the user is made an owner.
 func (p *PolicyUser) Create(tx *pop.Connection) error {
+	if p.Role == "" {
+		p.Role = api.PolicyUserRoleOwner
+	}
 	return create(tx, p)
 }
 
@@ -46,7 +56,8 @@ func (p *PolicyUser) FindByID(tx *pop.Connection, id uuid.UUID) error {
 	return tx.Find(p, id)
 }
 
-// IsActorAllowedTo ensure the actor is either an admin, or a member of this policy to perform any permission
+// IsActorAllowedTo ensure the actor is either an admin, or a member of this policy. Only an owner of the
+// policy may remove another member.
 func (p *PolicyUser) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permission, sub SubResource, r *http.Request) bool {
 	if actor.IsAdmin() {
 		return true
@@ -58,15 +69,22 @@ func (p *PolicyUser) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permi
 		return false
 	}
 
-	policy.LoadMembers(tx, false)
+	role, isMember := policy.memberRole(tx, actor.ID)
+	if !isMember {
+		return false
+	}
 
-	for _, m := range policy.Members {
-		if m.ID == actor.ID {
+	switch perm {
+	case PermissionView, PermissionList:
+		return true
+	case PermissionDelete:
+		// any member may leave the policy
+		if p.UserID == actor.ID {
 			return true
 		}
 	}
 
-	return false
+	return role == api.PolicyUserRoleOwner
 }
 
 func (p *PolicyUser) FindByPolicyAndUserIDs(tx *pop.Connection, policyID, userID uuid.UUID) error {
@@ -90,6 +108,12 @@ func (p *PolicyUser) Delete(ctx context.Context) error {
 		return err
 	}
 
+	if p.isLastOwner(pUsers) {
+		err := api.NewAppError(errors.New("may not delete the last of a policy's owners"),
+			api.ErrorPolicyUserIsTheLastOwner, api.CategoryForbidden)
+		return err
+	}
+
 	// update all related items with a null PolicyUserID
 	items := p.RelatedItems(tx)
 	for _, i := range items {
@@ -118,3 +142,62 @@ func (p *PolicyUser) RelatedItems(tx *pop.Connection) Items {
 
 	return items
 }
+
+// UpdateRole changes the role of the policy member. A policy must always have at least one owner.
+func (p *PolicyUser) UpdateRole(ctx context.Context, role api.PolicyUserRole) error {
+	tx := Tx(ctx)
+
+	if _, ok := ValidPolicyUserRoles[role]; !ok {
+		err := fmt.Errorf("invalid policy user role: %s", role)
+		return api.NewAppError(err, api.ErrorPolicyUserRole, api.CategoryUser)
+	}
+	if role == p.Role {
+		return nil
+	}
+
+	var pUsers PolicyUsers
+	if err := tx.Where("policy_id = ?", p.PolicyID).All(&pUsers); err != nil {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	if p.isLastOwner(pUsers) {
+		err := errors.New("may not change the role of the last of a policy's owners")
+		return api.NewAppError(err, api.ErrorPolicyUserIsTheLastOwner, api.CategoryUser)
+	}
+
+	var policy Policy
+	if err := policy.FindByID(tx, p.PolicyID); err != nil {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	var user User
+	if err := user.FindByID(tx, p.UserID); err != nil {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	history := policy.NewHistory(ctx, api.HistoryActionUpdate, FieldUpdate{
+		FieldName: FieldPolicyMemberRole,
+		OldValue:  fmt.Sprintf("%s: %s", user.Name(), p.Role),
+		NewValue:  fmt.Sprintf("%s: %s", user.Name(), role),
+	})
+	if err := history.Create(tx); err != nil {
+		return err
+	}
+
+	p.Role = role
+	if err := tx.UpdateColumns(p, "role", "updated_at"); err != nil {
+		return appErrorFromDB(err, api.ErrorUpdateFailure)
+	}
+	return nil
+}
+
+// isLastOwner returns true if this is the only owner among the given members of the policy
+func (p *PolicyUser) isLastOwner(pUsers PolicyUsers) bool {
+	if p.Role != api.PolicyUserRoleOwner {
+		return false
+	}
+	for _, pu := range pUsers {
+		if pu.ID != p.ID && pu.Role == api.PolicyUserRoleOwner {
+			return false
+		}
+	}
+	return true
+}
@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
 )
 
 func (ms *ModelSuite) TestPolicyUser_Delete() {
@@ -77,3 +79,90 @@ func (ms *ModelSuite) TestPolicyUser_Delete() {
 		})
 	}
 }
+
+func (ms *ModelSuite) TestPolicyUser_UpdateRole() {
+	f := CreatePolicyFixtures(ms.DB, FixturesConfig{UsersPerPolicy: 2})
+	policy := f.Policies[0]
+	ctx := CreateTestContext(f.Users[0])
+
+	polUsers := policy.GetPolicyUsers(ms.DB, false)
+	first := polUsers[0]
+	second := polUsers[1]
+
+	tests := []struct {
+		name    string
+		polUser *PolicyUser
+		role    api.PolicyUserRole
+		wantErr *api.AppError
+	}{
+		{
+			name:    "invalid role",
+			polUser: &first,
+			role:    "Boss",
+			wantErr: &api.AppError{Key: api.ErrorPolicyUserRole, Category: api.CategoryUser},
+		},
+		{
+			name:    "make viewer",
+			polUser: &first,
+			role:    api.PolicyUserRoleViewer,
+		},
+		{
+			name:    "last owner",
+			polUser: &second,
+			role:    api.PolicyUserRoleMember,
+			wantErr: &api.AppError{Key: api.ErrorPolicyUserIsTheLastOwner, Category: api.CategoryUser},
+		},
+		{
+			name:    "make owner again",
+			polUser: &first,
+			role:    api.PolicyUserRoleOwner,
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			err := tt.polUser.UpdateRole(ctx, tt.role)
+			if tt.wantErr != nil {
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+
+			var dbPolUser PolicyUser
+			ms.NoError(dbPolUser.FindByID(ms.DB, tt.polUser.ID))
+			ms.Equal(tt.role, dbPolUser.Role, "incorrect role")
+
+			var h PolicyHistory
+			ms.NoError(ms.DB.Where("policy_id = ? AND field_name = ?", policy.ID, FieldPolicyMemberRole).
+				Order("created_at desc").First(&h))
+			ms.Contains(h.NewValue, string(tt.role), "incorrect history")
+		})
+	}
+}
+
+func (ms *ModelSuite) Test_isPolicyActionAllowed() {
+	tests := []struct {
+		name string
+		role api.PolicyUserRole
+		perm Permission
+		sub  SubResource
+		want bool
+	}{
+		{name: "owner update", role: api.PolicyUserRoleOwner, perm: PermissionUpdate, want: true},
+		{name: "owner invite", role: api.PolicyUserRoleOwner, perm: PermissionCreate, sub: api.ResourceMembers, want: true},
+		{name: "member view", role: api.PolicyUserRoleMember, perm: PermissionView, want: true},
+		{name: "member add item", role: api.PolicyUserRoleMember, perm: PermissionCreate, sub: "items", want: true},
+		{name: "member update", role: api.PolicyUserRoleMember, perm: PermissionUpdate, want: false},
+		{name: "member invite", role: api.PolicyUserRoleMember, perm: PermissionCreate, sub: api.ResourceMembers, want: false},
+		{name: "member close", role: api.PolicyUserRoleMember, perm: PermissionCreate, sub: api.ResourceClose, want: false},
+		{name: "member list members", role: api.PolicyUserRoleMember, perm: PermissionList, sub: api.ResourceMembers, want: true},
+		{name: "member view members", role: api.PolicyUserRoleMember, perm: PermissionView, sub: api.ResourceMembers, want: true},
+		{name: "member remove member", role: api.PolicyUserRoleMember, perm: PermissionDelete, sub: api.ResourceMembers, want: false},
+		{name: "viewer view", role: api.PolicyUserRoleViewer, perm: PermissionView, sub: "items", want: true},
+		{name: "viewer add item", role: api.PolicyUserRoleViewer, perm: PermissionCreate, sub: "items", want: false},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			ms.Equal(tt.want, isPolicyActionAllowed(tt.role, tt.perm, tt.sub))
+		})
+	}
+}
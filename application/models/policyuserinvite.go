@@ -1,9 +1,11 @@
 package models
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/gobuffalo/events"
@@ -14,6 +16,7 @@ import (
 
 	"github.com/silinternational/cover-api/api"
 	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/log"
 )
 
 // PolicyUserInvite represents an invite for a policy co-manager
@@ -27,9 +30,13 @@ type PolicyUserInvite struct {
 	InviterName    string     `db:"inviter_name"`
 	InviterEmail   string     `db:"inviter_email"`
 	InviterMessage string     `db:"inviter_message"`
+	ExpiresAt      nulls.Time `db:"expires_at"`
 	CreatedAt      time.Time  `db:"created_at"`
 	UpdatedAt      time.Time  `db:"updated_at"`
 
+	// Role is given to the invitee when the invite is accepted
+	Role api.PolicyUserRole `db:"role" validate:"policyUserRole"`
+
 	Policy Policy `belongs_to:"policies" validate:"-"`
 }
 
@@ -62,9 +69,15 @@ func (i *PolicyUserInvite) ValidateUpdate(tx *pop.Connection) (*validate.Errors,
 	return validate.NewErrors(), nil
 }
 
-// Create new invite
+// Create new invite. If no role is given, the invitee is made a member.
 // emits domain.EventApiPolicyUserInviteCreated event
 func (i *PolicyUserInvite) Create(tx *pop.Connection) error {
+	if i.Role == "" {
+		i.Role = api.PolicyUserRoleMember
+	}
+	if !i.ExpiresAt.Valid {
+		i.ExpiresAt = nulls.NewTime(newInviteExpiration(time.Now().UTC()))
+	}
 	if err := create(tx, i); err != nil {
 		return err
 	}
@@ -98,6 +111,30 @@ func (i *PolicyUserInvite) FindByID(tx *pop.Connection, id uuid.UUID) error {
 	return tx.Find(i, id)
 }
 
+func (i *PolicyUserInvite) GetID() uuid.UUID {
+	return i.ID
+}
+
+// IsActorAllowedTo ensures the actor is an admin or a member of the invite's policy. Only an owner of the
+// policy may resend, extend or revoke an invite.
+func (i *PolicyUserInvite) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permission, sub SubResource, r *http.Request) bool {
+	if actor.IsAdmin() {
+		return true
+	}
+
+	var policy Policy
+	if err := policy.FindByID(tx, i.PolicyID); err != nil {
+		log.Error("failed to load policy for invite:", err)
+		return false
+	}
+
+	role, isMember := policy.memberRole(tx, actor.ID)
+	if !isMember {
+		return false
+	}
+	return perm == PermissionView || role == api.PolicyUserRoleOwner
+}
+
 func (i *PolicyUserInvite) FindByEmailAndPolicyID(tx *pop.Connection, email string, policyID uuid.UUID) error {
 	return tx.Where("email = ? and policy_id = ?", email, policyID).First(i)
 }
@@ -136,9 +173,14 @@ func (i *PolicyUserInvite) Accept(tx *pop.Connection, code string, user User) er
 		}
 	}
 
+	role := i.Role
+	if role == "" {
+		role = api.PolicyUserRoleMember
+	}
 	policyUser := PolicyUser{
 		PolicyID: i.PolicyID,
 		UserID:   user.ID,
+		Role:     role,
 	}
 
 	if err := policyUser.Create(tx); err != nil {
@@ -151,9 +193,7 @@ func (i *PolicyUserInvite) Accept(tx *pop.Connection, code string, user User) er
 // DestroyIfExpired returns nil if the invite is not too old or already accepted.
 // Otherwise, it attempts to destroy it and returns an error.
 func (i *PolicyUserInvite) DestroyIfExpired(tx *pop.Connection) error {
-	now := time.Now().UTC()
-	cutoff := now.Add(time.Duration(-domain.Env.InviteLifetimeDays) * domain.DurationDay)
-	if i.CreatedAt.After(cutoff) {
+	if !i.IsExpired(time.Now().UTC()) {
 		return nil
 	}
 
@@ -171,12 +211,133 @@ func (i *PolicyUserInvite) DestroyIfExpired(tx *pop.Connection) error {
 	)
 }
 
-func (i *PolicyUserInvite) ConvertToAPI() api.PolicyUserInvite {
+// Expiration returns the time after which the invite can no longer be accepted. Invites created before
+// expiration times were recorded expire InviteLifetimeDays after they were created.
+func (i *PolicyUserInvite) Expiration() time.Time {
+	if i.ExpiresAt.Valid {
+		return i.ExpiresAt.Time
+	}
+	return newInviteExpiration(i.CreatedAt)
+}
+
+// IsExpired returns true if the invite can no longer be accepted at the given time
+func (i *PolicyUserInvite) IsExpired(now time.Time) bool {
+	return now.After(i.Expiration())
+}
+
+// inviteRole returns the role requested for the invitee, or PolicyUserRoleMember if none is given
+func inviteRole(invite api.PolicyUserInviteCreate) (api.PolicyUserRole, error) {
+	if invite.Role == "" {
+		return api.PolicyUserRoleMember, nil
+	}
+	if _, ok := ValidPolicyUserRoles[invite.Role]; !ok {
+		err := fmt.Errorf("invalid policy user role: %s", invite.Role)
+		return "", api.NewAppError(err, api.ErrorPolicyUserRole, api.CategoryUser)
+	}
+	return invite.Role, nil
+}
+
+func newInviteExpiration(from time.Time) time.Time {
+	return from.Add(time.Duration(domain.Env.InviteLifetimeDays) * domain.DurationDay)
+}
+
+// Resend sends the invite email again. An expired invite must be extended instead.
+func (i *PolicyUserInvite) Resend(ctx context.Context) error {
+	if i.IsExpired(time.Now().UTC()) {
+		err := errors.New("cannot resend an expired invite, ID: " + i.ID.String())
+		return api.NewAppError(err, api.ErrorInviteExpired, api.CategoryUser)
+	}
+
+	if err := i.writeHistory(ctx, api.HistoryActionUpdate, FieldUpdate{
+		FieldName: FieldPolicyInviteSent,
+		NewValue:  i.Email,
+	}); err != nil {
+		return err
+	}
+
+	emitEvent(events.Event{
+		Kind:    domain.EventApiPolicyUserInviteResent,
+		Message: "PolicyUserInvite resent",
+		Payload: events.Payload{domain.EventPayloadID: i.ID},
+	})
+	return nil
+}
+
+// Extend resets the expiration of the invite to InviteLifetimeDays from now and sends the invite email again
+func (i *PolicyUserInvite) Extend(ctx context.Context) error {
+	tx := Tx(ctx)
+
+	oldExpiration := i.Expiration()
+	i.ExpiresAt = nulls.NewTime(newInviteExpiration(time.Now().UTC()))
+	if err := tx.UpdateColumns(i, "expires_at", "updated_at"); err != nil {
+		return appErrorFromDB(err, api.ErrorUpdateFailure)
+	}
+
+	if err := i.writeHistory(ctx, api.HistoryActionUpdate, FieldUpdate{
+		FieldName: FieldPolicyInviteExpires,
+		OldValue:  fmt.Sprintf("%s: %s", i.Email, oldExpiration.Format(domain.DateFormat)),
+		NewValue:  fmt.Sprintf("%s: %s", i.Email, i.ExpiresAt.Time.Format(domain.DateFormat)),
+	}); err != nil {
+		return err
+	}
+
+	emitEvent(events.Event{
+		Kind:    domain.EventApiPolicyUserInviteExtended,
+		Message: "PolicyUserInvite extended",
+		Payload: events.Payload{domain.EventPayloadID: i.ID},
+	})
+	return nil
+}
 
+// Revoke cancels the invite and notifies the invitee. Since the invite is destroyed, the event payload
+// carries the details needed for the notification.
+func (i *PolicyUserInvite) Revoke(ctx context.Context) error {
+	tx := Tx(ctx)
+
+	if err := i.writeHistory(ctx, api.HistoryActionUpdate, FieldUpdate{
+		FieldName: FieldPolicyInvites,
+		OldValue:  i.Email,
+	}); err != nil {
+		return err
+	}
+
+	if err := i.Destroy(tx); err != nil {
+		return err
+	}
+
+	emitEvent(events.Event{
+		Kind:    domain.EventApiPolicyUserInviteRevoked,
+		Message: "PolicyUserInvite revoked",
+		Payload: events.Payload{
+			domain.EventPayloadID: i.ID,
+			"policy_id":           i.PolicyID,
+			"email":               i.Email,
+			"invitee_name":        i.InviteeName,
+			"inviter_name":        i.InviterName,
+		},
+	})
+	return nil
+}
+
+func (i *PolicyUserInvite) writeHistory(ctx context.Context, action string, fieldUpdate FieldUpdate) error {
+	tx := Tx(ctx)
+	i.LoadPolicy(tx, false)
+	history := i.Policy.NewHistory(ctx, action, fieldUpdate)
+	return history.Create(tx)
+}
+
+func (i *PolicyUserInvite) ConvertToAPI() api.PolicyUserInvite {
 	return api.PolicyUserInvite{
-		Email:       i.Email,
-		Name:        i.InviteeName,
-		EmailSentAt: convertTimeToAPI(i.EmailSentAt),
+		ID:             i.ID,
+		Email:          i.Email,
+		Name:           i.InviteeName,
+		EmailSentAt:    convertTimeToAPI(i.EmailSentAt),
+		EmailSendCount: i.EmailSendCount,
+		InviterName:    i.InviterName,
+		Role:           i.Role,
+		ExpiresAt:      i.Expiration(),
+		IsExpired:      i.IsExpired(time.Now().UTC()),
+		CreatedAt:      i.CreatedAt,
 	}
 }
 
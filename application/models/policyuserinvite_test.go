@@ -2,6 +2,7 @@ package models
 
 import (
 	"errors"
+	"testing"
 	"time"
 
 	"github.com/gobuffalo/nulls"
@@ -108,3 +109,174 @@ func (ms *ModelSuite) TestDestroyIfExpired() {
 		})
 	}
 }
+
+func (ms *ModelSuite) TestPolicyUserInvite_Resend() {
+	f := CreatePolicyFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 2})
+	ctx := CreateTestContext(f.Users[0])
+
+	now := time.Now().UTC()
+	cutoff := now.Add(time.Duration(-domain.Env.InviteLifetimeDays) * domain.DurationDay)
+
+	current := CreateUniqueInvite(now, f.Policies[0].ID)
+	ms.NoError(ms.DB.Create(&current))
+	expired := CreateUniqueInvite(cutoff.Add(-time.Hour), f.Policies[1].ID)
+	ms.NoError(ms.DB.Create(&expired))
+
+	tests := []struct {
+		name    string
+		invite  PolicyUserInvite
+		wantErr *api.AppError
+	}{
+		{
+			name:    "expired",
+			invite:  expired,
+			wantErr: &api.AppError{Key: api.ErrorInviteExpired, Category: api.CategoryUser},
+		},
+		{
+			name:   "good",
+			invite: current,
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			eventDetected := false
+			deleteFn, err := RegisterEventDetector(domain.EventApiPolicyUserInviteResent, &eventDetected)
+			ms.NoError(err)
+			defer deleteFn()
+
+			err = tt.invite.Resend(ctx)
+			if tt.wantErr != nil {
+				ms.EqualAppError(*tt.wantErr, err)
+				ms.False(eventDetected, "unexpected %s event", domain.EventApiPolicyUserInviteResent)
+				return
+			}
+			ms.NoError(err)
+			ms.True(eventDetected, "expected %s event", domain.EventApiPolicyUserInviteResent)
+
+			var h PolicyHistory
+			ms.NoError(ms.DB.Where("policy_id = ? AND field_name = ?", tt.invite.PolicyID, FieldPolicyInviteSent).
+				First(&h))
+			ms.Equal(tt.invite.Email, h.NewValue, "incorrect history")
+		})
+	}
+}
+
+func (ms *ModelSuite) TestPolicyUserInvite_Extend() {
+	f := CreatePolicyFixtures(ms.DB, FixturesConfig{})
+	ctx := CreateTestContext(f.Users[0])
+
+	now := time.Now().UTC()
+	cutoff := now.Add(time.Duration(-domain.Env.InviteLifetimeDays) * domain.DurationDay)
+
+	invite := CreateUniqueInvite(cutoff.Add(-time.Hour), f.Policies[0].ID)
+	ms.NoError(ms.DB.Create(&invite))
+	ms.True(invite.IsExpired(now), "fixture invite should be expired")
+
+	ms.NoError(invite.Extend(ctx))
+
+	var dbInvite PolicyUserInvite
+	ms.NoError(dbInvite.FindByID(ms.DB, invite.ID))
+	ms.True(dbInvite.ExpiresAt.Valid, "ExpiresAt was not set")
+	ms.False(dbInvite.IsExpired(now), "invite should no longer be expired")
+	ms.WithinDuration(newInviteExpiration(now), dbInvite.ExpiresAt.Time, time.Minute, "incorrect ExpiresAt")
+
+	var h PolicyHistory
+	ms.NoError(ms.DB.Where("policy_id = ? AND field_name = ?", invite.PolicyID, FieldPolicyInviteExpires).First(&h))
+	ms.Contains(h.NewValue, invite.Email, "incorrect history")
+}
+
+func (ms *ModelSuite) TestPolicyUserInvite_Revoke() {
+	f := CreatePolicyFixtures(ms.DB, FixturesConfig{})
+	ctx := CreateTestContext(f.Users[0])
+
+	invite := CreateUniqueInvite(time.Now().UTC(), f.Policies[0].ID)
+	ms.NoError(ms.DB.Create(&invite))
+
+	eventDetected := false
+	deleteFn, err := RegisterEventDetector(domain.EventApiPolicyUserInviteRevoked, &eventDetected)
+	ms.NoError(err)
+	defer deleteFn()
+
+	ms.NoError(invite.Revoke(ctx))
+	ms.True(eventDetected, "expected %s event", domain.EventApiPolicyUserInviteRevoked)
+
+	count, err := ms.DB.Where("id = ?", invite.ID).Count(&PolicyUserInvite{})
+	ms.NoError(err)
+	ms.Equal(0, count, "invite was not destroyed")
+
+	var h PolicyHistory
+	ms.NoError(ms.DB.Where("policy_id = ? AND field_name = ?", invite.PolicyID, FieldPolicyInvites).First(&h))
+	ms.Equal(invite.Email, h.OldValue, "incorrect history")
+}
+
+func (ms *ModelSuite) TestPolicyUserInvite_Accept() {
+	f := CreatePolicyFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 1})
+	policy := f.Policies[0]
+
+	tests := []struct {
+		name     string
+		role     api.PolicyUserRole
+		wantRole api.PolicyUserRole
+	}{
+		{name: "default", wantRole: api.PolicyUserRoleMember},
+		{name: "viewer", role: api.PolicyUserRoleViewer, wantRole: api.PolicyUserRoleViewer},
+		{name: "owner", role: api.PolicyUserRoleOwner, wantRole: api.PolicyUserRoleOwner},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			invite := CreateUniqueInvite(time.Now().UTC(), policy.ID)
+			invite.Role = tt.role
+			ms.NoError(invite.Create(ms.DB))
+
+			user := CreateUserFixtures(ms.DB, 1).Users[0]
+			user.StaffID = nulls.String{}
+
+			var accepted PolicyUserInvite
+			ms.NoError(accepted.Accept(ms.DB, invite.ID.String(), user))
+
+			var pu PolicyUser
+			ms.NoError(ms.DB.Where("policy_id = ? AND user_id = ?", policy.ID, user.ID).First(&pu))
+			ms.Equal(tt.wantRole, pu.Role, "invitee should be given the role of the invite")
+		})
+	}
+}
+
+func (ms *ModelSuite) TestPolicy_NewTeamInvite_Role() {
+	f := CreatePolicyFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 1})
+	policy := f.Policies[0]
+	inviter := f.Users[0]
+
+	tests := []struct {
+		name     string
+		role     api.PolicyUserRole
+		wantRole api.PolicyUserRole
+		wantErr  *api.AppError
+	}{
+		{name: "default", wantRole: api.PolicyUserRoleMember},
+		{name: "viewer", role: api.PolicyUserRoleViewer, wantRole: api.PolicyUserRoleViewer},
+		{
+			name:    "invalid",
+			role:    "Boss",
+			wantErr: &api.AppError{Key: api.ErrorPolicyUserRole, Category: api.CategoryUser},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			input := api.PolicyUserInviteCreate{
+				Email: "invitee_" + randStr(5) + "@example.org",
+				Name:  "Invitee",
+				Role:  tt.role,
+			}
+			err := policy.NewTeamInvite(ms.DB, input, inviter)
+			if tt.wantErr != nil {
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+
+			var invite PolicyUserInvite
+			ms.NoError(invite.FindByEmailAndPolicyID(ms.DB, input.Email, policy.ID))
+			ms.Equal(tt.wantRole, invite.Role)
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/pop/v6"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+var renewalPreviewHeader = []string{
+	"Policy", "Entry Type", "Risk Category", "Item", "Paid Through", "Premium", "Entry Amount", "Anomalies",
+}
+
+// PreviewRenewals lists the ledger entries that ProcessRenewals would create for the given date and billing period,
+// with the items in each entry and any anomalies. Nothing is saved.
+func (p *Policies) PreviewRenewals(tx *pop.Connection, date time.Time, billingPeriod int) (api.RenewalPreview, error) {
+	periodStart, paidThroughDate := renewalPeriod(date, billingPeriod)
+
+	preview := api.RenewalPreview{
+		PeriodStart:     periodStart.Format(domain.DateFormat),
+		PaidThroughDate: paidThroughDate.Format(domain.DateFormat),
+		Entries:         []api.RenewalPreviewEntry{},
+		Anomalies:       []api.RenewalPreviewItem{},
+	}
+
+	for i := range *p {
+		policy := &(*p)[i]
+		entries, err := policy.previewRenewals(tx, date, billingPeriod, periodStart)
+		if err != nil {
+			return preview, fmt.Errorf("error previewing renewals for policy %s: %w", policy.ID, err)
+		}
+		if len(entries) > 0 {
+			preview.PolicyCount++
+		}
+
+		for _, e := range entries {
+			preview.EntryCount++
+			if e.Type == api.LedgerEntryType(LedgerEntryTypeNoClaimsDiscount) {
+				preview.TotalDiscount += e.Amount
+				continue
+			}
+			preview.TotalPremium -= e.Amount
+			for _, item := range e.Items {
+				preview.ItemCount++
+				if len(item.Anomalies) > 0 {
+					preview.Anomalies = append(preview.Anomalies, item)
+				}
+			}
+		}
+		preview.Entries = append(preview.Entries, entries...)
+	}
+	preview.Net = preview.TotalPremium - preview.TotalDiscount
+
+	return preview, nil
+}
+
+// previewRenewals returns the renewal and discount entries that ProcessRenewals would create for the policy, sorted
+// by risk category
+func (p *Policy) previewRenewals(tx *pop.Connection, date time.Time, billingPeriod int, periodStart time.Time,
+) ([]api.RenewalPreviewEntry, error) {
+	items, err := p.itemsToRenew(tx, date, billingPeriod)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+
+	charges, err := p.renewalCharges(tx, items, periodStart)
+	if err != nil {
+		return nil, err
+	}
+
+	previousPeriodEnd := periodStart.AddDate(0, 0, -1)
+
+	entries := make([]api.RenewalPreviewEntry, 0, 2*len(charges))
+	for _, c := range charges {
+		e := api.RenewalPreviewEntry{
+			PolicyID:   p.ID,
+			PolicyName: p.Name,
+			Type:       api.LedgerEntryType(LedgerEntryTypeCoverageRenewal),
+			Amount:     -c.Premium,
+		}
+
+		for i := range c.Items {
+			item := &c.Items[i]
+			item.LoadRiskCategory(tx, false)
+			e.RiskCategory = item.RiskCategory.Name
+
+			previewItem := api.RenewalPreviewItem{
+				PolicyID:        p.ID,
+				ItemID:          item.ID,
+				ItemName:        item.Name,
+				RiskCategory:    item.RiskCategory.Name,
+				PaidThroughDate: item.PaidThroughDate.Format(domain.DateFormat),
+				Premium:         c.ItemPremiums[i],
+				Anomalies:       []api.RenewalAnomaly{},
+			}
+			if c.ItemPremiums[i] == 0 {
+				previewItem.Anomalies = append(previewItem.Anomalies, api.RenewalAnomalyZeroPremium)
+			}
+			if item.PaidThroughDate.Before(previousPeriodEnd) {
+				previewItem.Anomalies = append(previewItem.Anomalies, api.RenewalAnomalyPaidThroughLapsed)
+			}
+			if item.CoverageEndDate.Valid && item.CoverageEndDate.Time.Before(periodStart) {
+				previewItem.Anomalies = append(previewItem.Anomalies, api.RenewalAnomalyCoverageEnded)
+			}
+			e.Items = append(e.Items, previewItem)
+		}
+		entries = append(entries, e)
+
+		if c.Discount <= 0 {
+			continue
+		}
+		entries = append(entries, api.RenewalPreviewEntry{
+			PolicyID:     p.ID,
+			PolicyName:   p.Name,
+			Type:         api.LedgerEntryType(LedgerEntryTypeNoClaimsDiscount),
+			RiskCategory: e.RiskCategory,
+			Amount:       c.Discount,
+			Items:        []api.RenewalPreviewItem{},
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].RiskCategory != entries[j].RiskCategory {
+			return entries[i].RiskCategory < entries[j].RiskCategory
+		}
+		return entries[i].Type == api.LedgerEntryType(LedgerEntryTypeCoverageRenewal)
+	})
+	return entries, nil
+}
+
+// NewRenewalPreviewFile renders a renewal preview as CSV and stores it as an unlinked File. The label names the
+// billing period in the file name, e.g. "annual".
+func NewRenewalPreviewFile(ctx context.Context, preview api.RenewalPreview, label string) (File, error) {
+	content, err := renderRenewalPreviewCSV(preview)
+	if err != nil {
+		return File{}, api.NewAppError(err, api.ErrorUnableToStoreFile, api.CategoryInternal)
+	}
+
+	f := File{
+		Name: fmt.Sprintf("%s_%s_renewal_preview_%s.csv",
+			domain.Env.AppName, label, preview.PeriodStart),
+		Content:     content,
+		ContentType: domain.ContentCSV,
+		CreatedByID: CurrentUser(ctx).ID,
+	}
+	if err := f.Store(Tx(ctx)); err != nil {
+		return File{}, err
+	}
+	return f, nil
+}
+
+// renderRenewalPreviewCSV writes one row per item of each renewal entry and one row per discount entry, followed by
+// the totals
+func renderRenewalPreviewCSV(preview api.RenewalPreview) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	records := [][]string{renewalPreviewHeader}
+	for _, e := range preview.Entries {
+		if len(e.Items) == 0 {
+			records = append(records, []string{
+				e.PolicyName, string(e.Type), e.RiskCategory, "", "", "", e.Amount.String(), "",
+			})
+			continue
+		}
+		for _, item := range e.Items {
+			anomalies := make([]string, len(item.Anomalies))
+			for i, a := range item.Anomalies {
+				anomalies[i] = string(a)
+			}
+			records = append(records, []string{
+				e.PolicyName,
+				string(e.Type),
+				e.RiskCategory,
+				item.ItemName,
+				item.PaidThroughDate,
+				item.Premium.String(),
+				e.Amount.String(),
+				strings.Join(anomalies, " "),
+			})
+		}
+	}
+
+	records = append(records, []string{},
+		[]string{"Policies", fmt.Sprint(preview.PolicyCount)},
+		[]string{"Items", fmt.Sprint(preview.ItemCount)},
+		[]string{"Entries", fmt.Sprint(preview.EntryCount)},
+		[]string{"Total premium", preview.TotalPremium.String()},
+		[]string{"Total discount", preview.TotalDiscount.String()},
+		[]string{"Net", preview.Net.String()},
+	)
+
+	if err := w.WriteAll(records); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+func (ms *ModelSuite) TestPolicies_PreviewRenewals() {
+	now := time.Now().UTC()
+	year := now.Year()
+
+	const annualItems = 4
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: annualItems})
+	f.Items[2].RiskCategoryID = RiskCategoryMobileID()
+	f.Items[3].RiskCategoryID = RiskCategoryMobileID()
+	for i := range f.Items {
+		f.Items[i].PaidThroughDate = domain.EndOfYear(year - 1)
+		f.Items[i].CoverageAmount = 1000
+	}
+	f.Items[3].PaidThroughDate = domain.EndOfYear(year - 2)
+	for i := range f.Items {
+		UpdateItemStatus(ms.DB, f.Items[i], api.ItemCoverageStatusApproved, "")
+	}
+
+	got, err := f.Policies.PreviewRenewals(ms.DB, domain.EndOfYear(year), domain.BillingPeriodAnnual)
+	ms.NoError(err)
+
+	ms.Equal(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC).Format(domain.DateFormat), got.PeriodStart)
+	ms.Equal(domain.EndOfYear(year).Format(domain.DateFormat), got.PaidThroughDate)
+	ms.Equal(1, got.PolicyCount)
+	ms.Equal(annualItems, got.ItemCount)
+	ms.Equal(2, got.EntryCount, "should be one renewal entry per risk category")
+	ms.Equal(api.Currency(20*annualItems), got.TotalPremium)
+	ms.Equal(got.TotalPremium-got.TotalDiscount, got.Net)
+
+	ms.Len(got.Entries, 2)
+	for _, e := range got.Entries {
+		ms.Equal(api.LedgerEntryType(LedgerEntryTypeCoverageRenewal), e.Type)
+		ms.Equal(api.Currency(-20*annualItems/2), e.Amount)
+		ms.Len(e.Items, 2)
+	}
+
+	ms.Len(got.Anomalies, 1)
+	ms.Equal(f.Items[3].ID, got.Anomalies[0].ItemID)
+	ms.Equal([]api.RenewalAnomaly{api.RenewalAnomalyPaidThroughLapsed}, got.Anomalies[0].Anomalies)
+
+	count, err := ms.DB.Where("policy_id = ?", f.Policies[0].ID).Count(&LedgerEntries{})
+	ms.NoError(err)
+	ms.Equal(0, count, "preview should not create ledger entries")
+
+	n, err := CountItemsToRenew(ms.DB, domain.EndOfYear(year), domain.BillingPeriodAnnual)
+	ms.NoError(err)
+	ms.Equal(annualItems, n, "preview should not update paid-through dates")
+
+	csv, err := renderRenewalPreviewCSV(got)
+	ms.NoError(err)
+	lines := strings.Split(strings.TrimSpace(string(csv)), "\n")
+	ms.Equal(strings.Join(renewalPreviewHeader, ","), lines[0])
+	ms.Len(lines, 1+annualItems+7, "should have a header, one line per item and the totals")
+	ms.Contains(string(csv), string(api.RenewalAnomalyPaidThroughLapsed))
+}
@@ -2,9 +2,12 @@ package models
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/gobuffalo/events"
+	"github.com/gobuffalo/nulls"
 	"github.com/gobuffalo/pop/v6"
 	"github.com/gobuffalo/validate/v3"
 	"github.com/gofrs/uuid"
@@ -16,13 +19,29 @@ import (
 // Items is a slice of Item objects
 type Strikes []Strike
 
+// strikeExpiryNoticeMonths is how long after a strike ages out its expiry is still worth a notification
+const strikeExpiryNoticeMonths = 1
+
+var ValidStrikeStatuses = map[api.StrikeStatus]struct{}{
+	api.StrikeStatusProposed: {},
+	api.StrikeStatusActive:   {},
+	api.StrikeStatusRejected: {},
+}
+
 // Strike model
 type Strike struct {
-	ID          uuid.UUID `db:"id"`
-	Description string    `db:"description"`
-	PolicyID    uuid.UUID `db:"policy_id" validate:"required"`
-	CreatedAt   time.Time `db:"created_at"`
-	UpdatedAt   time.Time `db:"updated_at"`
+	ID             uuid.UUID        `db:"id"`
+	Description    string           `db:"description"`
+	PolicyID       uuid.UUID        `db:"policy_id" validate:"required"`
+	Status         api.StrikeStatus `db:"status" validate:"strikeStatus"`
+	ClaimID        nulls.UUID       `db:"claim_id"`
+	StrikeRuleID   nulls.UUID       `db:"strike_rule_id"`
+	ExpiryNotified bool             `db:"expiry_notified"`
+	ActiveAt       nulls.Time       `db:"active_at"` // the time the strike was put in force
+	CreatedAt      time.Time        `db:"created_at"`
+	UpdatedAt      time.Time        `db:"updated_at"`
+
+	Policy Policy `belongs_to:"policies" validate:"-"`
 }
 
 // Validate gets run every time you call pop.ValidateAndSave, pop.ValidateAndCreate, or pop.ValidateAndUpdate
@@ -30,8 +49,27 @@ func (s *Strike) Validate(tx *pop.Connection) (*validate.Errors, error) {
 	return validateModel(s), nil
 }
 
+// Create stores the strike, in force unless another status is given, and triggers a notification to the
+// policy members, or to the stewards if it is only proposed
 func (s *Strike) Create(tx *pop.Connection) error {
-	return create(tx, s)
+	if s.Status == "" {
+		s.Status = api.StrikeStatusActive
+	}
+	if s.Status == api.StrikeStatusActive && !s.ActiveAt.Valid {
+		s.ActiveAt = nulls.NewTime(time.Now().UTC())
+	}
+
+	if err := create(tx, s); err != nil {
+		return err
+	}
+
+	switch s.Status {
+	case api.StrikeStatusActive:
+		s.emitEvent(domain.EventApiStrikeCreated, "Strike created")
+	case api.StrikeStatusProposed:
+		s.emitEvent(domain.EventApiStrikeProposed, "Strike proposed")
+	}
+	return nil
 }
 
 func (s *Strike) Update(ctx context.Context) error {
@@ -55,13 +93,71 @@ func (s *Strike) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permissio
 	return actor.IsAdmin()
 }
 
+// Approve puts a proposed strike in force. Its lifetime starts now, not when it was proposed.
+func (s *Strike) Approve(ctx context.Context) error {
+	if s.Status != api.StrikeStatusProposed {
+		err := fmt.Errorf("cannot approve a strike with status %s", s.Status)
+		return api.NewAppError(err, api.ErrorStrikeStatus, api.CategoryUser)
+	}
+
+	s.Status = api.StrikeStatusActive
+	s.ActiveAt = nulls.NewTime(time.Now().UTC())
+	if err := s.Update(ctx); err != nil {
+		return err
+	}
+
+	s.emitEvent(domain.EventApiStrikeCreated, "Strike approved")
+	return nil
+}
+
+// Reject keeps a proposed strike from being put in force
+func (s *Strike) Reject(ctx context.Context) error {
+	if s.Status != api.StrikeStatusProposed {
+		err := fmt.Errorf("cannot reject a strike with status %s", s.Status)
+		return api.NewAppError(err, api.ErrorStrikeStatus, api.CategoryUser)
+	}
+
+	s.Status = api.StrikeStatusRejected
+	return s.Update(ctx)
+}
+
+func (s *Strike) emitEvent(kind, message string) {
+	emitEvent(events.Event{
+		Kind:    kind,
+		Message: fmt.Sprintf("%s: %s  ID: %s", message, s.Description, s.ID),
+		Payload: events.Payload{domain.EventPayloadID: s.ID},
+	})
+}
+
+// LoadPolicy - a simple wrapper method for loading the policy on the struct
+func (s *Strike) LoadPolicy(tx *pop.Connection, reload bool) {
+	if s.Policy.ID == uuid.Nil || reload {
+		if err := tx.Load(s, "Policy"); err != nil {
+			panic("database error loading Strike.Policy, " + err.Error())
+		}
+	}
+}
+
+// ExpiresAt returns the time the strike is no longer counted in the deductible
+func (s *Strike) ExpiresAt() time.Time {
+	start := s.CreatedAt
+	if s.ActiveAt.Valid {
+		start = s.ActiveAt.Time
+	}
+	return start.AddDate(0, domain.Env.StrikeLifetimeMonths, 0)
+}
+
 func (s *Strike) ConvertToAPI() api.Strike {
 	apiStrike := api.Strike{
-		ID:          s.ID,
-		Description: s.Description,
-		PolicyID:    s.PolicyID,
-		CreatedAt:   s.CreatedAt,
-		UpdatedAt:   s.UpdatedAt,
+		ID:           s.ID,
+		Description:  s.Description,
+		PolicyID:     s.PolicyID,
+		Status:       s.Status,
+		ClaimID:      convertUUIDToAPI(s.ClaimID),
+		StrikeRuleID: convertUUIDToAPI(s.StrikeRuleID),
+		ActiveAt:     convertTimeToAPI(s.ActiveAt),
+		CreatedAt:    s.CreatedAt,
+		UpdatedAt:    s.UpdatedAt,
 	}
 
 	return apiStrike
@@ -76,14 +172,47 @@ func (s *Strikes) ConvertToAPI(tx *pop.Connection) api.Strikes {
 	return apiStrikes
 }
 
-// RecentForPolicy gets the strikes that have a matching policy_id and are newer than a year
-// before the cutOff date and no more recent than the cutOff date
+// RecentForPolicy gets the active strikes that have a matching policy_id and were put in force less than the strike
+// lifetime before the cutOff date, and not after the cutOff date
 func (s *Strikes) RecentForPolicy(tx *pop.Connection, policyID uuid.UUID, cutOff time.Time) error {
 	yearBefore := cutOff.AddDate(0, -domain.Env.StrikeLifetimeMonths, 0)
 
-	if err := tx.Where("policy_id = ? AND created_at > ? AND created_at < ?",
-		policyID, yearBefore, cutOff).All(s); err != nil {
+	if err := tx.Where("policy_id = ? AND status = ? AND active_at > ? AND active_at < ?",
+		policyID, api.StrikeStatusActive, yearBefore, cutOff).All(s); err != nil {
 	}
 
 	return nil
 }
+
+// FindProposed gets the strikes that are waiting for review by a steward, oldest first
+func (s *Strikes) FindProposed(tx *pop.Connection) error {
+	err := tx.Where("status = ?", api.StrikeStatusProposed).Order("created_at asc").All(s)
+	return appErrorFromDB(err, api.ErrorQueryFailure)
+}
+
+// NotifyExpiredStrikes triggers a notification to the policy members for each strike that has aged out since
+// the last time this was run. Strikes that aged out more than strikeExpiryNoticeMonths ago, e.g. before expiry
+// notices existed, are marked as notified without a notification. It returns the number of notifications.
+func NotifyExpiredStrikes(tx *pop.Connection, now time.Time) (int, error) {
+	expiredActiveAt := now.AddDate(0, -domain.Env.StrikeLifetimeMonths, 0)
+	var strikes Strikes
+	if err := tx.Where("status = ? AND NOT expiry_notified AND active_at <= ?",
+		api.StrikeStatusActive, expiredActiveAt).All(&strikes); err != nil {
+		return 0, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	noticeActiveAt := expiredActiveAt.AddDate(0, -strikeExpiryNoticeMonths, 0)
+	notified := 0
+	for i := range strikes {
+		strikes[i].ExpiryNotified = true
+		if err := update(tx, &strikes[i]); err != nil {
+			return notified, err
+		}
+		if strikes[i].ActiveAt.Time.Before(noticeActiveAt) {
+			continue
+		}
+		strikes[i].emitEvent(domain.EventApiStrikeExpired, "Strike expired")
+		notified++
+	}
+	return notified, nil
+}
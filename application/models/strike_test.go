@@ -3,6 +3,9 @@ package models
 import (
 	"testing"
 	"time"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
 )
 
 func (ms *ModelSuite) TestStrikes_RecentForPolicy() {
@@ -69,3 +72,70 @@ func (ms *ModelSuite) TestStrikes_RecentForPolicy() {
 		})
 	}
 }
+
+func (ms *ModelSuite) TestStrike_Approve() {
+	f := CreatePolicyFixtures(ms.DB, FixturesConfig{})
+	policy := f.Policies[0]
+	ctx := CreateTestContext(CreateAdminUsers(ms.DB)[AppRoleSteward])
+
+	proposed := Strike{Description: "proposed", PolicyID: policy.ID, Status: api.StrikeStatusProposed}
+	ms.NoError(proposed.Create(ms.DB))
+
+	rejected := Strike{Description: "rejected", PolicyID: policy.ID, Status: api.StrikeStatusProposed}
+	ms.NoError(rejected.Create(ms.DB))
+
+	var pending Strikes
+	ms.NoError(pending.FindProposed(ms.DB))
+	ms.Len(pending, 2, "incorrect number of proposed strikes")
+
+	before := policy.DeductibleRate(ms.DB, time.Now().UTC().Add(time.Minute))
+	ms.Equal(domain.Env.DeductibleRate, before, "proposed strikes should not raise the deductible")
+
+	// the strike waited a long time for review
+	proposedAt := time.Now().UTC().AddDate(0, -domain.Env.StrikeLifetimeMonths, -1)
+	Must(ms.DB.RawQuery("UPDATE strikes SET created_at = ? WHERE id = ?", proposedAt, proposed.ID).Exec())
+	ms.NoError(proposed.FindByID(ms.DB, proposed.ID))
+	ms.False(proposed.ActiveAt.Valid, "a proposed strike should not be in force")
+
+	ms.NoError(proposed.Approve(ctx))
+	ms.Equal(api.StrikeStatusActive, proposed.Status)
+	ms.WithinDuration(time.Now().UTC().AddDate(0, domain.Env.StrikeLifetimeMonths, 0), proposed.ExpiresAt(), time.Minute,
+		"the lifetime of the strike should start when it is approved")
+	ms.NoError(rejected.Reject(ctx))
+	ms.Equal(api.StrikeStatusRejected, rejected.Status)
+
+	ms.EqualAppError(api.AppError{Key: api.ErrorStrikeStatus, Category: api.CategoryUser}, proposed.Approve(ctx))
+	ms.EqualAppError(api.AppError{Key: api.ErrorStrikeStatus, Category: api.CategoryUser}, rejected.Approve(ctx))
+
+	ms.NoError(pending.FindProposed(ms.DB))
+	ms.Len(pending, 0, "no strikes should be waiting for review")
+
+	after := policy.DeductibleRate(ms.DB, time.Now().UTC().Add(time.Minute))
+	ms.Equal(domain.Env.DeductibleRate+domain.Env.DeductibleIncrease, after, "only the approved strike should count")
+}
+
+func (ms *ModelSuite) TestNotifyExpiredStrikes() {
+	f := CreatePolicyFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 2})
+
+	now := time.Now().UTC()
+	expired := now.AddDate(0, -domain.Env.StrikeLifetimeMonths, -1)
+	longExpired := now.AddDate(-1, -domain.Env.StrikeLifetimeMonths, 0)
+	strikes := CreateStrikeFixtures(ms.DB, f.Policies, [][]*time.Time{{&expired, nil, &longExpired}, {&expired}})
+
+	rejected := strikes[3]
+	rejected.Status = api.StrikeStatusRejected
+	ms.NoError(ms.DB.Update(&rejected))
+
+	n, err := NotifyExpiredStrikes(ms.DB, now)
+	ms.NoError(err)
+	ms.Equal(1, n, "only the active expired strike should be notified")
+
+	ms.NoError(strikes[0].FindByID(ms.DB, strikes[0].ID))
+	ms.True(strikes[0].ExpiryNotified, "expired strike should be marked as notified")
+	ms.NoError(strikes[2].FindByID(ms.DB, strikes[2].ID))
+	ms.True(strikes[2].ExpiryNotified, "long expired strike should be marked as notified without a notification")
+
+	n, err = NotifyExpiredStrikes(ms.DB, now)
+	ms.NoError(err)
+	ms.Equal(0, n, "expired strikes should only be notified once")
+}
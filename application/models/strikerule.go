@@ -0,0 +1,201 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v6"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+type StrikeRules []StrikeRule
+
+// StrikeRule creates or proposes a strike on a policy when one of its claims is approved. A claim matches a rule
+// if it matches all the conditions of the rule that are set.
+type StrikeRule struct {
+	ID                  uuid.UUID             `db:"id"`
+	Name                string                `db:"name" validate:"required"`
+	IncidentType        api.ClaimIncidentType `db:"incident_type" validate:"claimIncidentType"`
+	DescriptionContains string                `db:"description_contains"`
+	MinClaims           int                   `db:"min_claims" validate:"min=0"`
+	WindowMonths        int                   `db:"window_months" validate:"min=0"`
+	AutoCreate          bool                  `db:"auto_create"`
+	IsActive            bool                  `db:"is_active"`
+	CreatedAt           time.Time             `db:"created_at"`
+	UpdatedAt           time.Time             `db:"updated_at"`
+}
+
+// Validate gets run every time you call pop.ValidateAndSave, pop.ValidateAndCreate, or pop.ValidateAndUpdate
+func (r *StrikeRule) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validateModel(r), nil
+}
+
+func (r *StrikeRule) Create(tx *pop.Connection) error {
+	return create(tx, r)
+}
+
+func (r *StrikeRule) Update(tx *pop.Connection) error {
+	return update(tx, r)
+}
+
+func (r *StrikeRule) Destroy(tx *pop.Connection) error {
+	return destroy(tx, r)
+}
+
+func (r *StrikeRule) GetID() uuid.UUID {
+	return r.ID
+}
+
+func (r *StrikeRule) FindByID(tx *pop.Connection, id uuid.UUID) error {
+	return tx.Find(r, id)
+}
+
+// IsActorAllowedTo ensures the actor is an admin
+func (r *StrikeRule) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permission, sub SubResource, req *http.Request) bool {
+	return actor.IsAdmin()
+}
+
+// FindAll loads all the strike rules, ordered by name
+func (r *StrikeRules) FindAll(tx *pop.Connection) error {
+	err := tx.Order("name asc").All(r)
+	return appErrorFromDB(err, api.ErrorQueryFailure)
+}
+
+// NewStrikeRule creates a strike rule from the API input
+func NewStrikeRule(ctx context.Context, input api.StrikeRuleInput) (StrikeRule, error) {
+	var rule StrikeRule
+	if err := rule.setFromInput(input); err != nil {
+		return StrikeRule{}, err
+	}
+	if err := rule.Create(Tx(ctx)); err != nil {
+		return StrikeRule{}, err
+	}
+	return rule, nil
+}
+
+// UpdateFromInput replaces the conditions of the rule with those of the API input
+func (r *StrikeRule) UpdateFromInput(ctx context.Context, input api.StrikeRuleInput) error {
+	if err := r.setFromInput(input); err != nil {
+		return err
+	}
+	return r.Update(Tx(ctx))
+}
+
+func (r *StrikeRule) setFromInput(input api.StrikeRuleInput) error {
+	if input.IncidentType == "" && strings.TrimSpace(input.DescriptionContains) == "" && input.MinClaims < 2 {
+		err := errors.New("a strike rule must have an incident type, description text, or a minimum of 2 claims")
+		return api.NewAppError(err, api.ErrorStrikeRuleInvalid, api.CategoryUser)
+	}
+	if input.MinClaims > 1 && input.WindowMonths < 1 {
+		err := errors.New("a strike rule with a minimum number of claims must have a window of at least 1 month")
+		return api.NewAppError(err, api.ErrorStrikeRuleInvalid, api.CategoryUser)
+	}
+
+	r.Name = input.Name
+	r.IncidentType = input.IncidentType
+	r.DescriptionContains = strings.TrimSpace(input.DescriptionContains)
+	r.MinClaims = input.MinClaims
+	r.WindowMonths = input.WindowMonths
+	r.AutoCreate = input.AutoCreate
+	r.IsActive = input.IsActive
+	return nil
+}
+
+// matches returns true if the claim matches all the conditions of the rule that are set
+func (r *StrikeRule) matches(tx *pop.Connection, claim Claim) (bool, error) {
+	if r.IncidentType != "" && r.IncidentType != claim.IncidentType {
+		return false, nil
+	}
+
+	if r.DescriptionContains != "" &&
+		!strings.Contains(strings.ToLower(claim.IncidentDescription), strings.ToLower(r.DescriptionContains)) {
+		return false, nil
+	}
+
+	if r.MinClaims > 1 {
+		windowStart := claim.IncidentDate.AddDate(0, -r.WindowMonths, 0)
+		n, err := tx.Where("policy_id = ? AND status IN (?, ?) AND incident_date > ? AND incident_date <= ?",
+			claim.PolicyID, api.ClaimStatusApproved, api.ClaimStatusPaid, windowStart, claim.IncidentDate).
+			Count(Claim{})
+		if err != nil {
+			return false, err
+		}
+		if n < r.MinClaims {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// applyStrikeRules adds a strike to the claim's policy if the claim matches an active strike rule. Rules that
+// create strikes in force take precedence over rules that only propose them. A claim gets at most one strike.
+func (c *Claim) applyStrikeRules(tx *pop.Connection) error {
+	var rules StrikeRules
+	if err := tx.Where("is_active = true").Order("auto_create desc, name asc").All(&rules); err != nil {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	if n, err := tx.Where("claim_id = ?", c.ID).Count(Strike{}); err != nil {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	} else if n > 0 {
+		return nil
+	}
+
+	for _, rule := range rules {
+		matched, err := rule.matches(tx, *c)
+		if err != nil {
+			return appErrorFromDB(err, api.ErrorQueryFailure)
+		}
+		if !matched {
+			continue
+		}
+
+		strike := Strike{
+			Description:  fmt.Sprintf("%s (claim %s)", rule.Name, c.ReferenceNumber),
+			PolicyID:     c.PolicyID,
+			Status:       api.StrikeStatusProposed,
+			ClaimID:      nulls.NewUUID(c.ID),
+			StrikeRuleID: nulls.NewUUID(rule.ID),
+		}
+		if rule.AutoCreate {
+			strike.Status = api.StrikeStatusActive
+		}
+		return strike.Create(tx)
+	}
+	return nil
+}
+
+func (r *StrikeRule) ConvertToAPI() api.StrikeRule {
+	return api.StrikeRule{
+		ID:                  r.ID,
+		Name:                r.Name,
+		IncidentType:        r.IncidentType,
+		DescriptionContains: r.DescriptionContains,
+		MinClaims:           r.MinClaims,
+		WindowMonths:        r.WindowMonths,
+		AutoCreate:          r.AutoCreate,
+		IsActive:            r.IsActive,
+		CreatedAt:           r.CreatedAt,
+		UpdatedAt:           r.UpdatedAt,
+	}
+}
+
+func (r *StrikeRules) ConvertToAPI() api.StrikeRules {
+	rules := make(api.StrikeRules, len(*r))
+	for i := range *r {
+		rules[i] = (*r)[i].ConvertToAPI()
+	}
+	return rules
+}
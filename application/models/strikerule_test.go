@@ -0,0 +1,132 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+func (ms *ModelSuite) TestNewStrikeRule() {
+	ctx := CreateTestContext(CreateAdminUsers(ms.DB)[AppRoleSteward])
+
+	tests := []struct {
+		name    string
+		input   api.StrikeRuleInput
+		wantErr *api.AppError
+	}{
+		{
+			name:    "no conditions",
+			input:   api.StrikeRuleInput{Name: "everything", MinClaims: 1},
+			wantErr: &api.AppError{Key: api.ErrorStrikeRuleInvalid, Category: api.CategoryUser},
+		},
+		{
+			name:    "min claims without a window",
+			input:   api.StrikeRuleInput{Name: "frequent", MinClaims: 3},
+			wantErr: &api.AppError{Key: api.ErrorStrikeRuleInvalid, Category: api.CategoryUser},
+		},
+		{
+			name: "good",
+			input: api.StrikeRuleInput{
+				Name:                "unattended theft",
+				IncidentType:        api.ClaimIncidentTypeTheft,
+				DescriptionContains: " unattended ",
+				IsActive:            true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			got, err := NewStrikeRule(ctx, tt.input)
+			if tt.wantErr != nil {
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+			ms.Equal(tt.input.Name, got.Name)
+			ms.Equal("unattended", got.DescriptionContains, "description text should be trimmed")
+		})
+	}
+}
+
+func (ms *ModelSuite) TestClaim_applyStrikeRules() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 3, ClaimsPerPolicy: 2})
+	ctx := CreateTestContext(CreateAdminUsers(ms.DB)[AppRoleSteward])
+
+	theftRule, err := NewStrikeRule(ctx, api.StrikeRuleInput{
+		Name:                "Unattended theft",
+		IncidentType:        api.ClaimIncidentTypeTheft,
+		DescriptionContains: "unattended",
+		IsActive:            true,
+	})
+	ms.NoError(err)
+
+	frequentRule, err := NewStrikeRule(ctx, api.StrikeRuleInput{
+		Name:         "Frequent claims",
+		MinClaims:    2,
+		WindowMonths: 12,
+		AutoCreate:   true,
+		IsActive:     true,
+	})
+	ms.NoError(err)
+
+	_, err = NewStrikeRule(ctx, api.StrikeRuleInput{
+		Name:         "Inactive",
+		IncidentType: api.ClaimIncidentTypePhysicalDamage,
+		AutoCreate:   true,
+	})
+	ms.NoError(err)
+
+	theft := f.Policies[0].Claims[0]
+	theft.IncidentType = api.ClaimIncidentTypeTheft
+	theft.IncidentDescription = "Laptop was left Unattended in a cafe"
+	ms.NoError(ms.DB.Update(&theft))
+	theft = UpdateClaimStatus(ms.DB, theft, api.ClaimStatusApproved, "")
+
+	frequent := UpdateClaimStatus(ms.DB, f.Policies[1].Claims[0], api.ClaimStatusPaid, "")
+	frequent = UpdateClaimStatus(ms.DB, f.Policies[1].Claims[1], api.ClaimStatusApproved, "")
+
+	single := UpdateClaimStatus(ms.DB, f.Policies[2].Claims[0], api.ClaimStatusApproved, "")
+
+	tests := []struct {
+		name       string
+		claim      Claim
+		wantRuleID string
+		wantStatus api.StrikeStatus
+	}{
+		{
+			name:       "unattended theft",
+			claim:      theft,
+			wantRuleID: theftRule.ID.String(),
+			wantStatus: api.StrikeStatusProposed,
+		},
+		{
+			name:       "frequent claims",
+			claim:      frequent,
+			wantRuleID: frequentRule.ID.String(),
+			wantStatus: api.StrikeStatusActive,
+		},
+		{
+			name:  "no match",
+			claim: single,
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			ms.NoError(tt.claim.applyStrikeRules(ms.DB))
+
+			// applying the rules again must not add another strike
+			ms.NoError(tt.claim.applyStrikeRules(ms.DB))
+
+			var strikes Strikes
+			ms.NoError(ms.DB.Where("claim_id = ?", tt.claim.ID).All(&strikes))
+			if tt.wantRuleID == "" {
+				ms.Len(strikes, 0, "claim should not have a strike")
+				return
+			}
+			ms.Len(strikes, 1, "claim should have one strike")
+			ms.Equal(tt.wantRuleID, strikes[0].StrikeRuleID.UUID.String(), "incorrect strike rule")
+			ms.Equal(tt.wantStatus, strikes[0].Status, "incorrect strike status")
+			ms.Equal(tt.claim.PolicyID, strikes[0].PolicyID, "incorrect policy")
+		})
+	}
+}
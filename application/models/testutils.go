@@ -612,6 +612,38 @@ func DestroyAll() {
 	var ledgerReports LedgerReports
 	destroyTable(&ledgerReports)
 
+	// delete all Certificates
+	var certificates Certificates
+	destroyTable(&certificates)
+
+	// delete all ClaimReserveReports
+	var claimReserveReports ClaimReserveReports
+	destroyTable(&claimReserveReports)
+
+	// delete all PolicyStatements
+	var policyStatements PolicyStatements
+	destroyTable(&policyStatements)
+
+	// delete all CoverageLimits
+	var coverageLimits CoverageLimits
+	destroyTable(&coverageLimits)
+
+	// delete all StrikeRules
+	var strikeRules StrikeRules
+	destroyTable(&strikeRules)
+
+	// delete all JobSchedules and JobRuns
+	var jobSchedules JobSchedules
+	destroyTable(&jobSchedules)
+
+	// delete all FiscalPeriods
+	var fiscalPeriods FiscalPeriods
+	destroyTable(&fiscalPeriods)
+
+	// delete all LedgerAdjustments
+	var ledgerAdjustments LedgerAdjustments
+	destroyTable(&ledgerAdjustments)
+
 	// delete all Files and ClaimFiles
 	var files Files
 	destroyTable(&files)
@@ -925,8 +957,11 @@ func CreateStrikeFixtures(tx *pop.Connection, policies Policies, dates [][]*time
 
 			if dates[i][j] != nil {
 				// Merely calling the db.Update function doesn't overwrite the created_at value
-				q := tx.RawQuery("Update strikes SET created_at = ? WHERE id = ?", dates[i][j], strike.ID)
+				q := tx.RawQuery("Update strikes SET created_at = ?, active_at = ? WHERE id = ?",
+					dates[i][j], dates[i][j], strike.ID)
 				Must(q.Exec())
+				strike.CreatedAt = *dates[i][j]
+				strike.ActiveAt = nulls.NewTime(*dates[i][j])
 			}
 			strikes = append(strikes, strike)
 		}
@@ -961,6 +996,18 @@ func CreateUniqueInvite(createdAt time.Time, id uuid.UUID) PolicyUserInvite {
 		InviteeName:  "Test User" + randomStr,
 		InviterName:  "Tester" + randomStr,
 		InviterEmail: "test" + randomStr + "@example.org",
+		Role:         api.PolicyUserRoleMember,
 		CreatedAt:    createdAt,
 	}
 }
+
+// StubHouseholdIDLookup is a HouseholdIDLookup for tests. Staff IDs that are not in the map are not found,
+// and staff IDs that map to "error" return an error.
+type StubHouseholdIDLookup map[string]string
+
+func (s StubHouseholdIDLookup) LookupHouseholdID(staffID string) (string, error) {
+	if s[staffID] == "error" {
+		return "", errors.New("household ID lookup failed for staff ID " + staffID)
+	}
+	return s[staffID], nil
+}
@@ -281,15 +281,16 @@ func (u *User) ConvertToPolicyMember(polUserID uuid.UUID) api.PolicyMember {
 	}
 }
 
-func (u *Users) ConvertToPolicyMembers(polUserIDs []uuid.UUID) api.PolicyMembers {
+func (u *Users) ConvertToPolicyMembers(polUsers PolicyUsers) api.PolicyMembers {
 	userCount := len(*u)
-	puCount := len(polUserIDs)
+	puCount := len(polUsers)
 	if puCount != userCount {
-		panic(fmt.Sprintf("number of polUserIDs: %d must match number of users: %d", puCount, userCount))
+		panic(fmt.Sprintf("number of polUsers: %d must match number of users: %d", puCount, userCount))
 	}
 	members := make(api.PolicyMembers, userCount)
 	for i, uu := range *u {
-		members[i] = uu.ConvertToPolicyMember(polUserIDs[i])
+		members[i] = uu.ConvertToPolicyMember(polUsers[i].ID)
+		members[i].Role = polUsers[i].Role
 	}
 
 	return members
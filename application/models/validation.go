@@ -19,13 +19,19 @@ var fieldValidators = map[string]func(validator.FieldLevel) bool{
 	"claimIncidentType":             validateClaimIncidentType,
 	"claimStatus":                   validateClaimStatus,
 	"claimFilePurpose":              validateClaimFilePurpose,
+	"fiscalPeriodStatus":            validateFiscalPeriodStatus,
+	"jobRunResult":                  validateJobRunResult,
+	"jobRunTrigger":                 validateJobRunTrigger,
 	"payoutOption":                  validatePayoutOption,
 	"policyDependentChildBirthYear": validatePolicyDependentChildBirthYear,
 	"policyDependentRelationship":   validatePolicyDependentRelationship,
 	"policyType":                    validatePolicyType,
+	"policyUserRole":                validatePolicyUserRole,
 	"itemCategoryStatus":            validateItemCategoryStatus,
 	"itemCoverageStatus":            validateItemCoverageStatus,
+	"ledgerAdjustmentStatus":        validateLedgerAdjustmentStatus,
 	"ledgerEntryRecordType":         validateLedgerEntryRecordType,
+	"strikeStatus":                  validateStrikeStatus,
 }
 
 func validateModel(m any) *validate.Errors {
@@ -108,6 +114,54 @@ func validatePolicyType(field validator.FieldLevel) bool {
 	return false
 }
 
+func validatePolicyUserRole(field validator.FieldLevel) bool {
+	if value, ok := field.Field().Interface().(api.PolicyUserRole); ok {
+		_, valid := ValidPolicyUserRoles[value]
+		return valid
+	}
+	return false
+}
+
+func validateFiscalPeriodStatus(field validator.FieldLevel) bool {
+	if value, ok := field.Field().Interface().(api.FiscalPeriodStatus); ok {
+		_, valid := ValidFiscalPeriodStatuses[value]
+		return valid
+	}
+	return false
+}
+
+func validateJobRunResult(field validator.FieldLevel) bool {
+	if value, ok := field.Field().Interface().(api.JobRunResult); ok {
+		_, valid := ValidJobRunResults[value]
+		return valid
+	}
+	return false
+}
+
+func validateJobRunTrigger(field validator.FieldLevel) bool {
+	if value, ok := field.Field().Interface().(api.JobRunTrigger); ok {
+		_, valid := ValidJobRunTriggers[value]
+		return valid
+	}
+	return false
+}
+
+func validateLedgerAdjustmentStatus(field validator.FieldLevel) bool {
+	if value, ok := field.Field().Interface().(api.LedgerAdjustmentStatus); ok {
+		_, valid := ValidLedgerAdjustmentStatuses[value]
+		return valid
+	}
+	return false
+}
+
+func validateStrikeStatus(field validator.FieldLevel) bool {
+	if value, ok := field.Field().Interface().(api.StrikeStatus); ok {
+		_, valid := ValidStrikeStatuses[value]
+		return valid
+	}
+	return false
+}
+
 func validateAppRole(field validator.FieldLevel) bool {
 	if value, ok := field.Field().Interface().(UserAppRole); ok {
 		_, valid := validUserAppRoles[value]